@@ -3,11 +3,17 @@ package errors
 import "errors"
 
 var (
+	ErrBadRequest           = errors.New("the API rejected the request")
 	ErrInvalidIndex         = errors.New("the value is not a valid index for this type")
 	ErrLanguageNotSupported = errors.New("the selected language is not supported")
 	ErrMethodNotSupported   = errors.New("the selected method is not supported")
 	ErrMissingConfig        = errors.New("missing a required configuration value")
+	ErrMissingReferences    = errors.New("at least one reference text is required")
+	ErrRateLimited          = errors.New("the API rate limit was exceeded")
+	ErrServerError          = errors.New("the API had an internal server error")
+	ErrUnauthorized         = errors.New("the API key is missing, invalid, or not authorized")
 	ErrUndefinedValue       = errors.New("the mathematical operation has no defined value for the given arugments")
+	ErrUnequalLengthInputs  = errors.New("input arguments must have an equal number of elements")
 	ErrUnequalLengthVectors = errors.New("vector arguments must have an equal number of elements")
 )
 
@@ -27,3 +33,18 @@ var New func(text string) error = errors.New
 //
 // A non-nil error returned by Join implements the Unwrap() []error method.
 var Join func(errs ...error) error = errors.Join
+
+// Call to stdlib's [errors.Is]:
+//
+// Is reports whether any error in err's tree matches target. An error is
+// considered to match a target if it is equal to that target or if it
+// implements a method Is(error) bool such that Is(target) returns true.
+var Is func(err, target error) bool = errors.Is
+
+// Call to stdlib's [errors.As]:
+//
+// As finds the first error in err's tree that matches target, and if one is
+// found, sets target to that error value and returns true. Otherwise, it
+// returns false. It panics if target is not a non-nil pointer to either a type
+// that implements error, or to any interface type.
+var As func(err error, target any) bool = errors.As
@@ -0,0 +1,345 @@
+package evaluation
+
+import (
+	"math"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// ############################################################################
+// SmoothingMethod "enum"
+// ############################################################################
+
+// SmoothingMethod selects how a [BLEUScorer] handles n-gram orders which have
+// no matches, which would otherwise make the BLEU score zero. The methods are
+// numbered after Chen & Cherry (2014) and match NLTK's implementation.
+type SmoothingMethod uint8
+
+const (
+	// No smoothing; any n-gram order without matches makes the score zero.
+	SmoothingNone SmoothingMethod = iota
+	// Replaces zero match counts with a small epsilon (see
+	// [DefaultSmoothingEpsilon]).
+	SmoothingEpsilon
+	// Adds one to the match count and the total count for n-grams with n >= 2.
+	SmoothingAddOne
+	// For each n-gram order without matches, the precision is 1/(2^k * total)
+	// where k is the number of such orders seen so far (the NIST geometric
+	// sequence smoothing).
+	SmoothingExponential
+)
+
+// The epsilon used in place of zero match counts with [SmoothingEpsilon].
+const DefaultSmoothingEpsilon = 0.1
+
+// The default maximum n-gram order for BLEU (BLEU-4).
+const DefaultBLEUMaxN = 4
+
+// ############################################################################
+// BLEUScorer
+// ############################################################################
+
+/*
+BLEUScorer calculates the BLEU (bilingual evaluation understudy) score of
+candidate texts against one or more reference texts, as described by Papineni
+et al. (2002): the geometric mean of the modified (clipped) n-gram precisions
+multiplied by a brevity penalty; create with [NewBLEUScorer].
+
+Usage example:
+
+	// Create a BLEU-4 scorer with smoothing for short sentences
+	scorer, err := evaluation.NewBLEUScorer(
+		evaluation.BLEUScorerWithSmoothing(evaluation.SmoothingEpsilon),
+	)
+
+	// Score a single candidate against its references
+	score, err := scorer.Sentence("the cat sat on the mat", "the cat is on the mat")
+
+	// Score a corpus of candidates, each with its own references
+	score, err = scorer.Corpus(candidates, references)
+*/
+type BLEUScorer struct {
+	tokenizer tokenize.Tokenizer
+	stemmer   stem.Stemmer
+	maxN      int
+	smoothing SmoothingMethod
+	epsilon   float64
+	lowercase bool
+}
+
+// Returns a new [BLEUScorer] with the options set.
+//
+// Defaults:
+//   - Tokenizer: [tokenize.WhitespaceTokenizer]
+//   - Stemmer: [stem.NoOpStemmer]
+//   - MaxN: [DefaultBLEUMaxN] with uniform weights
+//   - Smoothing: [SmoothingNone]
+//   - Epsilon: [DefaultSmoothingEpsilon]
+//   - Lowercase: false
+func NewBLEUScorer(opts ...BLEUScorerOption) (scorer *BLEUScorer, err error) {
+	// Set defaults which are not zero values, then set options
+	scorer = &BLEUScorer{
+		maxN:    DefaultBLEUMaxN,
+		epsilon: DefaultSmoothingEpsilon,
+	}
+	for _, fn := range opts {
+		fn(scorer)
+	}
+
+	// Set defaults
+
+	if scorer.tokenizer == nil {
+		scorer.tokenizer = tokenize.NewWhitespaceTokenizer()
+	}
+
+	if scorer.stemmer == nil {
+		scorer.stemmer = &stem.NoOpStemmer{}
+	}
+
+	// Validate options
+	if scorer.smoothing > SmoothingExponential {
+		return nil, errors.ErrMethodNotSupported
+	}
+	if scorer.maxN < 1 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the maximum n-gram order must be positive"))
+	}
+	if scorer.epsilon <= 0.0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the smoothing epsilon must be positive"))
+	}
+
+	return scorer, nil
+}
+
+// Returns the [BLEUScorer]s configured [tokenize.Tokenizer].
+func (s *BLEUScorer) Tokenizer() tokenize.Tokenizer {
+	return s.tokenizer
+}
+
+// Returns the [BLEUScorer]s configured [stem.Stemmer].
+func (s *BLEUScorer) Stemmer() stem.Stemmer {
+	return s.stemmer
+}
+
+// Returns the [BLEUScorer]s configured maximum n-gram order.
+func (s *BLEUScorer) MaxN() int {
+	return s.maxN
+}
+
+// Returns the [BLEUScorer]s configured [SmoothingMethod].
+func (s *BLEUScorer) Smoothing() SmoothingMethod {
+	return s.smoothing
+}
+
+// Returns the [BLEUScorer]s configured smoothing epsilon.
+func (s *BLEUScorer) Epsilon() float64 {
+	return s.epsilon
+}
+
+// Returns true if the [BLEUScorer] lowercases tokens before matching.
+func (s *BLEUScorer) Lowercase() bool {
+	return s.lowercase
+}
+
+// Sentence returns the BLEU score in the range [0.0, 1.0] of a single candidate
+// text against one or more references. Returns [errors.ErrMissingReferences]
+// if no references are given.
+func (s *BLEUScorer) Sentence(candidate string, references ...string) (score float64, err error) {
+	return s.Corpus([]string{candidate}, [][]string{references})
+}
+
+// Corpus returns the corpus-level BLEU score in the range [0.0, 1.0] of the
+// candidates, where references[i] holds the references for candidates[i]. The
+// clipped n-gram counts and lengths are summed over the whole corpus before the
+// precisions and brevity penalty are calculated, so this is not the average of
+// the sentence-level scores. Returns [errors.ErrUnequalLengthInputs] if there
+// is not a set of references for each candidate, or
+// [errors.ErrMissingReferences] if any candidate has no references.
+func (s *BLEUScorer) Corpus(candidates []string, references [][]string) (score float64, err error) {
+	if len(candidates) != len(references) {
+		return 0.0, errors.ErrUnequalLengthInputs
+	}
+
+	var (
+		matches                    = make([]int, s.maxN)
+		totals                     = make([]int, s.maxN)
+		candidateLen, referenceLen int
+	)
+
+	for i, candidate := range candidates {
+		if len(references[i]) == 0 {
+			return 0.0, errors.ErrMissingReferences
+		}
+
+		var hyp []string
+		if hyp, err = prepareTokens(candidate, s.tokenizer, s.stemmer, s.lowercase); err != nil {
+			return 0.0, err
+		}
+
+		refs := make([][]string, 0, len(references[i]))
+		for _, reference := range references[i] {
+			var ref []string
+			if ref, err = prepareTokens(reference, s.tokenizer, s.stemmer, s.lowercase); err != nil {
+				return 0.0, err
+			}
+			refs = append(refs, ref)
+		}
+
+		for n := 1; n <= s.maxN; n++ {
+			match, total := ModifiedPrecision(hyp, refs, n)
+			matches[n-1] += match
+			totals[n-1] += total
+		}
+
+		candidateLen += len(hyp)
+		referenceLen += ClosestReferenceLength(len(hyp), refs)
+	}
+
+	// Without any unigram matches there is nothing to smooth
+	if matches[0] == 0 {
+		return 0.0, nil
+	}
+
+	precisions := s.smooth(matches, totals)
+	if precisions == nil {
+		return 0.0, nil
+	}
+
+	// Geometric mean of the precisions with uniform weights
+	var logSum float64
+	for _, p := range precisions {
+		logSum += math.Log(p)
+	}
+
+	return BrevityPenalty(candidateLen, referenceLen) * math.Exp(logSum/float64(s.maxN)), nil
+}
+
+// Returns the n-gram precisions after applying the configured smoothing, or
+// nil if any precision is zero and the score is therefore zero.
+func (s *BLEUScorer) smooth(matches, totals []int) (precisions []float64) {
+	precisions = make([]float64, len(matches))
+	divisor := 1.0
+	for i := range matches {
+		match, total := float64(matches[i]), float64(totals[i])
+		switch {
+		case s.smoothing == SmoothingAddOne && i > 0:
+			precisions[i] = (match + 1.0) / (total + 1.0)
+		case matches[i] > 0:
+			precisions[i] = match / total
+		case s.smoothing == SmoothingEpsilon:
+			precisions[i] = s.epsilon / total
+		case s.smoothing == SmoothingExponential:
+			divisor *= 2.0
+			precisions[i] = 1.0 / (divisor * total)
+		default:
+			return nil
+		}
+	}
+	return precisions
+}
+
+// ############################################################################
+// BLEU helpers
+// ############################################################################
+
+// ModifiedPrecision returns the clipped n-gram match count and the total n-gram
+// count of the candidate for BLEU. Each candidate n-gram count is clipped to
+// the maximum count of that n-gram in any one reference. The total is at least
+// one so that it can safely be used as a denominator.
+func ModifiedPrecision(candidate []string, references [][]string, n int) (matches, total int) {
+	counts := countNgrams(candidate, n)
+	maxRefCounts := make(map[string]int, len(counts))
+	for _, reference := range references {
+		refCounts := countNgrams(reference, n)
+		for gram := range counts {
+			maxRefCounts[gram] = max(maxRefCounts[gram], refCounts[gram])
+		}
+	}
+
+	for gram, count := range counts {
+		matches += min(count, maxRefCounts[gram])
+		total += count
+	}
+	return matches, max(1, total)
+}
+
+// ClosestReferenceLength returns the length of the reference closest in length
+// to the candidate; ties are broken in favor of the shorter reference.
+func ClosestReferenceLength(candidateLen int, references [][]string) (closest int) {
+	bestDiff := -1
+	for _, reference := range references {
+		diff := len(reference) - candidateLen
+		if diff < 0 {
+			diff = -diff
+		}
+		if bestDiff < 0 || diff < bestDiff || (diff == bestDiff && len(reference) < closest) {
+			bestDiff, closest = diff, len(reference)
+		}
+	}
+	return closest
+}
+
+// BrevityPenalty returns the BLEU brevity penalty for a candidate (or corpus)
+// length c and effective reference length r: 1 if c > r, otherwise
+// exp(1 - r/c); an empty candidate has a penalty of zero.
+func BrevityPenalty(candidateLen, referenceLen int) float64 {
+	if candidateLen > referenceLen {
+		return 1.0
+	}
+	if candidateLen == 0 {
+		return 0.0
+	}
+	return math.Exp(1.0 - float64(referenceLen)/float64(candidateLen))
+}
+
+// ############################################################################
+// BLEUScorerOption
+// ############################################################################
+
+// BLEUScorerOption functions modify a [BLEUScorer].
+type BLEUScorerOption func(s *BLEUScorer)
+
+// Sets the [tokenize.Tokenizer] used to split candidates and references into
+// tokens.
+func BLEUScorerWithTokenizer(tokenizer tokenize.Tokenizer) BLEUScorerOption {
+	return func(s *BLEUScorer) {
+		s.tokenizer = tokenizer
+	}
+}
+
+// Sets the [stem.Stemmer] applied to each token before matching.
+func BLEUScorerWithStemmer(stemmer stem.Stemmer) BLEUScorerOption {
+	return func(s *BLEUScorer) {
+		s.stemmer = stemmer
+	}
+}
+
+// Sets the maximum n-gram order; e.g. 4 for BLEU-4. The n-gram orders from 1 to
+// n are weighted uniformly.
+func BLEUScorerWithMaxN(n int) BLEUScorerOption {
+	return func(s *BLEUScorer) {
+		s.maxN = n
+	}
+}
+
+// Sets the [SmoothingMethod].
+func BLEUScorerWithSmoothing(method SmoothingMethod) BLEUScorerOption {
+	return func(s *BLEUScorer) {
+		s.smoothing = method
+	}
+}
+
+// Sets the epsilon used with [SmoothingEpsilon].
+func BLEUScorerWithEpsilon(epsilon float64) BLEUScorerOption {
+	return func(s *BLEUScorer) {
+		s.epsilon = epsilon
+	}
+}
+
+// Sets whether tokens are lowercased before matching.
+func BLEUScorerWithLowercase(lowercase bool) BLEUScorerOption {
+	return func(s *BLEUScorer) {
+		s.lowercase = lowercase
+	}
+}
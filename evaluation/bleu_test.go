@@ -0,0 +1,193 @@
+package evaluation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/evaluation"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// The example sentences from the NLTK BLEU documentation
+const (
+	hypothesis1 = "It is a guide to action which ensures that the military always obeys the commands of the party"
+	reference1a = "It is a guide to action that ensures that the military will forever heed Party commands"
+	reference1b = "It is the guiding principle which guarantees the military forces always being under the command of the Party"
+	reference1c = "It is the practical guide for the army always to heed the directions of the party"
+	hypothesis2 = "he read the book because he was interested in world history"
+	reference2a = "he was interested in world history because he read the book"
+)
+
+func TestNewBLEUScorer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		scorer, err := evaluation.NewBLEUScorer()
+		require.NoError(t, err)
+		require.NotNil(t, scorer)
+		require.IsType(t, &tokenize.WhitespaceTokenizer{}, scorer.Tokenizer())
+		require.NotNil(t, scorer.Stemmer())
+		require.Equal(t, evaluation.DefaultBLEUMaxN, scorer.MaxN())
+		require.Equal(t, evaluation.SmoothingNone, scorer.Smoothing())
+		require.Equal(t, evaluation.DefaultSmoothingEpsilon, scorer.Epsilon())
+		require.False(t, scorer.Lowercase())
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		tok := tokenize.NewRegexTokenizer()
+		scorer, err := evaluation.NewBLEUScorer(
+			evaluation.BLEUScorerWithTokenizer(tok),
+			evaluation.BLEUScorerWithMaxN(2),
+			evaluation.BLEUScorerWithSmoothing(evaluation.SmoothingEpsilon),
+			evaluation.BLEUScorerWithEpsilon(0.01),
+			evaluation.BLEUScorerWithLowercase(true),
+		)
+		require.NoError(t, err)
+		require.Equal(t, tok, scorer.Tokenizer())
+		require.Equal(t, 2, scorer.MaxN())
+		require.Equal(t, evaluation.SmoothingEpsilon, scorer.Smoothing())
+		require.Equal(t, 0.01, scorer.Epsilon())
+		require.True(t, scorer.Lowercase())
+	})
+
+	t.Run("ErrorSmoothing", func(t *testing.T) {
+		scorer, err := evaluation.NewBLEUScorer(evaluation.BLEUScorerWithSmoothing(evaluation.SmoothingMethod(100)))
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+		require.Nil(t, scorer)
+	})
+
+	t.Run("ErrorMaxN", func(t *testing.T) {
+		scorer, err := evaluation.NewBLEUScorer(evaluation.BLEUScorerWithMaxN(0))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, scorer)
+	})
+
+	t.Run("ErrorEpsilon", func(t *testing.T) {
+		scorer, err := evaluation.NewBLEUScorer(evaluation.BLEUScorerWithEpsilon(0.0))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, scorer)
+	})
+}
+
+func TestBLEUScorer(t *testing.T) {
+	scorer, err := evaluation.NewBLEUScorer()
+	require.NoError(t, err)
+
+	t.Run("Sentence", func(t *testing.T) {
+		score, err := scorer.Sentence(hypothesis1, reference1a, reference1b, reference1c)
+		require.NoError(t, err)
+		require.InDelta(t, 0.5045666840058485, score, 1e-12)
+	})
+
+	t.Run("Corpus", func(t *testing.T) {
+		score, err := scorer.Corpus(
+			[]string{hypothesis1, hypothesis2},
+			[][]string{{reference1a, reference1b, reference1c}, {reference2a}},
+		)
+		require.NoError(t, err)
+		require.InDelta(t, 0.5920778868801042, score, 1e-12)
+	})
+
+	t.Run("Identical", func(t *testing.T) {
+		score, err := scorer.Sentence(hypothesis2, hypothesis2)
+		require.NoError(t, err)
+		require.InDelta(t, 1.0, score, 1e-12)
+	})
+
+	t.Run("NoUnigramMatches", func(t *testing.T) {
+		score, err := scorer.Sentence("completely different words", "the cat sat on the mat")
+		require.NoError(t, err)
+		require.Equal(t, 0.0, score)
+	})
+
+	t.Run("EmptyCandidate", func(t *testing.T) {
+		score, err := scorer.Sentence("", "the cat sat on the mat")
+		require.NoError(t, err)
+		require.Equal(t, 0.0, score)
+	})
+
+	t.Run("ErrorNoReferences", func(t *testing.T) {
+		_, err := scorer.Sentence(hypothesis1)
+		require.ErrorIs(t, err, errors.ErrMissingReferences)
+	})
+
+	t.Run("ErrorUnequalLengths", func(t *testing.T) {
+		_, err := scorer.Corpus([]string{hypothesis1, hypothesis2}, [][]string{{reference1a}})
+		require.ErrorIs(t, err, errors.ErrUnequalLengthInputs)
+	})
+}
+
+func TestBLEUSmoothing(t *testing.T) {
+	testcases := []struct {
+		Name      string
+		Candidate string
+		Reference string
+		Expected  [4]float64 // none, epsilon, add-one, exponential
+	}{
+		{
+			Name:      "NoFourgramMatches",
+			Candidate: "the cat sat on the mat",
+			Reference: "the cat is on the mat",
+			Expected:  [4]float64{0.0, 0.25406637407730737, 0.48549177170732344, 0.37991784282579627},
+		},
+		{
+			Name:      "OnlyUnigramMatches",
+			Candidate: "the the the the",
+			Reference: "the cat",
+			Expected:  [4]float64{0.0, 0.08034284189446518, 0.31947155212313627, 0.1597357760615681},
+		},
+	}
+
+	methods := []evaluation.SmoothingMethod{
+		evaluation.SmoothingNone,
+		evaluation.SmoothingEpsilon,
+		evaluation.SmoothingAddOne,
+		evaluation.SmoothingExponential,
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			for i, method := range methods {
+				scorer, err := evaluation.NewBLEUScorer(evaluation.BLEUScorerWithSmoothing(method))
+				require.NoError(t, err)
+
+				score, err := scorer.Sentence(tc.Candidate, tc.Reference)
+				require.NoError(t, err)
+				require.InDelta(t, tc.Expected[i], score, 1e-12, "smoothing method %d", method)
+			}
+		})
+	}
+}
+
+func TestBLEUHelpers(t *testing.T) {
+	t.Run("ModifiedPrecision", func(t *testing.T) {
+		// The classic example from Papineni et al. (2002): "the" is clipped to
+		// its maximum count in any one reference
+		candidate := []string{"the", "the", "the", "the", "the", "the", "the"}
+		references := [][]string{
+			{"the", "cat", "is", "on", "the", "mat"},
+			{"there", "is", "a", "cat", "on", "the", "mat"},
+		}
+		matches, total := evaluation.ModifiedPrecision(candidate, references, 1)
+		require.Equal(t, 2, matches)
+		require.Equal(t, 7, total)
+
+		// The total is at least one
+		matches, total = evaluation.ModifiedPrecision([]string{"the"}, references, 2)
+		require.Equal(t, 0, matches)
+		require.Equal(t, 1, total)
+	})
+
+	t.Run("ClosestReferenceLength", func(t *testing.T) {
+		references := [][]string{make([]string, 8), make([]string, 12), make([]string, 20)}
+		require.Equal(t, 12, evaluation.ClosestReferenceLength(11, references))
+		require.Equal(t, 8, evaluation.ClosestReferenceLength(10, references), "ties favor the shorter reference")
+		require.Equal(t, 20, evaluation.ClosestReferenceLength(30, references))
+	})
+
+	t.Run("BrevityPenalty", func(t *testing.T) {
+		require.Equal(t, 1.0, evaluation.BrevityPenalty(12, 10))
+		require.Equal(t, 1.0, evaluation.BrevityPenalty(10, 10))
+		require.Equal(t, 0.0, evaluation.BrevityPenalty(0, 10))
+		require.InDelta(t, 0.6065306597126334, evaluation.BrevityPenalty(10, 15), 1e-12)
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Package evaluation provides reference-based metrics, such as BLEU and ROUGE, for
+evaluating generated text (e.g. from a model) against one or more reference
+texts written by humans.
+*/
+package evaluation
+
+import (
+	"strings"
+
+	"go.rtnl.ai/nlp/ngrams"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// ############################################################################
+// Score
+// ############################################################################
+
+// Score holds the precision, recall, and F-measure for a metric such as ROUGE.
+type Score struct {
+	Precision float64
+	Recall    float64
+	FMeasure  float64
+}
+
+// Returns a [Score] for the precision and recall, with the F-measure set to
+// their harmonic mean. If both are zero then the F-measure is zero.
+func NewScore(precision, recall float64) Score {
+	score := Score{Precision: precision, Recall: recall}
+	if precision+recall > 0.0 {
+		score.FMeasure = 2.0 * precision * recall / (precision + recall)
+	}
+	return score
+}
+
+// ############################################################################
+// Helpers
+// ############################################################################
+
+// Tokenizes the chunk, lowercasing and stemming each token if configured.
+func prepareTokens(chunk string, tokenizer tokenize.Tokenizer, stemmer stem.Stemmer, lowercase bool) (tokens []string, err error) {
+	if tokens, err = tokenizer.Tokenize(chunk); err != nil {
+		return nil, err
+	}
+	for i, tok := range tokens {
+		if lowercase {
+			tok = strings.ToLower(tok)
+		}
+		tokens[i] = stemmer.Stem(tok)
+	}
+	return tokens, nil
+}
+
+// Returns the count of each n-gram in the tokens, with the tokens in each
+// n-gram joined by a single space.
+func countNgrams(tokens []string, n int) (counts map[string]int) {
+	grams := ngrams.Ngrams(tokens, n)
+	counts = make(map[string]int, len(grams))
+	for _, gram := range grams {
+		counts[strings.Join(gram, " ")] += 1
+	}
+	return counts
+}
@@ -0,0 +1,372 @@
+package evaluation
+
+import (
+	"slices"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// Splits a text into its non-empty lines; the default sentence segmenter for
+// ROUGE-Lsum, which conventionally expects summaries with one sentence per line.
+const REGEX_LINES = `[^\r\n]+`
+
+// ############################################################################
+// ROUGEScorer
+// ############################################################################
+
+/*
+ROUGEScorer calculates ROUGE (recall-oriented understudy for gisting
+evaluation) scores of a candidate text against one or more reference texts, as
+described by Lin (2004); create with [NewROUGEScorer]. When there are multiple
+references the [Score] with the highest F-measure is returned.
+
+Usage example:
+
+	// Create a scorer which stems tokens with the Porter2 stemmer
+	stemmer, err := stem.NewPorter2Stemmer(language.English)
+	scorer, err := evaluation.NewROUGEScorer(evaluation.ROUGEScorerWithStemmer(stemmer))
+
+	// ROUGE-2 of a candidate against two references
+	score, err := scorer.RougeN(2, candidate, reference1, reference2)
+	fmt.Println(score.Precision, score.Recall, score.FMeasure)
+
+	// ROUGE-L (sentence-level) and ROUGE-Lsum (summary-level)
+	score, err = scorer.RougeL(candidate, reference1)
+	score, err = scorer.RougeLsum(candidate, reference1)
+*/
+type ROUGEScorer struct {
+	tokenizer tokenize.Tokenizer
+	segmenter tokenize.Tokenizer
+	stemmer   stem.Stemmer
+	lowercase bool
+}
+
+// Returns a new [ROUGEScorer] with the options set.
+//
+// Defaults:
+//   - Tokenizer: [tokenize.RegexTokenizer]
+//   - Sentence segmenter (for ROUGE-Lsum): [tokenize.RegexTokenizer] with
+//     [REGEX_LINES], i.e. one sentence per line
+//   - Stemmer: [stem.NoOpStemmer]
+//   - Lowercase: true
+func NewROUGEScorer(opts ...ROUGEScorerOption) (scorer *ROUGEScorer, err error) {
+	// Set defaults which are not zero values, then set options
+	scorer = &ROUGEScorer{
+		lowercase: true,
+	}
+	for _, fn := range opts {
+		fn(scorer)
+	}
+
+	// Set defaults
+
+	if scorer.tokenizer == nil {
+		scorer.tokenizer = tokenize.NewRegexTokenizer()
+	}
+
+	if scorer.segmenter == nil {
+		scorer.segmenter = tokenize.NewRegexTokenizer(tokenize.RegexTokenizerWithRegex(REGEX_LINES))
+	}
+
+	if scorer.stemmer == nil {
+		scorer.stemmer = &stem.NoOpStemmer{}
+	}
+
+	return scorer, nil
+}
+
+// Returns the [ROUGEScorer]s configured [tokenize.Tokenizer].
+func (s *ROUGEScorer) Tokenizer() tokenize.Tokenizer {
+	return s.tokenizer
+}
+
+// Returns the [ROUGEScorer]s configured sentence segmenter.
+func (s *ROUGEScorer) SentenceSegmenter() tokenize.Tokenizer {
+	return s.segmenter
+}
+
+// Returns the [ROUGEScorer]s configured [stem.Stemmer].
+func (s *ROUGEScorer) Stemmer() stem.Stemmer {
+	return s.stemmer
+}
+
+// Returns true if the [ROUGEScorer] lowercases tokens before matching.
+func (s *ROUGEScorer) Lowercase() bool {
+	return s.lowercase
+}
+
+// RougeN returns the ROUGE-N [Score] of the candidate, which is based on the
+// number of overlapping n-grams with the reference. Returns
+// [errors.ErrMissingReferences] if no references are given, or
+// [errors.ErrMissingConfig] if n is not positive.
+func (s *ROUGEScorer) RougeN(n int, candidate string, references ...string) (score Score, err error) {
+	if n < 1 {
+		return Score{}, errors.Join(errors.ErrMissingConfig, errors.New("the n-gram order must be positive"))
+	}
+	return s.best(candidate, references, s.tokens, func(c, r [][]string) Score {
+		return RougeNTokens(c[0], r[0], n)
+	})
+}
+
+// RougeL returns the ROUGE-L [Score] of the candidate, which is based on the
+// longest common subsequence (LCS) of tokens with the reference, treating each
+// text as a single sequence. Returns [errors.ErrMissingReferences] if no
+// references are given.
+func (s *ROUGEScorer) RougeL(candidate string, references ...string) (score Score, err error) {
+	return s.best(candidate, references, s.tokens, func(c, r [][]string) Score {
+		return RougeLTokens(c[0], r[0])
+	})
+}
+
+// RougeLsum returns the summary-level ROUGE-L [Score] of the candidate, which
+// splits both texts into sentences and uses the union LCS of each reference
+// sentence with every candidate sentence. By default sentences are expected to
+// be on separate lines (see [ROUGEScorerWithSentenceSegmenter]). Returns
+// [errors.ErrMissingReferences] if no references are given.
+func (s *ROUGEScorer) RougeLsum(candidate string, references ...string) (score Score, err error) {
+	return s.best(candidate, references, s.sentences, RougeLsumTokens)
+}
+
+// Prepares the candidate and each reference with the prepare function, scores
+// each reference with the score function, and returns the best score by
+// F-measure.
+func (s *ROUGEScorer) best(candidate string, references []string, prepare func(string) ([][]string, error), score func(c, r [][]string) Score) (best Score, err error) {
+	if len(references) == 0 {
+		return Score{}, errors.ErrMissingReferences
+	}
+
+	var cand [][]string
+	if cand, err = prepare(candidate); err != nil {
+		return Score{}, err
+	}
+
+	for i, reference := range references {
+		var ref [][]string
+		if ref, err = prepare(reference); err != nil {
+			return Score{}, err
+		}
+
+		if current := score(cand, ref); i == 0 || current.FMeasure > best.FMeasure {
+			best = current
+		}
+	}
+	return best, nil
+}
+
+// Returns the prepared tokens of the chunk as a single sequence.
+func (s *ROUGEScorer) tokens(chunk string) (sequences [][]string, err error) {
+	var tokens []string
+	if tokens, err = prepareTokens(chunk, s.tokenizer, s.stemmer, s.lowercase); err != nil {
+		return nil, err
+	}
+	return [][]string{tokens}, nil
+}
+
+// Returns the prepared tokens of each sentence in the chunk.
+func (s *ROUGEScorer) sentences(chunk string) (sequences [][]string, err error) {
+	var sentences []string
+	if sentences, err = s.segmenter.Tokenize(chunk); err != nil {
+		return nil, err
+	}
+
+	sequences = make([][]string, 0, len(sentences))
+	for _, sentence := range sentences {
+		var tokens []string
+		if tokens, err = prepareTokens(sentence, s.tokenizer, s.stemmer, s.lowercase); err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, tokens)
+	}
+	return sequences, nil
+}
+
+// ############################################################################
+// ROUGE functions
+// ############################################################################
+
+// RougeNTokens returns the ROUGE-N [Score] of the candidate tokens against the
+// reference tokens. Each n-gram match is clipped to the number of times the
+// n-gram occurs in the reference.
+func RougeNTokens(candidate, reference []string, n int) Score {
+	candCounts := countNgrams(candidate, n)
+	refCounts := countNgrams(reference, n)
+
+	var overlap, candTotal, refTotal int
+	for gram, count := range candCounts {
+		overlap += min(count, refCounts[gram])
+		candTotal += count
+	}
+	for _, count := range refCounts {
+		refTotal += count
+	}
+
+	return NewScore(
+		float64(overlap)/float64(max(1, candTotal)),
+		float64(overlap)/float64(max(1, refTotal)),
+	)
+}
+
+// RougeLTokens returns the ROUGE-L [Score] of the candidate tokens against the
+// reference tokens using the length of their longest common subsequence.
+func RougeLTokens(candidate, reference []string) Score {
+	if len(candidate) == 0 || len(reference) == 0 {
+		return Score{}
+	}
+
+	lcs := float64(LCSLength(candidate, reference))
+	return NewScore(lcs/float64(len(candidate)), lcs/float64(len(reference)))
+}
+
+// RougeLsumTokens returns the summary-level ROUGE-L [Score] of the candidate
+// sentences against the reference sentences. For each reference sentence the
+// union of its LCS with every candidate sentence is found, and the tokens in
+// the union are counted as hits; each token can only be hit as many times as it
+// occurs in both the candidate and the reference.
+func RougeLsumTokens(candidate, reference [][]string) Score {
+	var candLen, refLen int
+	candCounts := make(map[string]int)
+	refCounts := make(map[string]int)
+	for _, sentence := range candidate {
+		candLen += len(sentence)
+		for _, tok := range sentence {
+			candCounts[tok] += 1
+		}
+	}
+	for _, sentence := range reference {
+		refLen += len(sentence)
+		for _, tok := range sentence {
+			refCounts[tok] += 1
+		}
+	}
+
+	if candLen == 0 || refLen == 0 {
+		return Score{}
+	}
+
+	var hits int
+	for _, refSentence := range reference {
+		for _, tok := range unionLCS(refSentence, candidate) {
+			if candCounts[tok] > 0 && refCounts[tok] > 0 {
+				hits++
+				candCounts[tok]--
+				refCounts[tok]--
+			}
+		}
+	}
+
+	return NewScore(float64(hits)/float64(candLen), float64(hits)/float64(refLen))
+}
+
+// LCSLength returns the length of the longest common subsequence of a and b.
+func LCSLength[T comparable](a, b []T) int {
+	// Only two rows of the dynamic programming table are needed
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else {
+				curr[j] = max(prev[j], curr[j-1])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// Returns the tokens of the reference sentence which are in the LCS with any of
+// the candidate sentences, in reference order.
+func unionLCS(reference []string, candidates [][]string) (tokens []string) {
+	indices := make(map[int]struct{})
+	for _, candidate := range candidates {
+		for _, i := range lcsIndices(reference, candidate) {
+			indices[i] = struct{}{}
+		}
+	}
+
+	union := make([]int, 0, len(indices))
+	for i := range indices {
+		union = append(union, i)
+	}
+	slices.Sort(union)
+
+	tokens = make([]string, 0, len(union))
+	for _, i := range union {
+		tokens = append(tokens, reference[i])
+	}
+	return tokens
+}
+
+// Returns the indices into the reference of one longest common subsequence of
+// the reference and candidate, found by backtracking through the full dynamic
+// programming table.
+func lcsIndices(reference, candidate []string) (indices []int) {
+	table := make([][]int, len(reference)+1)
+	for i := range table {
+		table[i] = make([]int, len(candidate)+1)
+	}
+	for i := 1; i <= len(reference); i++ {
+		for j := 1; j <= len(candidate); j++ {
+			if reference[i-1] == candidate[j-1] {
+				table[i][j] = table[i-1][j-1] + 1
+			} else {
+				table[i][j] = max(table[i-1][j], table[i][j-1])
+			}
+		}
+	}
+
+	i, j := len(reference), len(candidate)
+	for i > 0 && j > 0 {
+		switch {
+		case reference[i-1] == candidate[j-1]:
+			indices = append(indices, i-1)
+			i--
+			j--
+		case table[i][j-1] > table[i-1][j]:
+			j--
+		default:
+			i--
+		}
+	}
+	slices.Reverse(indices)
+	return indices
+}
+
+// ############################################################################
+// ROUGEScorerOption
+// ############################################################################
+
+// ROUGEScorerOption functions modify a [ROUGEScorer].
+type ROUGEScorerOption func(s *ROUGEScorer)
+
+// Sets the [tokenize.Tokenizer] used to split texts and sentences into tokens.
+func ROUGEScorerWithTokenizer(tokenizer tokenize.Tokenizer) ROUGEScorerOption {
+	return func(s *ROUGEScorer) {
+		s.tokenizer = tokenizer
+	}
+}
+
+// Sets the [tokenize.Tokenizer] used to split texts into sentences for
+// ROUGE-Lsum, such as [tokenize.SentenceSegmenter].
+func ROUGEScorerWithSentenceSegmenter(segmenter tokenize.Tokenizer) ROUGEScorerOption {
+	return func(s *ROUGEScorer) {
+		s.segmenter = segmenter
+	}
+}
+
+// Sets the [stem.Stemmer] applied to each token before matching.
+func ROUGEScorerWithStemmer(stemmer stem.Stemmer) ROUGEScorerOption {
+	return func(s *ROUGEScorer) {
+		s.stemmer = stemmer
+	}
+}
+
+// Sets whether tokens are lowercased before matching.
+func ROUGEScorerWithLowercase(lowercase bool) ROUGEScorerOption {
+	return func(s *ROUGEScorer) {
+		s.lowercase = lowercase
+	}
+}
@@ -0,0 +1,148 @@
+package evaluation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/evaluation"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+func TestNewScore(t *testing.T) {
+	score := evaluation.NewScore(0.75, 0.5)
+	require.Equal(t, 0.75, score.Precision)
+	require.Equal(t, 0.5, score.Recall)
+	require.InDelta(t, 0.6, score.FMeasure, 1e-12)
+
+	require.Equal(t, evaluation.Score{}, evaluation.NewScore(0.0, 0.0))
+}
+
+func TestNewROUGEScorer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		scorer, err := evaluation.NewROUGEScorer()
+		require.NoError(t, err)
+		require.NotNil(t, scorer)
+		require.IsType(t, &tokenize.RegexTokenizer{}, scorer.Tokenizer())
+		require.IsType(t, &tokenize.RegexTokenizer{}, scorer.SentenceSegmenter())
+		require.IsType(t, &stem.NoOpStemmer{}, scorer.Stemmer())
+		require.True(t, scorer.Lowercase())
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		tok := tokenize.NewWhitespaceTokenizer()
+		seg := tokenize.NewSentenceSegmenter()
+		stemmer, err := stem.NewPorter2Stemmer(language.English)
+		require.NoError(t, err)
+
+		scorer, err := evaluation.NewROUGEScorer(
+			evaluation.ROUGEScorerWithTokenizer(tok),
+			evaluation.ROUGEScorerWithSentenceSegmenter(seg),
+			evaluation.ROUGEScorerWithStemmer(stemmer),
+			evaluation.ROUGEScorerWithLowercase(false),
+		)
+		require.NoError(t, err)
+		require.Equal(t, tok, scorer.Tokenizer())
+		require.Equal(t, seg, scorer.SentenceSegmenter())
+		require.Equal(t, stemmer, scorer.Stemmer())
+		require.False(t, scorer.Lowercase())
+	})
+}
+
+func TestROUGEScorer(t *testing.T) {
+	const (
+		candidate = "The fast brown fox leaps over the dog"
+		reference = "The quick brown fox jumps over the lazy dog"
+	)
+
+	scorer, err := evaluation.NewROUGEScorer()
+	require.NoError(t, err)
+
+	t.Run("Rouge1", func(t *testing.T) {
+		score, err := scorer.RougeN(1, candidate, reference)
+		require.NoError(t, err)
+		require.InDelta(t, 0.75, score.Precision, 1e-12)
+		require.InDelta(t, 0.6666666666666666, score.Recall, 1e-12)
+		require.InDelta(t, 0.7058823529411765, score.FMeasure, 1e-12)
+	})
+
+	t.Run("Rouge2", func(t *testing.T) {
+		score, err := scorer.RougeN(2, candidate, reference)
+		require.NoError(t, err)
+		require.InDelta(t, 0.2857142857142857, score.Precision, 1e-12)
+		require.InDelta(t, 0.25, score.Recall, 1e-12)
+		require.InDelta(t, 0.26666666666666666, score.FMeasure, 1e-12)
+	})
+
+	t.Run("RougeL", func(t *testing.T) {
+		score, err := scorer.RougeL(candidate, reference)
+		require.NoError(t, err)
+		require.InDelta(t, 0.75, score.Precision, 1e-12)
+		require.InDelta(t, 0.6666666666666666, score.Recall, 1e-12)
+		require.InDelta(t, 0.7058823529411765, score.FMeasure, 1e-12)
+	})
+
+	t.Run("RougeLsum", func(t *testing.T) {
+		// The summary-level LCS rewards the sentences matching out of order,
+		// which the sentence-level ROUGE-L on the whole text does not
+		cand := "The cat was on the mat.\nMy dog ate the homework."
+		ref := "The cat sat on the mat.\nThe dog ate my homework."
+
+		score, err := scorer.RougeLsum(cand, ref)
+		require.NoError(t, err)
+		require.InDelta(t, 0.8181818181818182, score.FMeasure, 1e-12)
+
+		score, err = scorer.RougeL(cand, ref)
+		require.NoError(t, err)
+		require.InDelta(t, 0.7272727272727273, score.FMeasure, 1e-12)
+	})
+
+	t.Run("MultipleReferences", func(t *testing.T) {
+		score, err := scorer.RougeN(1, candidate, "nothing in common", reference)
+		require.NoError(t, err)
+		require.InDelta(t, 0.7058823529411765, score.FMeasure, 1e-12)
+	})
+
+	t.Run("Stemming", func(t *testing.T) {
+		stemmer, err := stem.NewPorter2Stemmer(language.English)
+		require.NoError(t, err)
+		stemming, err := evaluation.NewROUGEScorer(evaluation.ROUGEScorerWithStemmer(stemmer))
+		require.NoError(t, err)
+
+		score, err := scorer.RougeN(1, "cats running", "cat runs")
+		require.NoError(t, err)
+		require.Equal(t, 0.0, score.FMeasure)
+
+		score, err = stemming.RougeN(1, "cats running", "cat runs")
+		require.NoError(t, err)
+		require.InDelta(t, 1.0, score.FMeasure, 1e-12)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		score, err := scorer.RougeL("", reference)
+		require.NoError(t, err)
+		require.Equal(t, evaluation.Score{}, score)
+
+		score, err = scorer.RougeLsum(candidate, "")
+		require.NoError(t, err)
+		require.Equal(t, evaluation.Score{}, score)
+	})
+
+	t.Run("ErrorNoReferences", func(t *testing.T) {
+		_, err := scorer.RougeL(candidate)
+		require.ErrorIs(t, err, errors.ErrMissingReferences)
+	})
+
+	t.Run("ErrorNgramOrder", func(t *testing.T) {
+		_, err := scorer.RougeN(0, candidate, reference)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+}
+
+func TestLCSLength(t *testing.T) {
+	require.Equal(t, 0, evaluation.LCSLength([]string{}, []string{"a"}))
+	require.Equal(t, 4, evaluation.LCSLength([]rune("ABCBDAB"), []rune("BDCABA")))
+	require.Equal(t, 3, evaluation.LCSLength([]string{"a", "b", "c"}, []string{"a", "b", "c"}))
+}
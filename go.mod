@@ -6,6 +6,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/stretchr/testify v1.11.1
 	go.rtnl.ai/x v1.9.0
+	golang.org/x/text v0.28.0
 )
 
 require (
@@ -0,0 +1,207 @@
+/*
+Package langdetect identifies the language of a text from its character n-grams,
+fully offline with a [Profile] for each supported language embedded in the
+package:
+
+	detector := langdetect.NewDetector()
+	guesses := detector.Detect("¿Dónde está la estación de tren?")
+	// [{Spanish 0.99...} {Portuguese 0.00...} ...]
+
+	lang, confidence := detector.Language("Der Hund läuft durch den Park.")
+	// language.German, 0.99...
+
+The guesses are ranked by confidence, which is the probability of each language
+under a naive Bayes model of the n-grams with equal prior probabilities for the
+languages. Short texts (a few words) are less reliable than longer ones, in
+particular between closely related languages such as German and Dutch.
+*/
+package langdetect
+
+import (
+	"cmp"
+	"embed"
+	"math"
+	"slices"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Embedded Profiles
+// ############################################################################
+
+//go:embed profiles/*.txt
+var profileFiles embed.FS
+
+// The file names of the embedded profiles of the supported languages.
+var profileNames = map[language.Language]string{
+	language.English:    "english",
+	language.Spanish:    "spanish",
+	language.French:     "french",
+	language.German:     "german",
+	language.Italian:    "italian",
+	language.Portuguese: "portuguese",
+	language.Dutch:      "dutch",
+}
+
+// The embedded profiles of the supported languages, loaded once.
+var embeddedProfiles = loadProfiles()
+
+// Returns the embedded profiles, panicking if one is missing or invalid since
+// that is a bug in the package.
+func loadProfiles() (profiles map[language.Language]*Profile) {
+	profiles = make(map[language.Language]*Profile, len(profileNames))
+	for lang, name := range profileNames {
+		file, err := profileFiles.Open("profiles/" + name + ".txt")
+		if err != nil {
+			panic(err)
+		}
+		if profiles[lang], err = ReadProfile(file); err != nil {
+			panic(err)
+		}
+		file.Close()
+	}
+	return profiles
+}
+
+// Returns the languages which have an embedded [Profile].
+func SupportedLanguages() (langs []language.Language) {
+	for lang := range profileNames {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+// ############################################################################
+// Guess
+// ############################################################################
+
+// A language guessed by a [Detector] and the confidence (from 0.0 to 1.0) that
+// the text is in that language.
+type Guess struct {
+	Language   language.Language
+	Confidence float64
+}
+
+// ############################################################################
+// Detector
+// ############################################################################
+
+// Detector identifies the language of a text by comparing its character
+// n-grams to a [Profile] for each candidate language; create with
+// [NewDetector].
+type Detector struct {
+	profiles map[language.Language]*Profile
+}
+
+// Returns a new [Detector] instance.
+//
+// Defaults:
+//   - Languages: all of the [SupportedLanguages] with their embedded profiles
+func NewDetector(opts ...DetectorOption) *Detector {
+	// Set defaults
+	detector := &Detector{
+		profiles: make(map[language.Language]*Profile, len(embeddedProfiles)),
+	}
+	for lang, profile := range embeddedProfiles {
+		detector.profiles[lang] = profile
+	}
+
+	// Set options
+	for _, fn := range opts {
+		fn(detector)
+	}
+
+	return detector
+}
+
+// Returns the candidate languages of the [Detector] in ascending order.
+func (d *Detector) Languages() (langs []language.Language) {
+	for lang := range d.profiles {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+// Returns the candidate languages for the text ranked from the most to the
+// least likely, with confidences which sum to 1.0. Returns nil if the text has
+// no letters or the [Detector] has no candidate languages.
+func (d *Detector) Detect(text string) (guesses []Guess) {
+	grams := NGrams(text)
+	if len(grams) == 0 || len(d.profiles) == 0 {
+		return nil
+	}
+
+	// Compute the log likelihood of the n-grams for each language
+	logs := make(map[language.Language]float64, len(d.profiles))
+	best := math.Inf(-1)
+	for lang, profile := range d.profiles {
+		var sum float64
+		for _, gram := range grams {
+			sum += profile.logProbability(gram)
+		}
+		logs[lang] = sum
+		best = max(best, sum)
+	}
+
+	// Normalize the likelihoods into probabilities (relative to the best to
+	// avoid underflow)
+	var total float64
+	guesses = make([]Guess, 0, len(logs))
+	for lang, sum := range logs {
+		confidence := math.Exp(sum - best)
+		guesses = append(guesses, Guess{Language: lang, Confidence: confidence})
+		total += confidence
+	}
+	for i := range guesses {
+		guesses[i].Confidence /= total
+	}
+
+	// Rank the guesses, breaking ties by language for a stable order
+	slices.SortFunc(guesses, func(a, b Guess) int {
+		return cmp.Or(cmp.Compare(b.Confidence, a.Confidence), cmp.Compare(a.Language, b.Language))
+	})
+	return guesses
+}
+
+// Returns the most likely language of the text and the confidence that the
+// text is in that language, or [language.Unknown] and 0.0 if the text has no
+// letters.
+func (d *Detector) Language(text string) (lang language.Language, confidence float64) {
+	if guesses := d.Detect(text); len(guesses) > 0 {
+		return guesses[0].Language, guesses[0].Confidence
+	}
+	return language.Unknown, 0.0
+}
+
+// ############################################################################
+// DetectorOption
+// ############################################################################
+
+// DetectorOption functions modify a [Detector].
+type DetectorOption func(d *Detector)
+
+// Returns a function which limits the candidate languages of a [Detector] to
+// the languages given, e.g. when the texts are known to be in one of a few
+// languages. Languages without an embedded [Profile] are ignored; use
+// [DetectorWithProfile] to add them.
+func DetectorWithLanguages(langs ...language.Language) DetectorOption {
+	return func(d *Detector) {
+		d.profiles = make(map[language.Language]*Profile, len(langs))
+		for _, lang := range langs {
+			if profile, ok := embeddedProfiles[lang]; ok {
+				d.profiles[lang] = profile
+			}
+		}
+	}
+}
+
+// Returns a function which adds a candidate language to a [Detector] with the
+// [Profile] given, or replaces the profile of a candidate language.
+func DetectorWithProfile(lang language.Language, profile *Profile) DetectorOption {
+	return func(d *Detector) {
+		d.profiles[lang] = profile
+	}
+}
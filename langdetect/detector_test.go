@@ -0,0 +1,135 @@
+package langdetect_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/langdetect"
+	"go.rtnl.ai/nlp/language"
+)
+
+func TestNewDetector(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		detector := langdetect.NewDetector()
+		require.Equal(t, langdetect.SupportedLanguages(), detector.Languages())
+		require.Len(t, detector.Languages(), 7)
+	})
+
+	t.Run("SuccessLanguages", func(t *testing.T) {
+		detector := langdetect.NewDetector(langdetect.DetectorWithLanguages(language.German, language.Dutch, language.Unknown))
+		require.Equal(t, []language.Language{language.German, language.Dutch}, detector.Languages())
+	})
+
+	t.Run("SuccessProfile", func(t *testing.T) {
+		profile := langdetect.NewProfile("zzz zzz zzz zzzz")
+		detector := langdetect.NewDetector(
+			langdetect.DetectorWithLanguages(language.English),
+			langdetect.DetectorWithProfile(language.Unknown, profile),
+		)
+		require.Equal(t, []language.Language{language.Unknown, language.English}, detector.Languages())
+
+		lang, _ := detector.Language("zzz zz")
+		require.Equal(t, language.Unknown, lang)
+	})
+}
+
+func TestDetect(t *testing.T) {
+	detector := langdetect.NewDetector()
+
+	testcases := []struct {
+		Text     string
+		Expected language.Language
+	}{
+		{"The quick brown fox jumps over the lazy dog.", language.English},
+		{"We need more information before we can make a decision.", language.English},
+		{"thank you very much", language.English},
+		{"¿Dónde está la estación de tren más cercana?", language.Spanish},
+		{"Necesitamos más información antes de tomar una decisión.", language.Spanish},
+		{"muchas gracias", language.Spanish},
+		{"Ils ont décidé de rester à la maison parce qu'il pleuvait.", language.French},
+		{"Je voudrais une tasse de café, s'il vous plaît.", language.French},
+		{"merci beaucoup", language.French},
+		{"Der Hund läuft jeden Morgen durch den Park.", language.German},
+		{"Ich hätte gern eine Tasse Kaffee, bitte.", language.German},
+		{"Wo ist der nächste Bahnhof?", language.German},
+	}
+
+	for _, tc := range testcases {
+		guesses := detector.Detect(tc.Text)
+		require.Len(t, guesses, 7, tc.Text)
+		require.Equal(t, tc.Expected, guesses[0].Language, tc.Text)
+
+		var total float64
+		for i, guess := range guesses {
+			if i > 0 {
+				require.LessOrEqual(t, guess.Confidence, guesses[i-1].Confidence, tc.Text)
+			}
+			total += guess.Confidence
+		}
+		require.InDelta(t, 1.0, total, 1e-9, tc.Text)
+	}
+
+	t.Run("NoLetters", func(t *testing.T) {
+		require.Nil(t, detector.Detect(""))
+		require.Nil(t, detector.Detect("42 -- 3.14 !?"))
+
+		lang, confidence := detector.Language("1234")
+		require.Equal(t, language.Unknown, lang)
+		require.Zero(t, confidence)
+	})
+}
+
+func TestLanguage(t *testing.T) {
+	detector := langdetect.NewDetector()
+
+	testcases := []struct {
+		Text     string
+		Expected language.Language
+	}{
+		{"Questo libro spiega la storia della città.", language.Italian},
+		{"Vorrei una tazza di caffè, per favore.", language.Italian},
+		{"Ela trabalha aqui desde o verão passado.", language.Portuguese},
+		{"Precisamos de mais informações antes de tomar uma decisão.", language.Portuguese},
+		{"De hond rent elke ochtend door het park.", language.Dutch},
+		{"Waar is het dichtstbijzijnde treinstation?", language.Dutch},
+	}
+
+	for _, tc := range testcases {
+		lang, confidence := detector.Language(tc.Text)
+		require.Equal(t, tc.Expected, lang, tc.Text)
+		require.Greater(t, confidence, 0.5, tc.Text)
+	}
+}
+
+func TestProfile(t *testing.T) {
+	profile := langdetect.NewProfile("The cat, the hat... THE END!")
+	require.Equal(t, 3, profile.Count("_th"))
+	require.Equal(t, 3, profile.Count("the"))
+	require.Equal(t, 2, profile.Count("at_"))
+	require.Zero(t, profile.Count("_"))
+	require.Zero(t, profile.Count("t,"))
+
+	// The profile round trips through its text format
+	var buf bytes.Buffer
+	_, err := profile.WriteTo(&buf)
+	require.NoError(t, err)
+
+	loaded, err := langdetect.ReadProfile(&buf)
+	require.NoError(t, err)
+	require.Equal(t, profile, loaded)
+
+	t.Run("ErrorInvalid", func(t *testing.T) {
+		_, err := langdetect.ReadProfile(bytes.NewBufferString("the 3\nhe\n"))
+		require.Error(t, err)
+
+		_, err = langdetect.ReadProfile(bytes.NewBufferString("the three\n"))
+		require.Error(t, err)
+	})
+}
+
+func TestNGrams(t *testing.T) {
+	require.Equal(t, []string{"o", "f", "_o", "of", "f_", "_of", "of_"}, langdetect.NGrams("Of"))
+	require.Equal(t, []string{"ß", "_ß", "ß_", "_ß_"}, langdetect.NGrams("ß"))
+	require.Empty(t, langdetect.NGrams("123 !"))
+}
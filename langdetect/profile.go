@@ -0,0 +1,160 @@
+package langdetect
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ############################################################################
+// Profile
+// ############################################################################
+
+const (
+	// The longest character n-gram in a [Profile].
+	MaxNGramLength = 3
+
+	// The number of most frequent n-grams kept in a [Profile] by [NewProfile].
+	DefaultProfileSize = 1000
+)
+
+/*
+Profile is the character n-gram profile of a language: the counts of the most
+frequent n-grams of one to [MaxNGramLength] letters in a sample of text, where
+each word is padded with an underscore so the n-grams at the beginning and end
+of words are distinct (e.g. "_th" and "he_" in "the"). Create a profile from a
+sample of text with [NewProfile] or load a saved profile with [ReadProfile].
+*/
+type Profile struct {
+	counts map[string]int
+	total  int
+}
+
+// Returns a new [Profile] of the [DefaultProfileSize] most frequent n-grams in
+// the text, which should be at least a few thousand characters of ordinary
+// prose in the language.
+func NewProfile(text string) *Profile {
+	counts := make(map[string]int)
+	for _, gram := range NGrams(text) {
+		counts[gram]++
+	}
+
+	// Keep the most frequent n-grams, breaking ties alphabetically so the
+	// profile is deterministic
+	grams := make([]string, 0, len(counts))
+	for gram := range counts {
+		grams = append(grams, gram)
+	}
+	slices.SortFunc(grams, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	profile := &Profile{counts: make(map[string]int)}
+	for _, gram := range grams[:min(len(grams), DefaultProfileSize)] {
+		profile.add(gram, counts[gram])
+	}
+	return profile
+}
+
+// Returns a [Profile] read from r, which has an n-gram and its count separated
+// by whitespace on each line (the format written by [Profile.WriteTo]).
+func ReadProfile(r io.Reader) (profile *Profile, err error) {
+	profile = &Profile{counts: make(map[string]int)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid profile line %q", scanner.Text())
+		}
+
+		var count int
+		if count, err = strconv.Atoi(fields[1]); err != nil || count <= 0 {
+			return nil, fmt.Errorf("invalid count on profile line %q", scanner.Text())
+		}
+		profile.add(fields[0], count)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Writes the [Profile] to w with one n-gram and its count on each line, from
+// the most to the least frequent.
+func (p *Profile) WriteTo(w io.Writer) (n int64, err error) {
+	grams := make([]string, 0, len(p.counts))
+	for gram := range p.counts {
+		grams = append(grams, gram)
+	}
+	slices.SortFunc(grams, func(a, b string) int {
+		return cmp.Or(cmp.Compare(p.counts[b], p.counts[a]), cmp.Compare(a, b))
+	})
+
+	for _, gram := range grams {
+		var written int
+		if written, err = fmt.Fprintf(w, "%s %d\n", gram, p.counts[gram]); err != nil {
+			return n, err
+		}
+		n += int64(written)
+	}
+	return n, nil
+}
+
+// Returns the number of n-grams in the [Profile].
+func (p *Profile) Len() int {
+	return len(p.counts)
+}
+
+// Returns the number of times the n-gram occurred in the sample of text.
+func (p *Profile) Count(gram string) int {
+	return p.counts[gram]
+}
+
+// Adds the count of the n-gram to the profile.
+func (p *Profile) add(gram string, count int) {
+	p.counts[gram] += count
+	p.total += count
+}
+
+// Returns the log probability of the n-gram in the language, with additive
+// smoothing so that n-grams which are not in the profile are improbable but
+// possible.
+func (p *Profile) logProbability(gram string) float64 {
+	const alpha = 0.5
+	return math.Log((float64(p.counts[gram]) + alpha) / (float64(p.total) + alpha*float64(len(p.counts)+1)))
+}
+
+// ############################################################################
+// N-Grams
+// ############################################################################
+
+// Returns the character n-grams of one to [MaxNGramLength] letters in the
+// lowercased words of the text, where a word is a sequence of letters padded
+// with an underscore on each side. Numbers, punctuation and other symbols
+// separate words and are not part of any n-gram.
+func NGrams(text string) (grams []string) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	for _, word := range words {
+		runes := []rune("_" + word + "_")
+		for n := 1; n <= MaxNGramLength; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if gram := string(runes[i : i+n]); gram != "_" {
+					grams = append(grams, gram)
+				}
+			}
+		}
+	}
+	return grams
+}
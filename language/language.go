@@ -14,6 +14,12 @@ type Language uint16
 const (
 	Unknown Language = iota
 	English
+	Spanish
+	French
+	German
+	Italian
+	Portuguese
+	Dutch
 )
 
 // ############################################################################
@@ -34,3 +40,48 @@ var SENTENCE_PUNCTUATION_ENGLISH = ".!?"
 
 // Words that look like they end a sentence but usually do not. Non-exhaustive.
 var SENTENCE_STOP_WORDS_ENGLISH = []string{"Mr.", "Mrs.", "Ms.", "Dr.", "Hon."}
+
+// Common English abbreviations, lowercase and without the final period, which
+// seed the abbreviations of an untrained Punkt sentence segmenter.
+// Non-exhaustive.
+var ABBREVIATIONS_ENGLISH = []string{
+	"a.m", "apr", "aug", "ave", "capt", "cf", "co", "col", "corp", "dec", "dept",
+	"dr", "e.g", "esp", "est", "etc", "feb", "fig", "gen", "gov", "hon", "i.e",
+	"inc", "jan", "jr", "jul", "jun", "lt", "ltd", "mar", "messrs", "mr", "mrs",
+	"ms", "mt", "nov", "oct", "p.m", "ph.d", "prof", "rep", "rev", "sen", "sep",
+	"sept", "sgt", "sr", "st", "u.k", "u.n", "u.s", "u.s.a", "viz", "vs",
+}
+
+// ############################################################################
+// Constants for other languages
+// ############################################################################
+
+// Punctuation marks which end a sentence in the other languages written in the
+// Latin alphabet ([Spanish], [French], [German], [Italian], [Portuguese] and
+// [Dutch]). The inverted marks which begin a Spanish question or exclamation
+// do not end a sentence.
+var SENTENCE_PUNCTUATION_LATIN = ".!?"
+
+// Words that look like they end a Spanish sentence but usually do not.
+// Non-exhaustive.
+var SENTENCE_STOP_WORDS_SPANISH = []string{"Sr.", "Sra.", "Srta.", "Dr.", "Dra.", "Ud.", "Uds.", "Lic.", "Prof."}
+
+// Words that look like they end a French sentence but usually do not.
+// Non-exhaustive.
+var SENTENCE_STOP_WORDS_FRENCH = []string{"M.", "MM.", "Mme.", "Mlle.", "Dr.", "Pr.", "Me."}
+
+// Words that look like they end a German sentence but usually do not.
+// Non-exhaustive.
+var SENTENCE_STOP_WORDS_GERMAN = []string{"Hr.", "Fr.", "Dr.", "Prof.", "Nr.", "Str.", "bzw.", "ca.", "vgl."}
+
+// Words that look like they end an Italian sentence but usually do not.
+// Non-exhaustive.
+var SENTENCE_STOP_WORDS_ITALIAN = []string{"Sig.", "Sigg.", "Dott.", "Dr.", "Prof.", "Ing.", "Avv."}
+
+// Words that look like they end a Portuguese sentence but usually do not.
+// Non-exhaustive.
+var SENTENCE_STOP_WORDS_PORTUGUESE = []string{"Sr.", "Sra.", "Dr.", "Dra.", "Prof.", "Profa.", "Exmo.", "Exma."}
+
+// Words that look like they end a Dutch sentence but usually do not.
+// Non-exhaustive.
+var SENTENCE_STOP_WORDS_DUTCH = []string{"Dhr.", "Mevr.", "Dr.", "Drs.", "Prof.", "Mr.", "Ir."}
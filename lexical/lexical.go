@@ -0,0 +1,354 @@
+/*
+Package lexical provides lexical diversity (lexical richness) metrics, which
+measure how varied the vocabulary of a text is; for example, to detect
+repetitive model output. Every metric takes a sequence of tokens, so it can be
+computed over either the word tokens or the word stems of a text:
+
+	myText, err := text.New("the cat sat on the mat and the cat sat still")
+
+	tokens, err := myText.Tokens()
+	ttr, err := lexical.TTR(tokens.Strings()) // 7 types / 11 tokens
+
+	stems, err := myText.Stems()
+	mtld, err := lexical.MTLD(stems.Strings(), lexical.DefaultMTLDThreshold)
+
+In the documentation N is the number of tokens, V is the number of types
+(unique tokens), and V(i) is the number of types which occur exactly i times.
+*/
+package lexical
+
+import (
+	"math"
+
+	"go.rtnl.ai/nlp/errors"
+)
+
+// ############################################################################
+// Defaults
+// ############################################################################
+
+const (
+	// The default moving window size for [MATTR].
+	DefaultMATTRWindow = 50
+
+	// The default TTR threshold which ends a factor for [MTLD], from McCarthy &
+	// Jarvis (2010).
+	DefaultMTLDThreshold = 0.72
+
+	// The default sample size for [HDD], from McCarthy & Jarvis (2007).
+	DefaultHDDSampleSize = 42
+)
+
+// ############################################################################
+// Frequencies
+// ############################################################################
+
+// Returns the number of times each type occurs in the tokens.
+func Frequencies[T comparable](tokens []T) (freqs map[T]int) {
+	freqs = make(map[T]int)
+	for _, tok := range tokens {
+		freqs[tok] += 1
+	}
+	return freqs
+}
+
+// Returns the frequency spectrum of the tokens: a map of each frequency i to
+// V(i), the number of types which occur exactly i times.
+func Spectrum[T comparable](tokens []T) (spectrum map[int]int) {
+	spectrum = make(map[int]int)
+	for _, freq := range Frequencies(tokens) {
+		spectrum[freq] += 1
+	}
+	return spectrum
+}
+
+// ############################################################################
+// Type-Token Ratios
+// ############################################################################
+
+// Returns the type-token ratio V / N. Returns [errors.ErrUndefinedValue] if
+// there are no tokens.
+func TTR[T comparable](tokens []T) (ttr float64, err error) {
+	if len(tokens) == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return float64(len(Frequencies(tokens))) / float64(len(tokens)), nil
+}
+
+// Returns Guiraud's root type-token ratio V / sqrt(N). Returns
+// [errors.ErrUndefinedValue] if there are no tokens.
+func RootTTR[T comparable](tokens []T) (rttr float64, err error) {
+	if len(tokens) == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return float64(len(Frequencies(tokens))) / math.Sqrt(float64(len(tokens))), nil
+}
+
+// Returns Herdan's log type-token ratio (Herdan's C) log(V) / log(N). Returns
+// [errors.ErrUndefinedValue] if there are fewer than two tokens.
+func LogTTR[T comparable](tokens []T) (logttr float64, err error) {
+	if len(tokens) < 2 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return math.Log(float64(len(Frequencies(tokens)))) / math.Log(float64(len(tokens))), nil
+}
+
+// Returns the moving-average type-token ratio (MATTR) of Covington & McFall
+// (2010): the mean TTR of every window of the given size as it moves over the
+// tokens one token at a time. If there are fewer tokens than the window size,
+// the TTR of all of the tokens is returned. Returns [errors.ErrUndefinedValue]
+// if there are no tokens or [errors.ErrMissingConfig] if the window size is
+// not positive.
+func MATTR[T comparable](tokens []T, window int) (mattr float64, err error) {
+	if window < 1 {
+		return 0.0, errors.Join(errors.ErrMissingConfig, errors.New("the window size must be positive"))
+	}
+	if len(tokens) <= window {
+		return TTR(tokens)
+	}
+
+	// Slide the window, updating the type counts incrementally
+	counts := Frequencies(tokens[:window])
+	sum := float64(len(counts))
+	for i := window; i < len(tokens); i++ {
+		out := tokens[i-window]
+		if counts[out]--; counts[out] == 0 {
+			delete(counts, out)
+		}
+		counts[tokens[i]] += 1
+		sum += float64(len(counts))
+	}
+
+	windows := len(tokens) - window + 1
+	return sum / float64(windows) / float64(window), nil
+}
+
+// ############################################################################
+// MTLD
+// ############################################################################
+
+// Returns the measure of textual lexical diversity (MTLD) of McCarthy & Jarvis
+// (2010): the mean length of sequential token runs (factors) which keep a TTR
+// above the threshold (usually [DefaultMTLDThreshold]), averaged over a forward
+// and a backward pass. The remaining partial factor at the end of each pass
+// is counted in proportion to how close its TTR is to the threshold. Returns
+// [errors.ErrUndefinedValue] if there are no tokens or [errors.ErrMissingConfig]
+// if the threshold is not in the range (0.0, 1.0).
+func MTLD[T comparable](tokens []T, threshold float64) (mtld float64, err error) {
+	if threshold <= 0.0 || threshold >= 1.0 {
+		return 0.0, errors.Join(errors.ErrMissingConfig, errors.New("the MTLD threshold must be between 0 and 1"))
+	}
+	if len(tokens) == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	reversed := make([]T, len(tokens))
+	for i, tok := range tokens {
+		reversed[len(tokens)-1-i] = tok
+	}
+
+	forward := mtldPass(tokens, threshold)
+	backward := mtldPass(reversed, threshold)
+	return (forward + backward) / 2.0, nil
+}
+
+// Returns the mean factor length for a single MTLD pass over the tokens.
+func mtldPass[T comparable](tokens []T, threshold float64) float64 {
+	var (
+		factors float64
+		count   int
+		ttr     float64
+		types   = make(map[T]struct{})
+	)
+
+	for _, tok := range tokens {
+		count++
+		types[tok] = struct{}{}
+		ttr = float64(len(types)) / float64(count)
+		if ttr <= threshold {
+			factors += 1.0
+			count = 0
+			clear(types)
+		}
+	}
+
+	// Partial factor for the remaining tokens
+	if count > 0 {
+		factors += (1.0 - ttr) / (1.0 - threshold)
+	}
+
+	// Every token is unique and so the TTR never dropped; there are no
+	// complete or partial factors
+	if factors == 0.0 {
+		return float64(len(tokens))
+	}
+	return float64(len(tokens)) / factors
+}
+
+// ############################################################################
+// HD-D and vocd-D
+// ############################################################################
+
+// Returns the hypergeometric distribution diversity (HD-D) of McCarthy & Jarvis
+// (2007): the expected TTR of a random sample of sampleSize tokens (usually
+// [DefaultHDDSampleSize]) drawn without replacement, calculated exactly from
+// the hypergeometric distribution. Returns [errors.ErrUndefinedValue] if there
+// are fewer tokens than the sample size or [errors.ErrMissingConfig] if the
+// sample size is not positive.
+func HDD[T comparable](tokens []T, sampleSize int) (hdd float64, err error) {
+	if sampleSize < 1 {
+		return 0.0, errors.Join(errors.ErrMissingConfig, errors.New("the sample size must be positive"))
+	}
+	if len(tokens) < sampleSize {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return expectedTTR(Frequencies(tokens), len(tokens), sampleSize), nil
+}
+
+// Returns vocd-D, the D parameter of the model TTR = D/n * (sqrt(1 + 2n/D) - 1)
+// of Malvern & Richards fit by least squares to the TTRs for sample sizes n of
+// 35 to 50 tokens. Unlike the original vocd procedure, which averages the TTRs
+// of 100 random samples for each size, this uses the exact expected TTRs from
+// the hypergeometric distribution (as for [HDD]), so the result is
+// deterministic. Returns [errors.ErrUndefinedValue] if there are fewer than 50
+// tokens.
+func VocdD[T comparable](tokens []T) (d float64, err error) {
+	const minN, maxN = 35, 50
+	if len(tokens) < maxN {
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	freqs := Frequencies(tokens)
+	ttrs := make([]float64, 0, maxN-minN+1)
+	for n := minN; n <= maxN; n++ {
+		ttrs = append(ttrs, expectedTTR(freqs, len(tokens), n))
+	}
+
+	// The sum of squared errors for a value of D
+	sse := func(d float64) (sum float64) {
+		for i, ttr := range ttrs {
+			n := float64(minN + i)
+			diff := ttr - d/n*(math.Sqrt(1.0+2.0*n/d)-1.0)
+			sum += diff * diff
+		}
+		return sum
+	}
+
+	// Golden-section search for the D that minimizes the error, searching over
+	// log(D) since plausible values of D span several orders of magnitude
+	lo, hi := math.Log(1e-3), math.Log(1e6)
+	ratio := (math.Sqrt(5.0) - 1.0) / 2.0
+	for range 200 {
+		a := hi - ratio*(hi-lo)
+		b := lo + ratio*(hi-lo)
+		if sse(math.Exp(a)) < sse(math.Exp(b)) {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+	return math.Exp((lo + hi) / 2.0), nil
+}
+
+// Returns the expected TTR of a sample of n tokens drawn without replacement
+// from total tokens with the type frequencies. Each type contributes the
+// probability that it occurs at least once in the sample.
+func expectedTTR[T comparable](freqs map[T]int, total, n int) float64 {
+	var types float64
+	for _, freq := range freqs {
+		types += 1.0 - probabilityNone(total, freq, n)
+	}
+	return types / float64(n)
+}
+
+// Returns the hypergeometric probability that a sample of n drawn without
+// replacement from total items contains none of the freq marked items, i.e.
+// C(total-freq, n) / C(total, n).
+func probabilityNone(total, freq, n int) float64 {
+	if n > total-freq {
+		return 0.0
+	}
+	p := 1.0
+	for i := range n {
+		p *= float64(total-freq-i) / float64(total-i)
+	}
+	return p
+}
+
+// ############################################################################
+// Frequency Spectrum Measures
+// ############################################################################
+
+// Returns Yule's characteristic K = 10^4 * (sum(i^2 * V(i)) - N) / N^2, which is
+// lower for more diverse vocabularies and does not depend on the text length.
+// Returns [errors.ErrUndefinedValue] if there are no tokens.
+func YulesK[T comparable](tokens []T) (k float64, err error) {
+	if len(tokens) == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	var m2 float64
+	for freq, types := range Spectrum(tokens) {
+		m2 += float64(freq*freq) * float64(types)
+	}
+	n := float64(len(tokens))
+	return 1e4 * (m2 - n) / (n * n), nil
+}
+
+// Returns Simpson's D = sum(i * (i-1) * V(i)) / (N * (N-1)), the probability
+// that two tokens drawn at random without replacement are the same type.
+// Returns [errors.ErrUndefinedValue] if there are fewer than two tokens.
+func SimpsonsD[T comparable](tokens []T) (d float64, err error) {
+	if len(tokens) < 2 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	var sum float64
+	for freq, types := range Spectrum(tokens) {
+		sum += float64(freq*(freq-1)) * float64(types)
+	}
+	n := float64(len(tokens))
+	return sum / (n * (n - 1.0)), nil
+}
+
+// Returns Honoré's R = 100 * log(N) / (1 - V(1)/V), which is higher for texts
+// with more hapax legomena. Returns [errors.ErrUndefinedValue] if there are no
+// tokens or if every type is a hapax legomenon.
+func HonoresR[T comparable](tokens []T) (r float64, err error) {
+	if len(tokens) == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	freqs := Frequencies(tokens)
+	hapaxes := Spectrum(tokens)[1]
+	if hapaxes == len(freqs) {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return 100.0 * math.Log(float64(len(tokens))) / (1.0 - float64(hapaxes)/float64(len(freqs))), nil
+}
+
+// Returns the hapax legomena ratio V(1) / V, the proportion of types which
+// occur exactly once. Returns [errors.ErrUndefinedValue] if there are no
+// tokens.
+func HapaxLegomenaRatio[T comparable](tokens []T) (ratio float64, err error) {
+	return legomenaRatio(tokens, 1)
+}
+
+// Returns the dis legomena ratio V(2) / V, the proportion of types which occur
+// exactly twice. Returns [errors.ErrUndefinedValue] if there are no tokens.
+func DisLegomenaRatio[T comparable](tokens []T) (ratio float64, err error) {
+	return legomenaRatio(tokens, 2)
+}
+
+// Returns V(i) / V.
+func legomenaRatio[T comparable](tokens []T, i int) (ratio float64, err error) {
+	if len(tokens) == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	spectrum := Spectrum(tokens)
+	var types int
+	for _, count := range spectrum {
+		types += count
+	}
+	return float64(spectrum[i]) / float64(types), nil
+}
@@ -0,0 +1,146 @@
+package lexical_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/lexical"
+	"go.rtnl.ai/nlp/text"
+)
+
+// 54 tokens and 34 types
+const sample = "the quick brown fox jumps over the lazy dog and the dog sleeps while the fox runs " +
+	"into the forest where the trees are tall and the river is cold so the fox drinks water " +
+	"then the fox returns to the lazy dog who is still asleep under the old tree by the river"
+
+func TestFrequencies(t *testing.T) {
+	tokens := []string{"a", "b", "a", "c", "a", "b"}
+	require.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, lexical.Frequencies(tokens))
+	require.Equal(t, map[int]int{3: 1, 2: 1, 1: 1}, lexical.Spectrum(tokens))
+}
+
+func TestMetrics(t *testing.T) {
+	tokens := strings.Fields(sample)
+	require.Len(t, tokens, 54)
+
+	testcases := []struct {
+		Name     string
+		Func     func([]string) (float64, error)
+		Expected float64
+		Delta    float64
+	}{
+		{"TTR", lexical.TTR[string], 0.6296296296296297, 1e-12},
+		{"RootTTR", lexical.RootTTR[string], 4.626813958590447, 1e-12},
+		{"LogTTR", lexical.LogTTR[string], 0.8840247249555756, 1e-12},
+		{
+			Name:     "MATTR10",
+			Func:     func(tokens []string) (float64, error) { return lexical.MATTR(tokens, 10) },
+			Expected: 0.86,
+			Delta:    1e-12,
+		},
+		{
+			Name:     "MATTR50",
+			Func:     func(tokens []string) (float64, error) { return lexical.MATTR(tokens, lexical.DefaultMATTRWindow) },
+			Expected: 0.648,
+			Delta:    1e-12,
+		},
+		{
+			Name:     "MTLD",
+			Func:     func(tokens []string) (float64, error) { return lexical.MTLD(tokens, lexical.DefaultMTLDThreshold) },
+			Expected: 27.41860465116279,
+			Delta:    1e-12,
+		},
+		{
+			Name:     "HDD",
+			Func:     func(tokens []string) (float64, error) { return lexical.HDD(tokens, lexical.DefaultHDDSampleSize) },
+			Expected: 0.6620256875528105,
+			Delta:    1e-12,
+		},
+		{"VocdD", lexical.VocdD[string], 27.28, 1e-2}, // from a grid search with a step of 0.01
+		{"YulesK", lexical.YulesK[string], 541.838134430727, 1e-9},
+		{"SimpsonsD", lexical.SimpsonsD[string], 0.05520614954577219, 1e-12},
+		{"HonoresR", lexical.HonoresR[string], 1937.506536902647, 1e-9},
+		{"HapaxLegomenaRatio", lexical.HapaxLegomenaRatio[string], 0.7941176470588235, 1e-12},
+		{"DisLegomenaRatio", lexical.DisLegomenaRatio[string], 0.11764705882352941, 1e-12},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := tc.Func(tokens)
+			require.NoError(t, err)
+			require.InDelta(t, tc.Expected, actual, tc.Delta)
+
+			// Every metric is undefined without any tokens
+			_, err = tc.Func(nil)
+			require.ErrorIs(t, err, errors.ErrUndefinedValue)
+		})
+	}
+}
+
+func TestMetricsEdgeCases(t *testing.T) {
+	t.Run("MATTRShortText", func(t *testing.T) {
+		// Falls back to the TTR when there are fewer tokens than the window
+		mattr, err := lexical.MATTR([]string{"a", "b", "a"}, 10)
+		require.NoError(t, err)
+		require.InDelta(t, 2.0/3.0, mattr, 1e-12)
+	})
+
+	t.Run("MTLDAllUnique", func(t *testing.T) {
+		mtld, err := lexical.MTLD([]string{"a", "b", "c"}, lexical.DefaultMTLDThreshold)
+		require.NoError(t, err)
+		require.Equal(t, 3.0, mtld)
+	})
+
+	t.Run("HDDIdenticalTokens", func(t *testing.T) {
+		// A single type is always in the sample
+		hdd, err := lexical.HDD([]string{"a", "a", "a", "a"}, 2)
+		require.NoError(t, err)
+		require.InDelta(t, 0.5, hdd, 1e-12)
+	})
+
+	t.Run("HonoresRAllHapaxes", func(t *testing.T) {
+		_, err := lexical.HonoresR([]string{"a", "b", "c"})
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+
+	t.Run("TooFewTokens", func(t *testing.T) {
+		_, err := lexical.HDD([]string{"a", "b"}, lexical.DefaultHDDSampleSize)
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+		_, err = lexical.VocdD([]string{"a", "b"})
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+		_, err = lexical.LogTTR([]string{"a"})
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+		_, err = lexical.SimpsonsD([]string{"a"})
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+
+	t.Run("ErrorConfig", func(t *testing.T) {
+		tokens := strings.Fields(sample)
+		_, err := lexical.MATTR(tokens, 0)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		_, err = lexical.MTLD(tokens, 1.0)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		_, err = lexical.HDD(tokens, 0)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+}
+
+func TestText(t *testing.T) {
+	myText, err := text.New("the cat sat on the mat and the cats sat still")
+	require.NoError(t, err)
+
+	tokens, err := myText.Tokens()
+	require.NoError(t, err)
+	ttr, err := lexical.TTR(tokens.Strings())
+	require.NoError(t, err)
+	require.InDelta(t, 8.0/11.0, ttr, 1e-12)
+
+	// "cat" and "cats" have the same stem
+	stems, err := myText.Stems()
+	require.NoError(t, err)
+	ttr, err = lexical.TTR(stems.Strings())
+	require.NoError(t, err)
+	require.InDelta(t, 7.0/11.0, ttr, 1e-12)
+}
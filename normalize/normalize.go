@@ -0,0 +1,186 @@
+/*
+Package normalize provides composable normalizers which clean up text before it
+is tokenized, such as lowercasing, Unicode NFKC normalization, accent stripping,
+whitespace collapsing, smart quote folding and URL or email masking:
+
+	pipeline := normalize.NewPipeline(
+		normalize.NFKC(),
+		normalize.FoldQuotes(),
+		normalize.Lowercase(),
+		normalize.MaskEmails("EMAIL"),
+		normalize.MaskURLs("URL"),
+		normalize.CollapseWhitespace(),
+	)
+
+	normalized, offsets := pipeline.Normalize("Email  “Ana” at ana@example.com")
+	// "email \"ana\" at EMAIL" (the masks are added after lowercasing)
+
+	start, end := offsets.Original(15, 20) // 20, 35 ("ana@example.com")
+
+Every [Normalizer] returns an [OffsetMap] along with the normalized text, which
+maps byte offsets in the normalized text back to byte offsets in the original
+text, so tokens found in the normalized text can be located in the original.
+*/
+package normalize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// ############################################################################
+// Normalizer
+// ############################################################################
+
+// Normalizer transforms text before it is tokenized.
+type Normalizer interface {
+	// Returns the normalized text and the [OffsetMap] from byte offsets in the
+	// normalized text back to byte offsets in the text.
+	Normalize(text string) (normalized string, offsets *OffsetMap)
+}
+
+// ############################################################################
+// Pipeline
+// ############################################################################
+
+// Ensure [Pipeline] meets the [Normalizer] interface requirements.
+var _ Normalizer = &Pipeline{}
+
+// Pipeline is a [Normalizer] which applies a sequence of normalizers in order;
+// create with [NewPipeline].
+type Pipeline struct {
+	normalizers []Normalizer
+}
+
+// Returns a new [Pipeline] which applies the normalizers in the order given.
+func NewPipeline(normalizers ...Normalizer) *Pipeline {
+	return &Pipeline{normalizers: normalizers}
+}
+
+// Returns the [Normalizer]s configured on the [Pipeline] in the order they are
+// applied.
+func (p *Pipeline) Normalizers() []Normalizer {
+	return p.normalizers
+}
+
+// Returns the text normalized by each of the normalizers in order, with the
+// [OffsetMap] from the final normalized text back to the text.
+func (p *Pipeline) Normalize(text string) (normalized string, offsets *OffsetMap) {
+	normalized = text
+	for _, normalizer := range p.normalizers {
+		var next *OffsetMap
+		normalized, next = normalizer.Normalize(normalized)
+		offsets = offsets.Compose(next)
+	}
+	return normalized, offsets
+}
+
+// ############################################################################
+// OffsetMap
+// ############################################################################
+
+/*
+OffsetMap maps byte offsets in a normalized text back to byte offsets in the
+original text it was normalized from. Each byte of the normalized text comes
+from a segment of the original text (e.g. the 2 bytes of "fi" from the 3 bytes
+of the ligature "ﬁ" with NFKC normalization, or a mask from a URL), and maps
+back to the whole segment.
+
+A nil *OffsetMap is the identity map, for text which was not changed.
+*/
+type OffsetMap struct {
+	// The start and end byte offsets of the original segment that each byte of
+	// the normalized text came from, with a final entry for the end of the text
+	starts []int
+	ends   []int
+}
+
+// Returns the number of bytes in the normalized text.
+func (m *OffsetMap) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.starts) - 1
+}
+
+// Returns the start and end byte offsets in the original text of the bytes
+// normalized[start:end], which begin at the start of the original segment of
+// the first byte and end at the end of the original segment of the last byte.
+func (m *OffsetMap) Original(start, end int) (originalStart, originalEnd int) {
+	if m == nil {
+		return start, end
+	}
+	if end <= start {
+		return m.starts[start], m.starts[start]
+	}
+	return m.starts[start], m.ends[end-1]
+}
+
+// Returns an [OffsetMap] which maps the byte offsets of a text normalized from
+// this map's normalized text (by the next map) back to this map's original
+// text.
+func (m *OffsetMap) Compose(next *OffsetMap) *OffsetMap {
+	if m == nil {
+		return next
+	}
+	if next == nil {
+		return m
+	}
+
+	composed := &OffsetMap{
+		starts: make([]int, len(next.starts)),
+		ends:   make([]int, len(next.ends)),
+	}
+	for i := range next.starts {
+		start, end := next.starts[i], next.ends[i]
+		composed.starts[i], composed.ends[i] = m.Original(start, end)
+	}
+	return composed
+}
+
+// ############################################################################
+// Helpers
+// ############################################################################
+
+// Builds a normalized text and its [OffsetMap] one segment at a time.
+type builder struct {
+	text    strings.Builder
+	offsets OffsetMap
+}
+
+// Appends the normalized string for the original segment text[start:end].
+func (b *builder) emit(s string, start, end int) {
+	b.text.WriteString(s)
+	for range len(s) {
+		b.offsets.starts = append(b.offsets.starts, start)
+		b.offsets.ends = append(b.offsets.ends, end)
+	}
+}
+
+// Appends the original segment text[start:end] unchanged.
+func (b *builder) copy(text string, start, end int) {
+	b.text.WriteString(text[start:end])
+	for i := start; i < end; i++ {
+		b.offsets.starts = append(b.offsets.starts, i)
+		b.offsets.ends = append(b.offsets.ends, i+1)
+	}
+}
+
+// Returns the normalized text and the [OffsetMap] back to the original text,
+// which is length bytes long.
+func (b *builder) result(length int) (string, *OffsetMap) {
+	b.offsets.starts = append(b.offsets.starts, length)
+	b.offsets.ends = append(b.offsets.ends, length)
+	return b.text.String(), &b.offsets
+}
+
+// Returns the text with each rune replaced by the string returned for it.
+func mapRunes(text string, fn func(r rune) string) (string, *OffsetMap) {
+	b := &builder{}
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		b.emit(fn(r), i, i+size)
+		i += size
+	}
+	return b.result(len(text))
+}
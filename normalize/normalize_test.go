@@ -0,0 +1,108 @@
+package normalize_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/normalize"
+)
+
+func TestNormalizers(t *testing.T) {
+	testcases := []struct {
+		Name       string
+		Normalizer normalize.Normalizer
+		Text       string
+		Expected   string
+	}{
+		{"Lowercase", normalize.Lowercase(), "The CAFÉ Ωmega", "the café ωmega"},
+		{"NFKC", normalize.NFKC(), "ﬁne Ｆｕｌｌ ① café x²", "fine Full 1 café x2"},
+		{"StripAccents", normalize.StripAccents(), "Naïve façade, Ærøskøbing, café", "Naive facade, Ærøskøbing, cafe"},
+		{"CollapseWhitespace", normalize.CollapseWhitespace(), " \t one  two\n\nthree  ", "one two three"},
+		{"FoldQuotes", normalize.FoldQuotes(), "“Don’t” ‘go’ «here» 5′ 6″", `"Don't" 'go' "here" 5' 6"`},
+		{"MaskURLs", normalize.MaskURLs("URL"), "See https://example.com/a?b=1, or www.rtnl.ai.", "See URL, or URL."},
+		{"MaskEmails", normalize.MaskEmails("EMAIL"), "Write to ana.lima+nlp@example.co.uk today", "Write to EMAIL today"},
+		{"MaskRegex", normalize.MaskRegex(regexp.MustCompile(`\d+`), "#"), "Call 555 1234", "Call # #"},
+		{"Empty", normalize.CollapseWhitespace(), "", ""},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			normalized, offsets := tc.Normalizer.Normalize(tc.Text)
+			require.Equal(t, tc.Expected, normalized)
+			require.Equal(t, len(normalized), offsets.Len())
+
+			// The whole normalized text maps back to the whole text, ignoring
+			// whitespace which was trimmed
+			start, end := offsets.Original(0, len(normalized))
+			require.LessOrEqual(t, 0, start)
+			require.LessOrEqual(t, end, len(tc.Text))
+		})
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	pipeline := normalize.NewPipeline(
+		normalize.NFKC(),
+		normalize.FoldQuotes(),
+		normalize.Lowercase(),
+		normalize.MaskEmails("EMAIL"),
+		normalize.MaskURLs("URL"),
+		normalize.CollapseWhitespace(),
+	)
+	require.Len(t, pipeline.Normalizers(), 6)
+
+	text := "Email  “Ana” at ana@example.com"
+	normalized, offsets := pipeline.Normalize(text)
+	require.Equal(t, `email "ana" at EMAIL`, normalized)
+
+	testcases := []struct {
+		Start, End int
+		Expected   string
+	}{
+		{0, 5, "Email"},
+		{5, 6, "  "},
+		{6, 11, "“Ana”"},
+		{7, 10, "Ana"},
+		{15, 20, "ana@example.com"},
+		{18, 19, "ana@example.com"},
+	}
+	for _, tc := range testcases {
+		start, end := offsets.Original(tc.Start, tc.End)
+		require.Equal(t, tc.Expected, text[start:end], normalized[tc.Start:tc.End])
+	}
+
+	// An empty range maps to an empty range at the start of its segment
+	start, end := offsets.Original(len(normalized), len(normalized))
+	require.Equal(t, len(text), start)
+	require.Equal(t, len(text), end)
+
+	t.Run("Empty", func(t *testing.T) {
+		normalized, offsets := normalize.NewPipeline().Normalize(text)
+		require.Equal(t, text, normalized)
+		require.Nil(t, offsets)
+
+		// A nil map is the identity
+		start, end := offsets.Original(7, 10)
+		require.Equal(t, 7, start)
+		require.Equal(t, 10, end)
+		require.Zero(t, offsets.Len())
+	})
+}
+
+func TestOffsetMapNFKC(t *testing.T) {
+	// The ligature is 3 bytes and becomes 2, and the composed é is 2 bytes
+	// from 3 (e and the combining acute accent)
+	text := "ﬁle cafés"
+	normalized, offsets := normalize.NFKC().Normalize(text)
+	require.Equal(t, "file cafés", normalized)
+
+	start, end := offsets.Original(0, 4)
+	require.Equal(t, "ﬁle", text[start:end])
+
+	start, end = offsets.Original(1, 2)
+	require.Equal(t, "ﬁ", text[start:end])
+
+	start, end = offsets.Original(5, 11)
+	require.Equal(t, "cafés", text[start:end])
+}
@@ -0,0 +1,167 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ############################################################################
+// Normalizers
+// ############################################################################
+
+// NormalizerFunc is a function which can be used as a [Normalizer].
+type NormalizerFunc func(text string) (normalized string, offsets *OffsetMap)
+
+// Ensure [NormalizerFunc] meets the [Normalizer] interface requirements.
+var _ Normalizer = NormalizerFunc(nil)
+
+// Calls the function with the text.
+func (f NormalizerFunc) Normalize(text string) (normalized string, offsets *OffsetMap) {
+	return f(text)
+}
+
+// Returns a [Normalizer] which lowercases each letter of the text.
+func Lowercase() Normalizer {
+	return NormalizerFunc(func(text string) (string, *OffsetMap) {
+		return mapRunes(text, func(r rune) string {
+			return string(unicode.ToLower(r))
+		})
+	})
+}
+
+// Returns a [Normalizer] which applies Unicode normalization form KC (NFKC) to
+// the text, which composes accented letters and replaces compatibility
+// characters with their standard equivalents, e.g. "ﬁ" with "fi", "①" with "1"
+// and full-width "Ａ" with "A".
+func NFKC() Normalizer {
+	return NormalizerFunc(func(text string) (string, *OffsetMap) {
+		b := &builder{}
+		var iter norm.Iter
+		iter.InitString(norm.NFKC, text)
+		for !iter.Done() {
+			start := iter.Pos()
+			segment := iter.Next()
+			b.emit(string(segment), start, iter.Pos())
+		}
+		return b.result(len(text))
+	})
+}
+
+// Returns a [Normalizer] which removes accents and other combining marks from
+// the letters of the text, e.g. "naïve façade" becomes "naive facade". Letters
+// which are not composed with a mark in Unicode, such as "ø", "æ" and "ß", are
+// not changed.
+func StripAccents() Normalizer {
+	return NormalizerFunc(func(text string) (string, *OffsetMap) {
+		b := &builder{}
+		var iter norm.Iter
+		iter.InitString(norm.NFD, text)
+		for !iter.Done() {
+			start := iter.Pos()
+			segment := strings.Map(func(r rune) rune {
+				if unicode.Is(unicode.Mn, r) {
+					return -1
+				}
+				return r
+			}, string(iter.Next()))
+			b.emit(norm.NFC.String(segment), start, iter.Pos())
+		}
+		return b.result(len(text))
+	})
+}
+
+// Returns a [Normalizer] which replaces each run of whitespace in the text with
+// a single space and removes the whitespace at the beginning and end of the
+// text.
+func CollapseWhitespace() Normalizer {
+	return NormalizerFunc(func(text string) (string, *OffsetMap) {
+		b := &builder{}
+		space := -1 // the start of the current run of whitespace
+		for i := 0; i < len(text); {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			if unicode.IsSpace(r) {
+				if space < 0 {
+					space = i
+				}
+			} else {
+				if space >= 0 && b.text.Len() > 0 {
+					b.emit(" ", space, i)
+				}
+				space = -1
+				b.copy(text, i, i+size)
+			}
+			i += size
+		}
+		return b.result(len(text))
+	})
+}
+
+// Returns a [Normalizer] which replaces typographic ("smart") quotes, primes
+// and guillemets in the text with the ASCII apostrophe (') and quotation mark
+// (").
+func FoldQuotes() Normalizer {
+	return NormalizerFunc(func(text string) (string, *OffsetMap) {
+		return mapRunes(text, func(r rune) string {
+			switch r {
+			case '‘', '’', '‚', '‛', '′', '‹', '›':
+				return "'"
+			case '“', '”', '„', '‟', '″', '«', '»':
+				return `"`
+			}
+			return string(r)
+		})
+	})
+}
+
+// Returns a [Normalizer] which replaces each match of the regular expression in
+// the text with the mask.
+func MaskRegex(regex *regexp.Regexp, mask string) Normalizer {
+	return NormalizerFunc(func(text string) (string, *OffsetMap) {
+		b := &builder{}
+		prev := 0
+		for _, loc := range regex.FindAllStringIndex(text, -1) {
+			b.copy(text, prev, loc[0])
+			b.emit(mask, loc[0], loc[1])
+			prev = loc[1]
+		}
+		b.copy(text, prev, len(text))
+		return b.result(len(text))
+	})
+}
+
+// Returns a [Normalizer] which replaces the URLs in the text (see [REGEX_URL])
+// with the mask, e.g. "URL".
+func MaskURLs(mask string) Normalizer {
+	return MaskRegex(urlRegex, mask)
+}
+
+// Returns a [Normalizer] which replaces the email addresses in the text (see
+// [REGEX_EMAIL]) with the mask, e.g. "EMAIL". Mask emails before URLs if both
+// are masked so the domain of an email is not masked as a URL.
+func MaskEmails(mask string) Normalizer {
+	return MaskRegex(emailRegex, mask)
+}
+
+// ############################################################################
+// Regex Expressions for Masking
+// ############################################################################
+
+const (
+	// URLs which begin with a scheme (such as "https://") or "www.", ending
+	// before whitespace, angle brackets or quotes, and not including trailing
+	// punctuation such as the period at the end of a sentence
+	REGEX_URL = `(?i)\b(?:[a-z][a-z0-9+.-]*://|www\.)[^\s<>"']*[^\s<>"'.,;:!?)\]}]`
+
+	// Email addresses with a local part, an "@", and a domain name with a top
+	// level domain of two or more letters
+	REGEX_EMAIL = `[\p{L}\p{N}._%+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)*\.\p{L}{2,}`
+)
+
+var (
+	urlRegex   = regexp.MustCompile(REGEX_URL)
+	emailRegex = regexp.MustCompile(REGEX_EMAIL)
+)
@@ -0,0 +1,150 @@
+package readability
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/stem"
+)
+
+// ############################################################################
+// Familiar Word Lists
+// ############################################################################
+
+//go:embed wordlists/dale_chall.txt
+var daleChallData string
+
+// Familiar (easy) words for [DaleChallScore], based on the Dale-Chall list of
+// about 3,000 words known to most fourth-grade students. Entries are lowercase
+// and punctuation such as the period in "Mr." is removed; multi-word entries
+// are omitted. The list was transcribed for this package and may differ in a
+// few entries from other published versions.
+var DaleChallWords = strings.Fields(daleChallData)
+
+// ############################################################################
+// FamiliarWords
+// ############################################################################
+
+/*
+FamiliarWords matches words against a familiar word list such as
+[DaleChallWords], using a [stem.Stemmer] so that inflections of a familiar word
+(e.g. "apples" for "apple") are also familiar; create with [NewFamiliarWords].
+
+Usage example:
+
+	stemmer, err := stem.NewPorter2Stemmer(language.English)
+	familiar := readability.NewFamiliarWords(readability.DaleChallWords, stemmer)
+
+	familiar.IsFamiliar("Apples,") // true
+	familiar.IsFamiliar("photosynthesis") // false
+*/
+type FamiliarWords struct {
+	stemmer stem.Stemmer
+	words   map[string]struct{}
+	stems   map[string]struct{}
+}
+
+// Returns a new [FamiliarWords] for the word list, which uses the stemmer to
+// match inflections. If the stemmer is nil only exact (case-insensitive)
+// matches are familiar.
+func NewFamiliarWords(words []string, stemmer stem.Stemmer) *FamiliarWords {
+	if stemmer == nil {
+		stemmer = &stem.NoOpStemmer{}
+	}
+
+	familiar := &FamiliarWords{
+		stemmer: stemmer,
+		words:   make(map[string]struct{}, len(words)),
+		stems:   make(map[string]struct{}, len(words)),
+	}
+	for _, word := range words {
+		word = normalizeWord(word)
+		familiar.words[word] = struct{}{}
+		familiar.stems[stemmer.Stem(word)] = struct{}{}
+	}
+	return familiar
+}
+
+// Returns the [FamiliarWords]s configured [stem.Stemmer].
+func (f *FamiliarWords) Stemmer() stem.Stemmer {
+	return f.stemmer
+}
+
+// Returns the number of words in the familiar word list.
+func (f *FamiliarWords) Len() int {
+	return len(f.words)
+}
+
+// Returns true if the word, or its stem, is in the familiar word list. Case and
+// leading or trailing punctuation are ignored. Words without any letters, such
+// as numbers, are always familiar.
+func (f *FamiliarWords) IsFamiliar(word string) bool {
+	word = normalizeWord(word)
+	if strings.IndexFunc(word, unicode.IsLetter) < 0 {
+		return true
+	}
+	if _, ok := f.words[word]; ok {
+		return true
+	}
+	_, ok := f.stems[f.stemmer.Stem(word)]
+	return ok
+}
+
+// Returns the number of words which are not familiar (difficult words) and
+// the number of unique difficult words in the words.
+func (f *FamiliarWords) DifficultWordCount(words []string) (count, unique int) {
+	seen := make(map[string]struct{})
+	for _, word := range words {
+		if !f.IsFamiliar(word) {
+			count++
+			seen[normalizeWord(word)] = struct{}{}
+		}
+	}
+	return count, len(seen)
+}
+
+// Lowercases the word and trims any leading or trailing runes which are not
+// letters or digits, so "Mr." becomes "mr" and "don't" is unchanged.
+func normalizeWord(word string) string {
+	word = strings.ReplaceAll(word, "’", "'")
+	word = strings.TrimFunc(word, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	return strings.ToLower(word)
+}
+
+// ############################################################################
+// Scores
+// ############################################################################
+
+// Returns the New Dale-Chall readability score, which is based on the
+// percentage of difficult words (words not on the familiar word list, see
+// [DaleChallWords]) and the average sentence length. Scores of 4.9 or lower are
+// easily understood by fourth-grade students and scores of 9.0 to 9.9 by
+// college students. Returns the value 0.0 when the sentence and/or word count
+// is zero.
+func DaleChallScore(wordCount, sentenceCount, difficultWordCount int) (score float64) {
+	if sentenceCount == 0 || wordCount == 0 {
+		return 0.0
+	}
+	difficult := 100.0 * float64(difficultWordCount) / float64(wordCount)
+	score = 0.1579*difficult + 0.0496*(float64(wordCount)/float64(sentenceCount))
+	if difficult > 5.0 {
+		score += 3.6365
+	}
+	return score
+}
+
+// Returns the revised Spache grade level, intended for text up to the fourth
+// grade, which is based on the average sentence length and the percentage of
+// unique difficult words (words not on the revised Spache word list, which is
+// not bundled and must be supplied by the caller, e.g. to [NewFamiliarWords]).
+// Returns the value 0.0 when the sentence and/or word count is zero.
+func SpacheScore(wordCount, sentenceCount, uniqueDifficultWordCount int) (score float64) {
+	if sentenceCount == 0 || wordCount == 0 {
+		return 0.0
+	}
+	difficult := 100.0 * float64(uniqueDifficultWordCount) / float64(wordCount)
+	return 0.121*(float64(wordCount)/float64(sentenceCount)) + 0.082*difficult + 0.659
+}
@@ -0,0 +1,84 @@
+package readability_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/readability"
+	"go.rtnl.ai/nlp/stem"
+)
+
+func TestFamiliarWordLists(t *testing.T) {
+	require.Greater(t, len(readability.DaleChallWords), 2900)
+
+	// The list is lowercase, sorted, and contains no duplicates
+	require.IsIncreasing(t, readability.DaleChallWords)
+	for _, word := range readability.DaleChallWords {
+		require.NotContains(t, word, " ")
+		require.Regexp(t, `^[a-z][a-z'\-]*$`, word)
+	}
+}
+
+func TestFamiliarWords(t *testing.T) {
+	stemmer, err := stem.NewPorter2Stemmer(language.English)
+	require.NoError(t, err)
+
+	words := []string{"apple", "Run", "Mr.", "don't"}
+
+	t.Run("Stemmed", func(t *testing.T) {
+		familiar := readability.NewFamiliarWords(words, stemmer)
+		require.Equal(t, 4, familiar.Len())
+		require.Equal(t, stemmer, familiar.Stemmer())
+
+		testcases := []struct {
+			Word     string
+			Expected bool
+		}{
+			{"apple", true},
+			{"Apples,", true},
+			{"running", true},
+			{"runs", true},
+			{"Mr.", true},
+			{"don't", true},
+			{"don’t", true},
+			{"1776", true},
+			{"--", true},
+			{"aardvark", false},
+			{"pineapple", false},
+		}
+
+		for _, tc := range testcases {
+			require.Equal(t, tc.Expected, familiar.IsFamiliar(tc.Word), tc.Word)
+		}
+	})
+
+	t.Run("NoStemmer", func(t *testing.T) {
+		familiar := readability.NewFamiliarWords(words, nil)
+		require.IsType(t, &stem.NoOpStemmer{}, familiar.Stemmer())
+		require.True(t, familiar.IsFamiliar("APPLE!"))
+		require.False(t, familiar.IsFamiliar("apples"))
+	})
+
+	t.Run("DifficultWordCount", func(t *testing.T) {
+		familiar := readability.NewFamiliarWords(words, stemmer)
+		count, unique := familiar.DifficultWordCount([]string{"The", "apple", "the", "aardvark", "ran", "runs."})
+		require.Equal(t, 4, count) // "The", "the", "aardvark", "ran"
+		require.Equal(t, 3, unique)
+	})
+}
+
+func TestDaleChallAndSpache(t *testing.T) {
+	// 10 words in 1 sentence with 1 (10%) difficult word
+	require.InDelta(t, 0.1579*10.0+0.0496*10.0+3.6365, readability.DaleChallScore(10, 1, 1), 1e-12)
+	require.InDelta(t, 0.121*10.0+0.082*10.0+0.659, readability.SpacheScore(10, 1, 1), 1e-12)
+
+	// The Dale-Chall adjustment is only added above 5% difficult words
+	require.InDelta(t, 0.1579*5.0+0.0496*20.0, readability.DaleChallScore(20, 1, 1), 1e-12)
+
+	// Zero counts
+	require.Equal(t, 0.0, readability.DaleChallScore(0, 1, 1))
+	require.Equal(t, 0.0, readability.DaleChallScore(1, 0, 1))
+	require.Equal(t, 0.0, readability.SpacheScore(0, 1, 1))
+	require.Equal(t, 0.0, readability.SpacheScore(1, 0, 1))
+}
@@ -0,0 +1,72 @@
+package readability
+
+import "math"
+
+// Returns the Gunning Fog index, which estimates the years of formal education
+// needed to understand the text on a first reading. Complex words are words
+// with three or more syllables. Returns the value 0.0 when the sentence and/or
+// word count is zero.
+func GunningFogIndex(wordCount, sentenceCount, complexWordCount int) (score float64) {
+	if sentenceCount == 0 || wordCount == 0 {
+		return 0.0
+	}
+	return 0.4 * (float64(wordCount)/float64(sentenceCount) + 100.0*float64(complexWordCount)/float64(wordCount))
+}
+
+// Returns the SMOG (Simple Measure of Gobbledygook) grade, normalized to a
+// sample of 30 sentences. Polysyllables are words with three or more
+// syllables. Returns the value 0.0 when the sentence count is zero.
+func SMOGGrade(sentenceCount, polysyllableCount int) (score float64) {
+	if sentenceCount == 0 {
+		return 0.0
+	}
+	return 1.043*math.Sqrt(float64(polysyllableCount)*30.0/float64(sentenceCount)) + 3.1291
+}
+
+// Returns the Coleman-Liau index, which uses the average number of letters and
+// sentences per 100 words instead of syllables. Returns the value 0.0 when the
+// word count is zero.
+func ColemanLiauIndex(letterCount, wordCount, sentenceCount int) (score float64) {
+	if wordCount == 0 {
+		return 0.0
+	}
+	letters := 100.0 * float64(letterCount) / float64(wordCount)
+	sentences := 100.0 * float64(sentenceCount) / float64(wordCount)
+	return 0.0588*letters - 0.296*sentences - 15.8
+}
+
+// Returns the Automated Readability Index (ARI), which uses the average number
+// of characters per word and words per sentence. Returns the value 0.0 when the
+// sentence and/or word count is zero.
+func AutomatedReadabilityIndex(characterCount, wordCount, sentenceCount int) (score float64) {
+	if sentenceCount == 0 || wordCount == 0 {
+		return 0.0
+	}
+	return 4.71*(float64(characterCount)/float64(wordCount)) + 0.5*(float64(wordCount)/float64(sentenceCount)) - 21.43
+}
+
+// Returns the Linsear Write grade level. Easy words (fewer than three
+// syllables) score one point and hard words (three or more syllables) score
+// three points; the points per sentence are then converted to a grade level.
+// Returns the value 0.0 when the sentence count is zero.
+func LinsearWriteFormula(easyWordCount, hardWordCount, sentenceCount int) (score float64) {
+	if sentenceCount == 0 {
+		return 0.0
+	}
+	score = float64(easyWordCount+3*hardWordCount) / float64(sentenceCount)
+	if score > 20.0 {
+		return score / 2.0
+	}
+	return (score - 2.0) / 2.0
+}
+
+// Returns the FORCAST grade level, which only uses the number of monosyllabic
+// words per 150 words and so does not depend on sentence boundaries; this makes
+// it suitable for text such as forms and lists. Returns the value 0.0 when the
+// word count is zero.
+func FORCASTGradeLevel(wordCount, monosyllableCount int) (score float64) {
+	if wordCount == 0 {
+		return 0.0
+	}
+	return 20.0 - (150.0*float64(monosyllableCount)/float64(wordCount))/10.0
+}
@@ -0,0 +1,51 @@
+package readability_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/readability"
+)
+
+func TestGradeLevels(t *testing.T) {
+	// A hypothetical 100 word sample with 5 sentences, 12 words with three or
+	// more syllables, 60 monosyllabic words, and 470 letters
+	const (
+		words         = 100
+		sentences     = 5
+		polysyllables = 12
+		monosyllables = 60
+		letters       = 470
+	)
+
+	testcases := []struct {
+		Name     string
+		Actual   float64
+		Expected float64
+	}{
+		{"GunningFog", readability.GunningFogIndex(words, sentences, polysyllables), 12.8},
+		{"SMOG", readability.SMOGGrade(sentences, polysyllables), 11.979248473330827},
+		{"ColemanLiau", readability.ColemanLiauIndex(letters, words, sentences), 10.356},
+		{"ARI", readability.AutomatedReadabilityIndex(letters, words, sentences), 10.707},
+		{"LinsearWriteHard", readability.LinsearWriteFormula(words-polysyllables, polysyllables, sentences), 12.4},
+		{"LinsearWriteEasy", readability.LinsearWriteFormula(words-polysyllables, polysyllables, 2*sentences), 5.2},
+		{"FORCAST", readability.FORCASTGradeLevel(words, monosyllables), 11.0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.InDelta(t, tc.Expected, tc.Actual, 1e-9)
+		})
+	}
+}
+
+func TestGradeLevelsZeroCounts(t *testing.T) {
+	require.Equal(t, 0.0, readability.GunningFogIndex(0, 1, 1))
+	require.Equal(t, 0.0, readability.GunningFogIndex(1, 0, 1))
+	require.Equal(t, 0.0, readability.SMOGGrade(0, 1))
+	require.Equal(t, 0.0, readability.ColemanLiauIndex(1, 0, 1))
+	require.Equal(t, 0.0, readability.AutomatedReadabilityIndex(1, 0, 1))
+	require.Equal(t, 0.0, readability.AutomatedReadabilityIndex(1, 1, 0))
+	require.Equal(t, 0.0, readability.LinsearWriteFormula(1, 1, 0))
+	require.Equal(t, 0.0, readability.FORCASTGradeLevel(0, 1))
+}
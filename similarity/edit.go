@@ -0,0 +1,421 @@
+package similarity
+
+import (
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/mathematics"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/tokenlist"
+)
+
+// ############################################################################
+// EditMethod "enum"
+// ############################################################################
+
+// EditMethod selects the edit distance algorithm used by an [EditSimilarizer].
+type EditMethod uint8
+
+const (
+	EditUnknown EditMethod = iota
+	// See [LevenshteinDistance].
+	EditLevenshtein
+	// See [OSADistance].
+	EditOSA
+	// See [DamerauLevenshteinDistance].
+	EditDamerauLevenshtein
+	// See [JaroSimilarity].
+	EditJaro
+	// See [JaroWinklerSimilarity].
+	EditJaroWinkler
+)
+
+// The default prefix scaling factor for [JaroWinklerSimilarity].
+const DefaultJaroWinklerPrefixScale = 0.1
+
+// ############################################################################
+// EditSimilarizer
+// ############################################################################
+
+/*
+EditSimilarizer can be used to calculate the similarity of two short strings,
+such as entity names or short answers containing typos, using an edit distance
+algorithm; create with [NewEditSimilarizer].
+
+By default the strings are compared rune-by-rune. If a [tokenize.Tokenizer] is
+configured with [EditSimilarizerWithTokenizer], the strings are tokenized and
+the edits are counted on whole tokens instead.
+
+Usage example:
+
+	// Create a Jaro-Winkler similarizer
+	sim, err := similarity.NewEditSimilarizer(
+		similarity.EditSimilarizerWithMethod(similarity.EditJaroWinkler),
+	)
+
+	// Compare two strings
+	score, err := sim.Similarity("MARTHA", "MARHTA") // ~0.961
+
+	// Or get the distance between them
+	distance, err := sim.Distance("MARTHA", "MARHTA") // ~0.039
+*/
+type EditSimilarizer struct {
+	method      EditMethod
+	tokenizer   tokenize.Tokenizer
+	prefixScale float64
+}
+
+// Ensure [EditSimilarizer] meets the [Similarizer] interface requirements.
+var _ Similarizer = &EditSimilarizer{}
+
+// Returns a new [EditSimilarizer] with the options set.
+//
+// Defaults:
+//   - Method: [EditLevenshtein]
+//   - Tokenizer: nil (compares runes)
+//   - Prefix scale: [DefaultJaroWinklerPrefixScale]
+func NewEditSimilarizer(opts ...EditSimilarizerOption) (similarizer *EditSimilarizer, err error) {
+	// Set defaults which are not zero values, then set options
+	similarizer = &EditSimilarizer{
+		prefixScale: DefaultJaroWinklerPrefixScale,
+	}
+	for _, fn := range opts {
+		fn(similarizer)
+	}
+
+	// Set defaults
+
+	if similarizer.method == EditUnknown {
+		similarizer.method = EditLevenshtein
+	}
+
+	// Validate options
+	if similarizer.method > EditJaroWinkler {
+		return nil, errors.ErrMethodNotSupported
+	}
+	if similarizer.prefixScale < 0.0 || 0.25 < similarizer.prefixScale {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the Jaro-Winkler prefix scale must be in the range [0.0, 0.25]"))
+	}
+
+	return similarizer, nil
+}
+
+// Returns the [EditSimilarizer]s configured [EditMethod].
+func (s *EditSimilarizer) Method() EditMethod {
+	return s.method
+}
+
+// Returns the [EditSimilarizer]s configured [tokenize.Tokenizer], which is nil
+// when comparing runes.
+func (s *EditSimilarizer) Tokenizer() tokenize.Tokenizer {
+	return s.tokenizer
+}
+
+// Returns the [EditSimilarizer]s configured Jaro-Winkler prefix scale.
+func (s *EditSimilarizer) PrefixScale() float64 {
+	return s.prefixScale
+}
+
+// Similarity returns a value in the range [0.0, 1.0] that indicates how
+// similar two strings are, where 1.0 means they are identical. For the
+// Levenshtein, OSA, and Damerau-Levenshtein methods this is the distance
+// normalized by the length of the longer sequence (see [EditSimilarity]).
+func (s *EditSimilarizer) Similarity(a, b string) (similarity float64, err error) {
+	if s.tokenizer == nil {
+		return editSimilarity(s, []rune(a), []rune(b)), nil
+	}
+
+	var tokensA, tokensB []string
+	if tokensA, err = s.tokenizer.Tokenize(a); err != nil {
+		return 0.0, err
+	}
+	if tokensB, err = s.tokenizer.Tokenize(b); err != nil {
+		return 0.0, err
+	}
+	return editSimilarity(s, tokensA, tokensB), nil
+}
+
+// Distance returns the edit distance between two strings. For the
+// Levenshtein, OSA, and Damerau-Levenshtein methods this is the number of
+// edits, and for the Jaro and Jaro-Winkler methods this is one minus the
+// similarity.
+func (s *EditSimilarizer) Distance(a, b string) (distance float64, err error) {
+	if s.tokenizer == nil {
+		return editDistance(s, []rune(a), []rune(b)), nil
+	}
+
+	var tokensA, tokensB []string
+	if tokensA, err = s.tokenizer.Tokenize(a); err != nil {
+		return 0.0, err
+	}
+	if tokensB, err = s.tokenizer.Tokenize(b); err != nil {
+		return 0.0, err
+	}
+	return editDistance(s, tokensA, tokensB), nil
+}
+
+// SimilarityTokens is like [EditSimilarizer.Similarity] but compares two
+// [tokenlist.TokenList]s token-by-token.
+func (s *EditSimilarizer) SimilarityTokens(a, b tokenlist.TokenList) (similarity float64) {
+	return editSimilarity(s, a.Strings(), b.Strings())
+}
+
+// DistanceTokens is like [EditSimilarizer.Distance] but compares two
+// [tokenlist.TokenList]s token-by-token.
+func (s *EditSimilarizer) DistanceTokens(a, b tokenlist.TokenList) (distance float64) {
+	return editDistance(s, a.Strings(), b.Strings())
+}
+
+// Returns the similarity of the sequences using the similarizer's method.
+func editSimilarity[S ~[]T, T comparable](s *EditSimilarizer, a, b S) float64 {
+	switch s.method {
+	case EditJaro:
+		return JaroSimilarity(a, b)
+	case EditJaroWinkler:
+		return JaroWinklerSimilarity(a, b, s.prefixScale)
+	}
+	return EditSimilarity(int(editDistance(s, a, b)), len(a), len(b))
+}
+
+// Returns the distance of the sequences using the similarizer's method.
+func editDistance[S ~[]T, T comparable](s *EditSimilarizer, a, b S) float64 {
+	switch s.method {
+	case EditOSA:
+		return float64(OSADistance(a, b))
+	case EditDamerauLevenshtein:
+		return float64(DamerauLevenshteinDistance(a, b))
+	case EditJaro, EditJaroWinkler:
+		return 1.0 - editSimilarity(s, a, b)
+	}
+	return float64(LevenshteinDistance(a, b))
+}
+
+// ############################################################################
+// Edit Distance Functions
+// ############################################################################
+
+// LevenshteinDistance returns the minimum number of single item insertions,
+// deletions, and substitutions needed to change sequence a into sequence b.
+// Use `[]rune(aString)` to compare strings by character.
+func LevenshteinDistance[S ~[]T, T comparable](a, b S) (distance int) {
+	// Only two rows of the distance matrix are needed at a time
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution
+			)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// OSADistance returns the optimal string alignment distance, which is the
+// [LevenshteinDistance] with the addition of transpositions of two adjacent
+// items, with the restriction that no substring is edited more than once.
+// This is also known as the restricted Damerau-Levenshtein distance.
+func OSADistance[S ~[]T, T comparable](a, b S) (distance int) {
+	// Only three rows of the distance matrix are needed at a time
+	prevprev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution
+			)
+			if 1 < i && 1 < j && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prevprev[j-2]+1) // transposition
+			}
+		}
+		prevprev, prev, curr = prev, curr, prevprev
+	}
+
+	return prev[len(b)]
+}
+
+// DamerauLevenshteinDistance returns the (unrestricted) Damerau-Levenshtein
+// distance, which is the minimum number of insertions, deletions,
+// substitutions, and transpositions of two adjacent items needed to change
+// sequence a into sequence b. Unlike [OSADistance], substrings may be edited
+// more than once, so "CA" to "ABC" has a distance of 2 rather than 3.
+func DamerauLevenshteinDistance[S ~[]T, T comparable](a, b S) (distance int) {
+	// The full distance matrix is needed, with an extra first row and column
+	// which hold the maximum possible distance
+	maxDist := len(a) + len(b)
+	d := make([][]int, len(a)+2)
+	for i := range d {
+		d[i] = make([]int, len(b)+2)
+	}
+	d[0][0] = maxDist
+	for i := 0; i <= len(a); i++ {
+		d[i+1][0] = maxDist
+		d[i+1][1] = i
+	}
+	for j := 0; j <= len(b); j++ {
+		d[0][j+1] = maxDist
+		d[1][j+1] = j
+	}
+
+	// The last row each item was seen in sequence a
+	lastRow := make(map[T]int)
+
+	for i := 1; i <= len(a); i++ {
+		// The last column in this row where the items matched
+		lastMatchCol := 0
+		for j := 1; j <= len(b); j++ {
+			k := lastRow[b[j-1]]
+			l := lastMatchCol
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+				lastMatchCol = j
+			}
+			d[i+1][j+1] = min(
+				d[i][j]+cost,              // substitution
+				d[i+1][j]+1,               // insertion
+				d[i][j+1]+1,               // deletion
+				d[k][l]+(i-k-1)+1+(j-l-1), // transposition
+			)
+		}
+		lastRow[a[i-1]] = i
+	}
+
+	return d[len(a)+1][len(b)+1]
+}
+
+// EditSimilarity normalizes an edit distance between two sequences of the
+// given lengths to a similarity in the range [0.0, 1.0] by dividing it by the
+// length of the longer sequence and subtracting the result from one. Two empty
+// sequences have a similarity of 1.0.
+func EditSimilarity(distance, lenA, lenB int) (similarity float64) {
+	longest := max(lenA, lenB)
+	if longest == 0 {
+		return 1.0
+	}
+	return mathematics.BoundToRange(1.0-float64(distance)/float64(longest), 0.0, 1.0)
+}
+
+// JaroSimilarity returns the Jaro similarity of the two sequences in the range
+// [0.0, 1.0], which is based on the number of matching items within a window
+// of each other and the number of transpositions between those matches. Two
+// empty sequences have a similarity of 1.0.
+func JaroSimilarity[S ~[]T, T comparable](a, b S) (similarity float64) {
+	if len(a) == 0 && len(b) == 0 {
+		return 1.0
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0.0
+	}
+
+	// Items match if they are equal and no further apart than the window
+	window := max(0, max(len(a), len(b))/2-1)
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		start := max(0, i-window)
+		end := min(len(b), i+window+1)
+		for j := start; j < end; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i] = true
+				matchedB[j] = true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0.0
+	}
+
+	// Count the matched items which are out of order
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	t := float64(transpositions) / 2.0
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-t)/m) / 3.0
+}
+
+// JaroWinklerSimilarity returns the Jaro-Winkler similarity of the two
+// sequences in the range [0.0, 1.0], which boosts the [JaroSimilarity] of
+// sequences which share a common prefix of up to four items. The prefixScale
+// should be in the range [0.0, 0.25] to keep the similarity at or below 1.0;
+// [DefaultJaroWinklerPrefixScale] is the standard value.
+func JaroWinklerSimilarity[S ~[]T, T comparable](a, b S, prefixScale float64) (similarity float64) {
+	similarity = JaroSimilarity(a, b)
+
+	// Find the length of the common prefix, up to four items
+	prefix := 0
+	for prefix < min(4, len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	return mathematics.BoundToRange(similarity+float64(prefix)*prefixScale*(1.0-similarity), 0.0, 1.0)
+}
+
+// ############################################################################
+// EditSimilarizerOption
+// ############################################################################
+
+// An EditSimilarizerOption function sets options for an [EditSimilarizer].
+type EditSimilarizerOption func(s *EditSimilarizer)
+
+// Returns a function which sets an [EditSimilarizer]s [EditMethod].
+func EditSimilarizerWithMethod(method EditMethod) EditSimilarizerOption {
+	return func(s *EditSimilarizer) {
+		s.method = method
+	}
+}
+
+// Returns a function which sets an [EditSimilarizer]s [tokenize.Tokenizer]. If
+// set, edits are counted on whole tokens rather than runes.
+func EditSimilarizerWithTokenizer(tokenizer tokenize.Tokenizer) EditSimilarizerOption {
+	return func(s *EditSimilarizer) {
+		s.tokenizer = tokenizer
+	}
+}
+
+// Returns a function which sets an [EditSimilarizer]s Jaro-Winkler prefix
+// scale, which must be in the range [0.0, 0.25].
+func EditSimilarizerWithPrefixScale(scale float64) EditSimilarizerOption {
+	return func(s *EditSimilarizer) {
+		s.prefixScale = scale
+	}
+}
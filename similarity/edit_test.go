@@ -0,0 +1,203 @@
+package similarity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/similarity"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/tokenlist"
+)
+
+func TestNewEditSimilarizer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		sim, err := similarity.NewEditSimilarizer()
+		require.NoError(t, err)
+		require.NotNil(t, sim)
+		require.Equal(t, similarity.EditLevenshtein, sim.Method())
+		require.Nil(t, sim.Tokenizer())
+		require.Equal(t, similarity.DefaultJaroWinklerPrefixScale, sim.PrefixScale())
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		tok := tokenize.NewWhitespaceTokenizer()
+		sim, err := similarity.NewEditSimilarizer(
+			similarity.EditSimilarizerWithMethod(similarity.EditJaroWinkler),
+			similarity.EditSimilarizerWithTokenizer(tok),
+			similarity.EditSimilarizerWithPrefixScale(0.2),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, sim)
+		require.Equal(t, similarity.EditJaroWinkler, sim.Method())
+		require.Equal(t, tok, sim.Tokenizer())
+		require.Equal(t, 0.2, sim.PrefixScale())
+	})
+
+	t.Run("ErrorMethod", func(t *testing.T) {
+		sim, err := similarity.NewEditSimilarizer(similarity.EditSimilarizerWithMethod(similarity.EditMethod(100)))
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+		require.Nil(t, sim)
+	})
+
+	t.Run("ErrorPrefixScale", func(t *testing.T) {
+		sim, err := similarity.NewEditSimilarizer(similarity.EditSimilarizerWithPrefixScale(0.5))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, sim)
+	})
+}
+
+func TestEditDistances(t *testing.T) {
+	// NOTE: "ca" -> "abc" is the classic case where the OSA distance differs from
+	// the unrestricted Damerau-Levenshtein distance
+	testcases := []struct {
+		A           string
+		B           string
+		Levenshtein int
+		OSA         int
+		Damerau     int
+	}{
+		{"", "", 0, 0, 0},
+		{"abc", "", 3, 3, 3},
+		{"", "abc", 3, 3, 3},
+		{"kitten", "sitting", 3, 3, 3},
+		{"flaw", "lawn", 2, 2, 2},
+		{"ab", "ba", 2, 1, 1},
+		{"ca", "abc", 3, 3, 2},
+		{"Saturday", "Sunday", 3, 3, 3},
+		{"naïve", "naive", 1, 1, 1},
+		{"teh", "the", 2, 1, 1},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.A+"_"+tc.B, func(t *testing.T) {
+			a, b := []rune(tc.A), []rune(tc.B)
+			require.Equal(t, tc.Levenshtein, similarity.LevenshteinDistance(a, b), "levenshtein")
+			require.Equal(t, tc.OSA, similarity.OSADistance(a, b), "osa")
+			require.Equal(t, tc.Damerau, similarity.DamerauLevenshteinDistance(a, b), "damerau-levenshtein")
+		})
+	}
+}
+
+func TestJaroSimilarities(t *testing.T) {
+	// The MARTHA, DWAYNE, and DIXON examples are the classic examples from
+	// Winkler (1990)
+	testcases := []struct {
+		A           string
+		B           string
+		Jaro        float64
+		JaroWinkler float64
+	}{
+		{"", "", 1.0, 1.0},
+		{"abc", "", 0.0, 0.0},
+		{"abc", "xyz", 0.0, 0.0},
+		{"MARTHA", "MARHTA", 0.9444444444444445, 0.9611111111111111},
+		{"DWAYNE", "DUANE", 0.8222222222222223, 0.8400000000000001},
+		{"DIXON", "DICKSONX", 0.7666666666666666, 0.8133333333333332},
+		{"same", "same", 1.0, 1.0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.A+"_"+tc.B, func(t *testing.T) {
+			a, b := []rune(tc.A), []rune(tc.B)
+			require.InDelta(t, tc.Jaro, similarity.JaroSimilarity(a, b), 1e-12, "jaro")
+			require.InDelta(t, tc.JaroWinkler, similarity.JaroWinklerSimilarity(a, b, similarity.DefaultJaroWinklerPrefixScale), 1e-12, "jaro-winkler")
+		})
+	}
+}
+
+func TestEditSimilarity(t *testing.T) {
+	require.Equal(t, 1.0, similarity.EditSimilarity(0, 0, 0))
+	require.Equal(t, 0.0, similarity.EditSimilarity(3, 3, 0))
+	require.InDelta(t, 0.5714285714285714, similarity.EditSimilarity(3, 6, 7), 1e-12)
+}
+
+func TestEditSimilarizer(t *testing.T) {
+	testcases := []struct {
+		Name       string
+		Method     similarity.EditMethod
+		Tokenizer  tokenize.Tokenizer
+		A          string
+		B          string
+		Similarity float64
+		Distance   float64
+	}{
+		{
+			Name:       "Levenshtein",
+			Method:     similarity.EditLevenshtein,
+			A:          "kitten",
+			B:          "sitting",
+			Similarity: 0.5714285714285714,
+			Distance:   3,
+		},
+		{
+			Name:       "OSA",
+			Method:     similarity.EditOSA,
+			A:          "teh",
+			B:          "the",
+			Similarity: 0.6666666666666667,
+			Distance:   1,
+		},
+		{
+			Name:       "DamerauLevenshtein",
+			Method:     similarity.EditDamerauLevenshtein,
+			A:          "ca",
+			B:          "abc",
+			Similarity: 0.33333333333333337,
+			Distance:   2,
+		},
+		{
+			Name:       "Jaro",
+			Method:     similarity.EditJaro,
+			A:          "MARTHA",
+			B:          "MARHTA",
+			Similarity: 0.9444444444444445,
+			Distance:   0.05555555555555547,
+		},
+		{
+			Name:       "JaroWinkler",
+			Method:     similarity.EditJaroWinkler,
+			A:          "MARTHA",
+			B:          "MARHTA",
+			Similarity: 0.9611111111111111,
+			Distance:   0.03888888888888886,
+		},
+		{
+			Name:       "LevenshteinTokens",
+			Method:     similarity.EditLevenshtein,
+			Tokenizer:  tokenize.NewRegexTokenizer(),
+			A:          "the quick brown fox",
+			B:          "the quick red fox jumps",
+			Similarity: 0.6,
+			Distance:   2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sim, err := similarity.NewEditSimilarizer(
+				similarity.EditSimilarizerWithMethod(tc.Method),
+				similarity.EditSimilarizerWithTokenizer(tc.Tokenizer),
+			)
+			require.NoError(t, err)
+
+			actual, err := sim.Similarity(tc.A, tc.B)
+			require.NoError(t, err)
+			require.InDelta(t, tc.Similarity, actual, 1e-12)
+
+			actual, err = sim.Distance(tc.A, tc.B)
+			require.NoError(t, err)
+			require.InDelta(t, tc.Distance, actual, 1e-12)
+		})
+	}
+}
+
+func TestEditSimilarizerTokens(t *testing.T) {
+	sim, err := similarity.NewEditSimilarizer(similarity.EditSimilarizerWithMethod(similarity.EditOSA))
+	require.NoError(t, err)
+
+	a := tokenlist.New([]string{"new", "york", "city"})
+	b := tokenlist.New([]string{"york", "new", "city"})
+	require.Equal(t, 1.0, sim.DistanceTokens(a, b))
+	require.InDelta(t, 0.6666666666666667, sim.SimilarityTokens(a, b), 1e-12)
+}
@@ -0,0 +1,385 @@
+package similarity
+
+import (
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/ngrams"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// ############################################################################
+// Set type
+// ############################################################################
+
+// Set is a set of unique comparable items, such as stems or shingles.
+type Set[T comparable] map[T]struct{}
+
+// Returns a new [Set] containing the unique items.
+func NewSet[T comparable](items ...T) Set[T] {
+	set := make(Set[T], len(items))
+	for _, item := range items {
+		set[item] = struct{}{}
+	}
+	return set
+}
+
+// Returns a new [Set] containing the keys of the map, such as the types from
+// [tokenize.TypeCounter.TypeCount].
+func NewSetFromKeys[T comparable, V any](m map[T]V) Set[T] {
+	set := make(Set[T], len(m))
+	for key := range m {
+		set[key] = struct{}{}
+	}
+	return set
+}
+
+// Returns the number of items in both sets.
+func (s Set[T]) IntersectionLen(other Set[T]) (count int) {
+	// Loop over the smaller set
+	small, large := s, other
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	for item := range small {
+		if _, ok := large[item]; ok {
+			count++
+		}
+	}
+	return count
+}
+
+// ############################################################################
+// SetMethod and ShingleUnit "enums"
+// ############################################################################
+
+// SetMethod selects the set similarity measure used by a [SetSimilarizer].
+type SetMethod uint8
+
+const (
+	SetUnknown SetMethod = iota
+	// See [Jaccard].
+	SetJaccard
+	// See [Dice].
+	SetDice
+	// See [Overlap].
+	SetOverlap
+	// See [Tversky].
+	SetTversky
+)
+
+// ShingleUnit selects what the shingles (n-grams) compared by a
+// [SetSimilarizer] are made of.
+type ShingleUnit uint8
+
+const (
+	ShingleUnknown ShingleUnit = iota
+	// Shingles are n-grams of word stems; with n = 1 this is the set of types
+	// from [tokenize.TypeCounter.TypeCount].
+	ShingleStems
+	// Shingles are n-grams of the characters in the lowercase tokens joined by
+	// single spaces.
+	ShingleCharacters
+)
+
+// ############################################################################
+// SetSimilarizer
+// ############################################################################
+
+/*
+SetSimilarizer can be used to calculate the similarity of two text strings by
+comparing their sets of stems or shingles (n-grams), which does not require a
+vocabulary; create with [NewSetSimilarizer].
+
+Usage example:
+
+	// Create a Jaccard similarizer over word stems
+	sim, err := similarity.NewSetSimilarizer()
+
+	// Compare two strings
+	score, err := sim.Similarity("the cats sat", "a cat sat") // 0.5
+
+	// Create a Dice similarizer over character trigrams
+	sim, err = similarity.NewSetSimilarizer(
+		similarity.SetSimilarizerWithMethod(similarity.SetDice),
+		similarity.SetSimilarizerWithShingles(similarity.ShingleCharacters, 3),
+	)
+*/
+type SetSimilarizer struct {
+	lang        language.Language
+	typeCounter *tokenize.TypeCounter
+	method      SetMethod
+	unit        ShingleUnit
+	n           int
+	alpha       float64
+	beta        float64
+}
+
+// Ensure [SetSimilarizer] meets the [Similarizer] interface requirements.
+var _ Similarizer = &SetSimilarizer{}
+
+// Returns a new [SetSimilarizer] with the options set.
+//
+// Defaults:
+//   - Lang: [language.English]
+//   - TypeCounter: [tokenize.TypeCounter]
+//   - Method: [SetJaccard]
+//   - Shingles: [ShingleStems] with n = 1
+//   - Tversky alpha and beta: 0.5 and 0.5 (equivalent to [SetDice])
+func NewSetSimilarizer(opts ...SetSimilarizerOption) (similarizer *SetSimilarizer, err error) {
+	// Set defaults which are not zero values, then set options
+	similarizer = &SetSimilarizer{
+		alpha: 0.5,
+		beta:  0.5,
+	}
+	for _, fn := range opts {
+		fn(similarizer)
+	}
+
+	// Set defaults
+
+	if similarizer.lang == language.Unknown {
+		similarizer.lang = language.English
+	}
+
+	if similarizer.typeCounter == nil {
+		if similarizer.typeCounter, err = tokenize.NewTypeCounter(
+			tokenize.TypeCounterWithLanguage(similarizer.lang),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if similarizer.method == SetUnknown {
+		similarizer.method = SetJaccard
+	}
+
+	if similarizer.unit == ShingleUnknown {
+		similarizer.unit = ShingleStems
+	}
+
+	if similarizer.n == 0 {
+		similarizer.n = 1
+	}
+
+	// Validate options
+	if similarizer.method > SetTversky || similarizer.unit > ShingleCharacters {
+		return nil, errors.ErrMethodNotSupported
+	}
+	if similarizer.n < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the shingle size must be positive"))
+	}
+	if similarizer.alpha < 0.0 || similarizer.beta < 0.0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the Tversky alpha and beta cannot be negative"))
+	}
+
+	return similarizer, nil
+}
+
+// Returns the [SetSimilarizer]s configured [language.Language].
+func (s *SetSimilarizer) Language() language.Language {
+	return s.lang
+}
+
+// Returns the [SetSimilarizer]s configured [tokenize.TypeCounter].
+func (s *SetSimilarizer) TypeCounter() *tokenize.TypeCounter {
+	return s.typeCounter
+}
+
+// Returns the [SetSimilarizer]s configured [SetMethod].
+func (s *SetSimilarizer) Method() SetMethod {
+	return s.method
+}
+
+// Returns the [SetSimilarizer]s configured [ShingleUnit].
+func (s *SetSimilarizer) ShingleUnit() ShingleUnit {
+	return s.unit
+}
+
+// Returns the [SetSimilarizer]s configured shingle size (n).
+func (s *SetSimilarizer) ShingleSize() int {
+	return s.n
+}
+
+// Returns the [SetSimilarizer]s configured Tversky alpha and beta weights.
+func (s *SetSimilarizer) TverskyWeights() (alpha, beta float64) {
+	return s.alpha, s.beta
+}
+
+// Similarity returns a value in the range [0.0, 1.0] that indicates how similar
+// two strings are by comparing their sets of shingles with the configured
+// [SetMethod]. Returns [errors.ErrUndefinedValue] if neither string has any
+// shingles.
+func (s *SetSimilarizer) Similarity(a, b string) (similarity float64, err error) {
+	var setA, setB Set[string]
+	if setA, err = s.Shingles(a); err != nil {
+		return 0.0, err
+	}
+	if setB, err = s.Shingles(b); err != nil {
+		return 0.0, err
+	}
+	return s.Compare(setA, setB)
+}
+
+// Compare returns the similarity of two sets using the configured [SetMethod].
+func (s *SetSimilarizer) Compare(a, b Set[string]) (similarity float64, err error) {
+	switch s.method {
+	case SetJaccard:
+		return Jaccard(a, b)
+	case SetDice:
+		return Dice(a, b)
+	case SetOverlap:
+		return Overlap(a, b)
+	case SetTversky:
+		return Tversky(a, b, s.alpha, s.beta)
+	}
+	return 0.0, errors.ErrMethodNotSupported
+}
+
+// Shingles returns the set of shingles for a string using the configured
+// [ShingleUnit] and shingle size.
+func (s *SetSimilarizer) Shingles(chunk string) (shingles Set[string], err error) {
+	// Tokenize using the type counter's tokenizer
+	var tokens []string
+	if tokens, err = s.typeCounter.Tokenizer().Tokenize(chunk); err != nil {
+		return nil, err
+	}
+
+	switch s.unit {
+	case ShingleStems:
+		for i, tok := range tokens {
+			tokens[i] = s.typeCounter.Stemmer().Stem(tok)
+		}
+		return WordShingles(tokens, s.n), nil
+	case ShingleCharacters:
+		for i, tok := range tokens {
+			tokens[i] = strings.ToLower(tok)
+		}
+		return CharacterShingles(strings.Join(tokens, " "), s.n), nil
+	}
+	return nil, errors.ErrMethodNotSupported
+}
+
+// ############################################################################
+// Shingling Functions
+// ############################################################################
+
+// WordShingles returns the set of word n-grams (using [ngrams.Ngrams]), with
+// the words in each n-gram joined by a single space.
+func WordShingles(words []string, n int) (shingles Set[string]) {
+	grams := ngrams.Ngrams(words, n)
+	shingles = make(Set[string], len(grams))
+	for _, gram := range grams {
+		shingles[strings.Join(gram, " ")] = struct{}{}
+	}
+	return shingles
+}
+
+// CharacterShingles returns the set of character (rune) n-grams in the chunk
+// (using [ngrams.Ngrams]).
+func CharacterShingles(chunk string, n int) (shingles Set[string]) {
+	grams := ngrams.Ngrams([]rune(chunk), n)
+	shingles = make(Set[string], len(grams))
+	for _, gram := range grams {
+		shingles[string(gram)] = struct{}{}
+	}
+	return shingles
+}
+
+// ############################################################################
+// Set Similarity Functions
+// ############################################################################
+
+// Jaccard returns the Jaccard index of two sets, `|A ∩ B| / |A ∪ B|`, in the
+// range [0.0, 1.0]. Returns [errors.ErrUndefinedValue] if both sets are empty.
+func Jaccard[T comparable](a, b Set[T]) (similarity float64, err error) {
+	inter := a.IntersectionLen(b)
+	union := len(a) + len(b) - inter
+	if union == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return float64(inter) / float64(union), nil
+}
+
+// Dice returns the Sørensen–Dice coefficient of two sets,
+// `2|A ∩ B| / (|A| + |B|)`, in the range [0.0, 1.0]. Returns
+// [errors.ErrUndefinedValue] if both sets are empty.
+func Dice[T comparable](a, b Set[T]) (similarity float64, err error) {
+	total := len(a) + len(b)
+	if total == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return 2.0 * float64(a.IntersectionLen(b)) / float64(total), nil
+}
+
+// Overlap returns the overlap (Szymkiewicz–Simpson) coefficient of two sets,
+// `|A ∩ B| / min(|A|, |B|)`, in the range [0.0, 1.0]. Returns
+// [errors.ErrUndefinedValue] if either set is empty.
+func Overlap[T comparable](a, b Set[T]) (similarity float64, err error) {
+	smallest := min(len(a), len(b))
+	if smallest == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return float64(a.IntersectionLen(b)) / float64(smallest), nil
+}
+
+// Tversky returns the Tversky index of two sets,
+// `|A ∩ B| / (|A ∩ B| + α|A - B| + β|B - A|)`, in the range [0.0, 1.0]. With
+// α = β = 1 this is the [Jaccard] index and with α = β = 0.5 this is the [Dice]
+// coefficient. Returns [errors.ErrUndefinedValue] if the denominator is zero.
+func Tversky[T comparable](a, b Set[T], alpha, beta float64) (similarity float64, err error) {
+	inter := float64(a.IntersectionLen(b))
+	denom := inter + alpha*(float64(len(a))-inter) + beta*(float64(len(b))-inter)
+	if denom == 0.0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return inter / denom, nil
+}
+
+// ############################################################################
+// SetSimilarizerOption
+// ############################################################################
+
+// A SetSimilarizerOption function sets options for a [SetSimilarizer].
+type SetSimilarizerOption func(s *SetSimilarizer)
+
+// Returns a function which sets a [SetSimilarizer]s [language.Language].
+func SetSimilarizerWithLanguage(lang language.Language) SetSimilarizerOption {
+	return func(s *SetSimilarizer) {
+		s.lang = lang
+	}
+}
+
+// Returns a function which sets a [SetSimilarizer]s [tokenize.TypeCounter],
+// which provides the tokenizer and stemmer used for shingling.
+func SetSimilarizerWithTypeCounter(typecounter *tokenize.TypeCounter) SetSimilarizerOption {
+	return func(s *SetSimilarizer) {
+		s.typeCounter = typecounter
+	}
+}
+
+// Returns a function which sets a [SetSimilarizer]s [SetMethod].
+func SetSimilarizerWithMethod(method SetMethod) SetSimilarizerOption {
+	return func(s *SetSimilarizer) {
+		s.method = method
+	}
+}
+
+// Returns a function which sets a [SetSimilarizer]s [ShingleUnit] and the
+// number of units (n) in each shingle.
+func SetSimilarizerWithShingles(unit ShingleUnit, n int) SetSimilarizerOption {
+	return func(s *SetSimilarizer) {
+		s.unit = unit
+		s.n = n
+	}
+}
+
+// Returns a function which sets a [SetSimilarizer]s Tversky alpha and beta
+// weights, which are only used with [SetTversky].
+func SetSimilarizerWithTverskyWeights(alpha, beta float64) SetSimilarizerOption {
+	return func(s *SetSimilarizer) {
+		s.alpha = alpha
+		s.beta = beta
+	}
+}
@@ -0,0 +1,185 @@
+package similarity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/similarity"
+)
+
+func TestNewSetSimilarizer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		sim, err := similarity.NewSetSimilarizer()
+		require.NoError(t, err)
+		require.NotNil(t, sim)
+		require.NotNil(t, sim.TypeCounter())
+		require.Equal(t, similarity.SetJaccard, sim.Method())
+		require.Equal(t, similarity.ShingleStems, sim.ShingleUnit())
+		require.Equal(t, 1, sim.ShingleSize())
+		alpha, beta := sim.TverskyWeights()
+		require.Equal(t, 0.5, alpha)
+		require.Equal(t, 0.5, beta)
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		sim, err := similarity.NewSetSimilarizer(
+			similarity.SetSimilarizerWithMethod(similarity.SetTversky),
+			similarity.SetSimilarizerWithShingles(similarity.ShingleCharacters, 3),
+			similarity.SetSimilarizerWithTverskyWeights(1.0, 0.0),
+		)
+		require.NoError(t, err)
+		require.Equal(t, similarity.SetTversky, sim.Method())
+		require.Equal(t, similarity.ShingleCharacters, sim.ShingleUnit())
+		require.Equal(t, 3, sim.ShingleSize())
+		alpha, beta := sim.TverskyWeights()
+		require.Equal(t, 1.0, alpha)
+		require.Equal(t, 0.0, beta)
+	})
+
+	t.Run("ErrorMethod", func(t *testing.T) {
+		sim, err := similarity.NewSetSimilarizer(similarity.SetSimilarizerWithMethod(similarity.SetMethod(100)))
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+		require.Nil(t, sim)
+	})
+
+	t.Run("ErrorNegativeWeights", func(t *testing.T) {
+		sim, err := similarity.NewSetSimilarizer(similarity.SetSimilarizerWithTverskyWeights(-1.0, 0.5))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, sim)
+	})
+}
+
+func TestSetFunctions(t *testing.T) {
+	a := similarity.NewSet("a", "b", "c", "d")
+	b := similarity.NewSet("c", "d", "e")
+	empty := similarity.NewSet[string]()
+
+	require.Equal(t, 2, a.IntersectionLen(b))
+
+	testcases := []struct {
+		Name     string
+		Func     func(a, b similarity.Set[string]) (float64, error)
+		Expected float64
+	}{
+		{"Jaccard", similarity.Jaccard[string], 2.0 / 5.0},
+		{"Dice", similarity.Dice[string], 4.0 / 7.0},
+		{"Overlap", similarity.Overlap[string], 2.0 / 3.0},
+		{
+			Name: "TverskyAsJaccard",
+			Func: func(a, b similarity.Set[string]) (float64, error) {
+				return similarity.Tversky(a, b, 1.0, 1.0)
+			},
+			Expected: 2.0 / 5.0,
+		},
+		{
+			Name: "TverskyAsDice",
+			Func: func(a, b similarity.Set[string]) (float64, error) {
+				return similarity.Tversky(a, b, 0.5, 0.5)
+			},
+			Expected: 4.0 / 7.0,
+		},
+		{
+			Name: "TverskyAsymmetric",
+			Func: func(a, b similarity.Set[string]) (float64, error) {
+				return similarity.Tversky(a, b, 0.0, 1.0)
+			},
+			Expected: 2.0 / 3.0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := tc.Func(a, b)
+			require.NoError(t, err)
+			require.InDelta(t, tc.Expected, actual, 1e-12)
+
+			// Identical sets are completely similar
+			actual, err = tc.Func(a, a)
+			require.NoError(t, err)
+			require.InDelta(t, 1.0, actual, 1e-12)
+
+			// Empty sets are undefined
+			_, err = tc.Func(empty, empty)
+			require.ErrorIs(t, err, errors.ErrUndefinedValue)
+		})
+	}
+}
+
+func TestShingles(t *testing.T) {
+	words := similarity.WordShingles([]string{"a", "b", "a", "b"}, 2)
+	require.Equal(t, similarity.NewSet("a b", "b a"), words)
+
+	chars := similarity.CharacterShingles("abab", 2)
+	require.Equal(t, similarity.NewSet("ab", "ba"), chars)
+
+	// Sequences shorter than n have no shingles
+	require.Empty(t, similarity.CharacterShingles("a", 2))
+}
+
+func TestSetSimilarizer(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Options  []similarity.SetSimilarizerOption
+		A        string
+		B        string
+		Expected float64
+	}{
+		{
+			Name:     "JaccardStems",
+			A:        "the cats sat",
+			B:        "a cat sat",
+			Expected: 0.5,
+		},
+		{
+			Name: "DiceStemBigrams",
+			Options: []similarity.SetSimilarizerOption{
+				similarity.SetSimilarizerWithMethod(similarity.SetDice),
+				similarity.SetSimilarizerWithShingles(similarity.ShingleStems, 2),
+			},
+			A:        "the cats sat down",
+			B:        "a cat sat down",
+			Expected: 0.6666666666666666, // {cat sat, sat down} in both of 3 each
+		},
+		{
+			Name: "OverlapCharacters",
+			Options: []similarity.SetSimilarizerOption{
+				similarity.SetSimilarizerWithMethod(similarity.SetOverlap),
+				similarity.SetSimilarizerWithShingles(similarity.ShingleCharacters, 3),
+			},
+			A:        "Rotational",
+			B:        "rotation labs",
+			Expected: 0.75, // 6 of the 8 trigrams of "rotational" are in "rotation labs"
+		},
+		{
+			Name: "TverskyCharacters",
+			Options: []similarity.SetSimilarizerOption{
+				similarity.SetSimilarizerWithMethod(similarity.SetTversky),
+				similarity.SetSimilarizerWithShingles(similarity.ShingleCharacters, 2),
+				similarity.SetSimilarizerWithTverskyWeights(1.0, 0.0),
+			},
+			A:        "night",
+			B:        "nacht",
+			Expected: 0.25, // {ni, ig, gh, ht} vs {na, ac, ch, ht}
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sim, err := similarity.NewSetSimilarizer(tc.Options...)
+			require.NoError(t, err)
+
+			actual, err := sim.Similarity(tc.A, tc.B)
+			require.NoError(t, err)
+			require.InDelta(t, tc.Expected, actual, 1e-12)
+		})
+	}
+
+	t.Run("ErrorNoShingles", func(t *testing.T) {
+		sim, err := similarity.NewSetSimilarizer()
+		require.NoError(t, err)
+
+		_, err = sim.Similarity("", "!!!")
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+}
@@ -0,0 +1,492 @@
+package stem
+
+import (
+	_ "embed"
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// PartOfSpeech
+// ############################################################################
+
+// A hint for the [Lemmatizer] about the word class of a word, which selects the
+// exception list and the rules used to find its lemma.
+type PartOfSpeech uint8
+
+const (
+	// The part of speech is not known: the exceptions for every part of speech
+	// are checked, then the noun rules and the verb rules are tried.
+	UnknownPartOfSpeech PartOfSpeech = iota
+	Noun
+	Verb
+	Adjective
+	Adverb
+)
+
+// ############################################################################
+// Word Lists
+// ############################################################################
+
+//go:embed wordlists/noun.exc
+var nounExceptionData string
+
+//go:embed wordlists/verb.exc
+var verbExceptionData string
+
+//go:embed wordlists/adj.exc
+var adjectiveExceptionData string
+
+//go:embed wordlists/adv.exc
+var adverbExceptionData string
+
+//go:embed wordlists/lemmas.txt
+var lemmaData string
+
+// Irregular inflections (e.g. "mice" or "went") mapped to their lemmas for each
+// part of speech, from the exception lists compiled for this package, which
+// have an inflected form and its lemma on each line (the format of WordNet's
+// exception files).
+var lemmaExceptions = map[PartOfSpeech]map[string]string{
+	Noun:      parseExceptions(nounExceptionData),
+	Verb:      parseExceptions(verbExceptionData),
+	Adjective: parseExceptions(adjectiveExceptionData),
+	Adverb:    parseExceptions(adverbExceptionData),
+}
+
+// Words which are already lemmas but look inflected to the rules (e.g.
+// "species", "during" or "sober"), so they are not changed.
+var knownLemmas = parseLemmas(lemmaData)
+
+// The order the exceptions are checked for [UnknownPartOfSpeech], which
+// prefers the nouns and adjectives to the verbs (e.g. "better" is "good").
+var exceptionOrder = []PartOfSpeech{Noun, Adjective, Verb, Adverb}
+
+// Returns the exceptions in the data, which has an inflected form and its lemma
+// separated by whitespace on each line.
+func parseExceptions(data string) (exceptions map[string]string) {
+	exceptions = make(map[string]string)
+	for line := range strings.Lines(data) {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			exceptions[fields[0]] = fields[1]
+		}
+	}
+	return exceptions
+}
+
+// Returns the set of whitespace separated words in the data.
+func parseLemmas(data string) (lemmas map[string]struct{}) {
+	lemmas = make(map[string]struct{})
+	for _, word := range strings.Fields(data) {
+		lemmas[word] = struct{}{}
+	}
+	return lemmas
+}
+
+// ############################################################################
+// Lemmatizer
+// ############################################################################
+
+// Ensure [Lemmatizer] meets the [stemming.Stemmer] interface requirements.
+var _ Stemmer = &Lemmatizer{}
+
+/*
+Lemmatizer is a rule-based lemmatizer which maps inflected words to their
+dictionary forms (lemmas), e.g. "running" to "run", "mice" to "mouse" and
+"better" to "good", using small exception lists for common irregular forms and
+suffix rules for the regular inflections; create with [NewLemmatizer]. Unlike
+WordNet's morphy it does not check the candidate lemmas against a lexicon, so
+irregular forms which are not in the exception lists may get a lemma which is
+not a word. Unlike the stems from a [Porter2Stemmer] (e.g. "gener" for
+"generation") the lemmas are usually words, so they are readable in reports.
+
+An optional [PartOfSpeech] hint selects the exceptions and rules. Without a hint
+the noun rules are tried before the verb rules, and the comparatives and
+superlatives of regular adjectives (e.g. "happier") are only reduced with the
+[Adjective] hint. Words which are not inflected are returned lowercased.
+
+A [Lemmatizer] is a [Stemmer], so it can be used in place of the default stemmer
+of a text (e.g. with text.WithStemmer) to count human-readable types.
+
+Usage example:
+
+	lemmatizer, err := stem.NewLemmatizer()
+	lemmatizer.Stem("Children") // "child"
+	lemmatizer.Lemmatize("happiest", stem.Adjective) // "happy"
+*/
+type Lemmatizer struct {
+	lang language.Language
+	pos  PartOfSpeech
+}
+
+// Returns a new [Lemmatizer], or an error if the language is not supported.
+//
+// Defaults:
+//   - Language: [language.English] (the only supported language)
+//   - Part of speech: [UnknownPartOfSpeech]
+func NewLemmatizer(opts ...LemmatizerOption) (lemmatizer *Lemmatizer, err error) {
+	// Set defaults
+	lemmatizer = &Lemmatizer{
+		lang: language.English,
+		pos:  UnknownPartOfSpeech,
+	}
+
+	// Set options
+	for _, fn := range opts {
+		fn(lemmatizer)
+	}
+
+	// Only English word lists are available
+	if lemmatizer.lang != language.English {
+		return nil, errors.ErrLanguageNotSupported
+	}
+
+	return lemmatizer, nil
+}
+
+// Returns the [Lemmatizer]s configured [language.Language].
+func (l *Lemmatizer) Language() language.Language {
+	return l.lang
+}
+
+// Returns the [Lemmatizer]s configured [PartOfSpeech] hint used by
+// [Lemmatizer.Stem].
+func (l *Lemmatizer) PartOfSpeech() PartOfSpeech {
+	return l.pos
+}
+
+// Returns the lemma of the word using the configured [PartOfSpeech] hint.
+// Whitespace will be trimmed and the lemma will be returned in all lowercase.
+func (l *Lemmatizer) Stem(word string) (lemma string) {
+	return l.Lemmatize(word, l.pos)
+}
+
+// Returns the lemma of the word as the [PartOfSpeech] given. Whitespace will be
+// trimmed and the lemma will be returned in all lowercase.
+func (l *Lemmatizer) Lemmatize(word string, pos PartOfSpeech) (lemma string) {
+	word = strings.TrimSpace(strings.ToLower(word))
+
+	if pos == UnknownPartOfSpeech {
+		// Check the lemmas first, since e.g. "ground" is more often the noun
+		// than the past tense of "grind"
+		if _, ok := knownLemmas[word]; ok {
+			return word
+		}
+		for _, p := range exceptionOrder {
+			if lemma, ok := lemmaExceptions[p][word]; ok {
+				return lemma
+			}
+		}
+		if lemma = lemmatizeNoun(word); lemma != word {
+			return lemma
+		}
+		return lemmatizeVerb(word)
+	}
+
+	// Check the exceptions, then the lemmas, then the rules
+	if lemma, ok := lemmaExceptions[pos][word]; ok {
+		return lemma
+	}
+	if _, ok := knownLemmas[word]; ok {
+		return word
+	}
+
+	switch pos {
+	case Noun:
+		return lemmatizeNoun(word)
+	case Verb:
+		return lemmatizeVerb(word)
+	case Adjective:
+		return lemmatizeAdjective(word)
+	default:
+		// There are no rules for adverbs
+		return word
+	}
+}
+
+// ############################################################################
+// LemmatizerOption
+// ############################################################################
+
+// LemmatizerOption functions modify a [Lemmatizer].
+type LemmatizerOption func(l *Lemmatizer)
+
+// Returns a function which sets the [language.Language] of a [Lemmatizer].
+func LemmatizerWithLanguage(lang language.Language) LemmatizerOption {
+	return func(l *Lemmatizer) {
+		l.lang = lang
+	}
+}
+
+// Returns a function which sets the [PartOfSpeech] hint a [Lemmatizer] uses for
+// [Lemmatizer.Stem], e.g. when all of the words are known to be verbs.
+func LemmatizerWithPartOfSpeech(pos PartOfSpeech) LemmatizerOption {
+	return func(l *Lemmatizer) {
+		l.pos = pos
+	}
+}
+
+// ############################################################################
+// Morphological Rules
+// ############################################################################
+
+// Returns the singular of a regular plural noun, e.g. "cities" to "city",
+// "boxes" to "box" and "firemen" to "fireman".
+func lemmatizeNoun(word string) string {
+	if strings.HasSuffix(word, "men") && len(word) > 4 {
+		return word[:len(word)-3] + "man"
+	}
+	return removeS(word)
+}
+
+// Returns the base form of a regular verb, e.g. "studies" to "study", "hoped"
+// to "hope", "stopped" to "stop" and "making" to "make".
+func lemmatizeVerb(word string) string {
+	n := len(word)
+	switch {
+	case strings.HasSuffix(word, "ing"):
+		return restoreBase(word, word[:n-3])
+
+	case strings.HasSuffix(word, "ied"):
+		// "studied" but "died"
+		if n > 4 {
+			return word[:n-3] + "y"
+		}
+		return word[:n-1]
+
+	case strings.HasSuffix(word, "eed"):
+		// "need" and "proceed" are not inflected, "agreed" is an exception
+		return word
+
+	case strings.HasSuffix(word, "ed"):
+		return restoreBase(word, word[:n-2])
+
+	default:
+		return removeS(word)
+	}
+}
+
+// Returns the positive form of a regular comparative or superlative adjective,
+// e.g. "happier" to "happy", "biggest" to "big" and "nicer" to "nice".
+func lemmatizeAdjective(word string) string {
+	n := len(word)
+	switch {
+	case strings.HasSuffix(word, "iest") && n > 5:
+		return word[:n-4] + "y"
+	case strings.HasSuffix(word, "ier") && n > 4:
+		return word[:n-3] + "y"
+	case strings.HasSuffix(word, "est"):
+		return restoreBase(word, word[:n-3])
+	case strings.HasSuffix(word, "er"):
+		return restoreBase(word, word[:n-2])
+	default:
+		return word
+	}
+}
+
+// Returns the word without a plural or third person "s" ending, e.g. "cats" to
+// "cat", "wishes" to "wish", "flies" to "fly" and "ties" to "tie". Words ending
+// in "ss", "us" or "is" (e.g. "glass", "status" or "analysis") and words of
+// three or fewer letters are not changed.
+func removeS(word string) string {
+	n := len(word)
+	switch {
+	case n < 4 || !strings.HasSuffix(word, "s"):
+		return word
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "ies"):
+		// "cities" but not "ties"
+		if n > 4 {
+			return word[:n-3] + "y"
+		}
+		return word[:n-1]
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zzes"):
+		return word[:n-2]
+	case strings.HasSuffix(word, "oes") && n > 5:
+		// "heroes" but not "shoes"
+		return word[:n-2]
+	default:
+		return word[:n-1]
+	}
+}
+
+// Returns the base of the word with the suffix removed, undoubling a final
+// consonant (e.g. "stopp" to "stop") or restoring a final "e" (e.g. "hop" to
+// "hope") as needed, or the word itself if the base has no vowel (e.g. "thing"
+// or "bed").
+func restoreBase(word, base string) string {
+	n := len(base)
+	if n < 2 || syllables(base) == 0 {
+		return word
+	}
+
+	if base[n-1] == base[n-2] && !isLemmaVowel(base[n-1]) {
+		return undouble(base)
+	}
+	if needsFinalE(base) {
+		return base + "e"
+	}
+	return base
+}
+
+// Returns the base with a final doubled consonant undoubled (e.g. "stopp" to
+// "stop", "controll" to "control") unless the consonant is doubled in the
+// lemma (e.g. "add", "call", "miss" or "buzz").
+func undouble(base string) string {
+	n := len(base)
+	switch base[n-1] {
+	case 'b', 'd', 'g', 'k', 'm', 'n', 'p', 'r', 't', 'v':
+		// Short words which start with a vowel keep the doubled consonant
+		if n == 3 && isLemmaVowel(base[0]) {
+			return base
+		}
+		return base[:n-1]
+	case 'l':
+		// Only longer words double a final "l" after "e" or "o" (e.g.
+		// "travelled" or "controlled" but "called" and "spelled")
+		if (base[n-3] == 'e' || base[n-3] == 'o') && syllables(base) > 1 {
+			return base[:n-1]
+		}
+	}
+	return base
+}
+
+// Endings of a consonant, a single vowel and a consonant after which the lemma
+// always has a final "e", because verbs which end in these letters without an
+// "e" double the consonant (e.g. "decided", "required" and "determined").
+var finalESyllables = []string{"ar", "at", "id", "il", "in", "ir", "ud", "ul", "ur", "ut"}
+
+// Prefixes which are removed to check if the rest of the base is a short
+// syllable which takes a final "e" (e.g. "explor" or "complet").
+var finalEPrefixes = []string{"ad", "as", "com", "con", "de", "dis", "es", "ex", "im", "in", "pre", "pro", "re", "sub", "trans"}
+
+// Consonants which can begin a syllable after one of the [finalEPrefixes].
+var syllableOnsets = map[string]struct{}{
+	"b": {}, "c": {}, "d": {}, "f": {}, "g": {}, "h": {}, "j": {}, "k": {}, "l": {}, "m": {}, "n": {}, "p": {},
+	"q": {}, "r": {}, "s": {}, "t": {}, "v": {}, "w": {}, "z": {}, "bl": {}, "br": {}, "ch": {}, "cl": {},
+	"cr": {}, "dr": {}, "fl": {}, "fr": {}, "gl": {}, "gr": {}, "kn": {}, "pl": {}, "pr": {}, "sc": {},
+	"scr": {}, "sh": {}, "sk": {}, "sl": {}, "sm": {}, "sn": {}, "sp": {}, "spl": {}, "spr": {}, "sq": {},
+	"st": {}, "str": {}, "sw": {}, "th": {}, "thr": {}, "tr": {}, "tw": {}, "wh": {}, "wr": {},
+}
+
+// Returns true if the base of a word (with the suffix removed) needs a final
+// "e" to be a lemma, e.g. "hop" (from "hoped"), "danc", "handl" or "complet".
+func needsFinalE(base string) bool {
+	n := len(base)
+	last := base[n-1]
+
+	switch {
+	case last == 'c', last == 'u', last == 'v':
+		// "dance", "continue" and "love"
+		return true
+	case last == 'z':
+		// "realize" but not "waltz"
+		return base[n-2] != 't'
+	case last == 's':
+		// "cause" and "sense"
+		return true
+	case last == 'g':
+		// "change", "plunge" and "challenge" but not "belong", "sing" or "hang"
+		if base[n-2] != 'n' {
+			return true
+		}
+		return strings.HasSuffix(base, "chang") || strings.HasSuffix(base, "rang") || strings.HasSuffix(base, "eng") ||
+			(strings.HasSuffix(base, "ung") && n > 3 && !isLemmaVowel(base[n-4]))
+	case last == 'l' && strings.IndexByte("bcdfgkpstz", base[n-2]) >= 0:
+		// "handle" and "cycle" but not "curl"
+		return true
+	case strings.HasSuffix(base, "th"):
+		// "breathe" but not "smooth" or "mouth"
+		return n > 2 && isLemmaVowel(base[n-3]) && !strings.HasSuffix(base, "ooth") && !strings.HasSuffix(base, "outh")
+	case strings.HasSuffix(base, "uad"), strings.HasSuffix(base, "iat"), strings.HasSuffix(base, "uat"),
+		strings.HasSuffix(base, "creat"), strings.HasSuffix(base, "phon"):
+		// "persuade", "initiate", "evaluate", "create" and "telephone"
+		return true
+	}
+
+	// A consonant, a single vowel and one of the endings which drop an "e"
+	if n > 2 && isSingleVowel(base, n-2) {
+		for _, ending := range finalESyllables {
+			if strings.HasSuffix(base, ending) {
+				return true
+			}
+		}
+	}
+
+	// A short syllable, alone (e.g. "hop") or after a prefix (e.g. "explor")
+	if isShortSyllable(base) {
+		return true
+	}
+	for _, prefix := range finalEPrefixes {
+		if rest, ok := strings.CutPrefix(base, prefix); ok && hasSyllableOnset(rest) && isShortSyllable(rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the word is a single syllable which ends in a consonant
+// (other than w, x or y) after a single vowel, like the short words which
+// drop a final "e" before a suffix (e.g. "hop", "stor" or "quot").
+func isShortSyllable(word string) bool {
+	n := len(word)
+	if n < 2 || syllables(word) != 1 {
+		return false
+	}
+
+	last := word[n-1]
+	if isLemmaVowel(last) || strings.IndexByte("wxy", last) >= 0 {
+		return false
+	}
+	return isSingleVowel(word, n-2)
+}
+
+// Returns true if the byte at i is a vowel (or a "y" after a consonant, e.g.
+// "typ") which does not follow another vowel, where the "u" in "qu" and "gu"
+// is not a vowel (e.g. "quot" and "guid").
+func isSingleVowel(word string, i int) bool {
+	if !isLemmaVowel(word[i]) && (word[i] != 'y' || i == 0) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	before := word[i-1]
+	if before == 'u' && i > 1 && (word[i-2] == 'q' || word[i-2] == 'g') {
+		return true
+	}
+	return !isLemmaVowel(before) && before != 'y'
+}
+
+// Returns true if the word begins with consonants which can begin a syllable.
+func hasSyllableOnset(word string) bool {
+	i := strings.IndexAny(word, "aeiouy")
+	if i <= 0 {
+		return false
+	}
+	_, ok := syllableOnsets[word[:i]]
+	return ok
+}
+
+// Returns the number of syllables (groups of vowels) in the word, where "y" is
+// a vowel unless it begins the word or follows a vowel.
+func syllables(word string) (count int) {
+	prev := false
+	for i := 0; i < len(word); i++ {
+		vowel := isLemmaVowel(word[i]) || (word[i] == 'y' && i > 0 && !prev)
+		if vowel && !prev {
+			count++
+		}
+		prev = vowel
+	}
+	return count
+}
+
+// Returns true if the byte is one of the vowels a, e, i, o or u.
+func isLemmaVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
@@ -0,0 +1,129 @@
+package stem_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/stem"
+)
+
+func TestNewLemmatizer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		lemmatizer, err := stem.NewLemmatizer()
+		require.NoError(t, err)
+		require.Equal(t, language.English, lemmatizer.Language())
+		require.Equal(t, stem.UnknownPartOfSpeech, lemmatizer.PartOfSpeech())
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		lemmatizer, err := stem.NewLemmatizer(
+			stem.LemmatizerWithLanguage(language.English),
+			stem.LemmatizerWithPartOfSpeech(stem.Verb),
+		)
+		require.NoError(t, err)
+		require.Equal(t, stem.Verb, lemmatizer.PartOfSpeech())
+		require.Equal(t, "grind", lemmatizer.Stem("ground"))
+		require.Equal(t, "ground", lemmatizer.Lemmatize("ground", stem.UnknownPartOfSpeech))
+	})
+
+	t.Run("ErrorLanguage", func(t *testing.T) {
+		lemmatizer, err := stem.NewLemmatizer(stem.LemmatizerWithLanguage(language.French))
+		require.ErrorIs(t, err, errors.ErrLanguageNotSupported)
+		require.Nil(t, lemmatizer)
+	})
+}
+
+func TestLemmatizer(t *testing.T) {
+	lemmatizer, err := stem.NewLemmatizer()
+	require.NoError(t, err)
+
+	testcases := []struct {
+		Word     string
+		POS      stem.PartOfSpeech
+		Expected string
+	}{
+		// Regular and irregular nouns
+		{"Cats", stem.Noun, "cat"},
+		{"cities", stem.Noun, "city"},
+		{"churches", stem.Noun, "church"},
+		{"boxes", stem.Noun, "box"},
+		{"heroes", stem.Noun, "hero"},
+		{"shoes", stem.Noun, "shoe"},
+		{"ties", stem.Noun, "tie"},
+		{"policemen", stem.Noun, "policeman"},
+		{"children", stem.Noun, "child"},
+		{"mice", stem.Noun, "mouse"},
+		{"leaves", stem.Noun, "leaf"},
+		{"criteria", stem.Noun, "criterion"},
+		{"analyses", stem.Noun, "analysis"},
+		{"viruses", stem.Noun, "virus"},
+		{"movies", stem.Noun, "movie"},
+		{"glass", stem.Noun, "glass"},
+		{"status", stem.Noun, "status"},
+		{"species", stem.Noun, "species"},
+		{"specimen", stem.Noun, "specimen"},
+
+		// Regular and irregular verbs
+		{"makes", stem.Verb, "make"},
+		{"studies", stem.Verb, "study"},
+		{"studied", stem.Verb, "study"},
+		{"died", stem.Verb, "die"},
+		{"hoped", stem.Verb, "hope"},
+		{"hopped", stem.Verb, "hop"},
+		{"running", stem.Verb, "run"},
+		{"making", stem.Verb, "make"},
+		{"seeing", stem.Verb, "see"},
+		{"added", stem.Verb, "add"},
+		{"called", stem.Verb, "call"},
+		{"controlled", stem.Verb, "control"},
+		{"generated", stem.Verb, "generate"},
+		{"handled", stem.Verb, "handle"},
+		{"changing", stem.Verb, "change"},
+		{"singing", stem.Verb, "sing"},
+		{"continued", stem.Verb, "continue"},
+		{"explored", stem.Verb, "explore"},
+		{"visited", stem.Verb, "visit"},
+		{"opened", stem.Verb, "open"},
+		{"needed", stem.Verb, "need"},
+		{"agreed", stem.Verb, "agree"},
+		{"went", stem.Verb, "go"},
+		{"was", stem.Verb, "be"},
+		{"written", stem.Verb, "write"},
+		{"lying", stem.Verb, "lie"},
+
+		// Adjectives and adverbs
+		{"happier", stem.Adjective, "happy"},
+		{"biggest", stem.Adjective, "big"},
+		{"nicer", stem.Adjective, "nice"},
+		{"larger", stem.Adjective, "large"},
+		{"simplest", stem.Adjective, "simple"},
+		{"smaller", stem.Adjective, "small"},
+		{"better", stem.Adjective, "good"},
+		{"worst", stem.Adjective, "bad"},
+		{"clever", stem.Adjective, "clever"},
+		{"better", stem.Adverb, "well"},
+		{"quickly", stem.Adverb, "quickly"},
+
+		// Without a part of speech hint
+		{"dogs", stem.UnknownPartOfSpeech, "dog"},
+		{"running", stem.UnknownPartOfSpeech, "run"},
+		{"generations", stem.UnknownPartOfSpeech, "generation"},
+		{"better", stem.UnknownPartOfSpeech, "good"},
+		{"happier", stem.UnknownPartOfSpeech, "happier"},
+		{"ground", stem.UnknownPartOfSpeech, "ground"},
+		{"thing", stem.UnknownPartOfSpeech, "thing"},
+		{"during", stem.UnknownPartOfSpeech, "during"},
+		{"hundred", stem.UnknownPartOfSpeech, "hundred"},
+		{" Always ", stem.UnknownPartOfSpeech, "always"},
+	}
+
+	for _, tc := range testcases {
+		require.Equal(t, tc.Expected, lemmatizer.Lemmatize(tc.Word, tc.POS), "wrong lemma for %q (%d)", tc.Word, tc.POS)
+	}
+
+	// The lemmatizer is a stemmer
+	var stemmer stem.Stemmer = lemmatizer
+	require.Equal(t, "mouse", stemmer.Stem("mice"))
+}
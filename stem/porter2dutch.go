@@ -0,0 +1,194 @@
+package stem
+
+import (
+	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Porter2Stemmer Dutch Steps
+// ############################################################################
+
+// Returns the stem for the selected Dutch word using the Dutch Snowball
+// algorithm. Whitespace will be trimmed and the stem will be returned in all
+// lowercase.
+func (p *Porter2Stemmer) StemDutch(word string) (stem string) {
+	// Ensure the language configured is Dutch, in case the user did not call
+	// [Porter2Stemmer.Stem] to get here.
+	defer p.useLanguage(language.Dutch)()
+
+	// Lowercase and remove any whitespace, then put the word into the buffer
+	// with the accents and umlauts removed
+	word = strings.TrimSpace(strings.ToLower(word))
+	p.word = []rune(dutchAccents.Replace(word))
+
+	// Mark an initial "y", a "y" after a vowel, and an "i" between vowels as
+	// non-vowels
+	if len(p.word) > 0 && p.word[0] == 'y' {
+		p.word[0] = 'Y'
+	}
+	for i := 1; i < len(p.word); i++ {
+		if p.isVowel(i-1) && (p.word[i] == 'y' || p.word[i] == 'i' && p.hasVowelAt(i+1)) {
+			p.word[i] = unicode.ToUpper(p.word[i])
+		}
+	}
+
+	// Setup the regions R1 and R2
+	p.markRegions()
+
+	// Run the Snowball algorithm steps in order; step 3b removes "bar" only if
+	// step 2 removed an "e"
+	p.step_1_Dutch()
+	eRemoved := p.step_2_Dutch()
+	p.step_3a_Dutch()
+	p.step_3b_Dutch(eRemoved)
+	p.step_4_Dutch()
+
+	// Unmark the "i" and "y" non-vowels
+	return strings.ToLower(string(p.word))
+}
+
+// Replaces the acute accents and umlauts with unaccented vowels before
+// stemming.
+var dutchAccents = strings.NewReplacer(
+	"á", "a", "ä", "a", "é", "e", "ë", "e", "í", "i", "ï", "i", "ó", "o", "ö", "o", "ú", "u", "ü", "u",
+)
+
+// Performs step 1 of the Dutch Snowball algorithm on the word buffer, which
+// removes the suffixes "heden", "en", "ene", "s" and "se".
+func (p *Porter2Stemmer) step_1_Dutch() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "heden", "en", "ene", "s", "se")
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+
+	case "heden":
+		// Replace with "heid" if in R1
+		if p.inRegion(longest, p.p1) {
+			p.swapSuffix(longest, "heid")
+		}
+
+	case "en", "ene":
+		p.removeEnEndingDutch(longest)
+
+	case "s", "se":
+		// Delete if in R1 and preceded by a non-vowel other than "j"
+		idx := len(p.word) - len([]rune(longest)) - 1
+		if p.inRegion(longest, p.p1) && p.hasNonVowelAt(idx) && p.word[idx] != 'j' {
+			p.deleteSuffix(longest)
+		}
+	}
+}
+
+// Performs step 2 of the Dutch Snowball algorithm on the word buffer, which
+// removes a final "e". Returns true if the "e" was removed.
+func (p *Porter2Stemmer) step_2_Dutch() (removed bool) {
+	return p.removeEEndingDutch()
+}
+
+// Performs step 3a of the Dutch Snowball algorithm on the word buffer, which
+// removes the suffix "heid" in R2 if not preceded by "c", and then an "en"
+// before it.
+func (p *Porter2Stemmer) step_3a_Dutch() {
+	if !p.inRegion("heid", p.p2) || p.precededBy("heid", "c") {
+		return
+	}
+	p.deleteSuffix("heid")
+	if p.endsWith("en") {
+		p.removeEnEndingDutch("en")
+	}
+}
+
+// Performs step 3b of the Dutch Snowball algorithm on the word buffer, which
+// removes the derivational suffixes in R2. The "bar" suffix is only removed if
+// an "e" was removed in step 2.
+func (p *Porter2Stemmer) step_3b_Dutch(eRemoved bool) {
+	longest := p.longestMatchingSuffix(0, len(p.word), "end", "ing", "ig", "lijk", "baar", "bar")
+	if longest == "" || !p.inRegion(longest, p.p2) {
+		return
+	}
+
+	// Perform the operation
+	switch longest {
+	case "end", "ing":
+		// Delete, then delete a preceding "ig" in R2 if not preceded by "e",
+		// otherwise undouble the ending
+		p.deleteSuffix(longest)
+		if p.inRegion("ig", p.p2) && !p.precededBy("ig", "e") {
+			p.deleteSuffix("ig")
+		} else {
+			p.undoubleDutch()
+		}
+
+	case "ig":
+		// Delete if not preceded by "e"
+		if !p.precededBy(longest, "e") {
+			p.deleteSuffix(longest)
+		}
+
+	case "lijk":
+		// Delete, then remove a final "e" as in step 2
+		p.deleteSuffix(longest)
+		p.removeEEndingDutch()
+
+	case "baar":
+		p.deleteSuffix(longest)
+
+	case "bar":
+		if eRemoved {
+			p.deleteSuffix(longest)
+		}
+	}
+}
+
+// Performs step 4 of the Dutch Snowball algorithm on the word buffer, which
+// undoubles a vowel in a final non-vowel, double vowel, non-vowel sequence
+// (e.g. "maan" to "man").
+func (p *Porter2Stemmer) step_4_Dutch() {
+	n := len(p.word)
+	if n < 4 || p.isVowel(n-1) || p.word[n-1] == 'I' || p.isVowel(n-4) {
+		return
+	}
+
+	// Delete the second vowel of a double "aa", "ee", "oo" or "uu"
+	switch p.word[n-2] {
+	case 'a', 'e', 'o', 'u':
+		if p.word[n-3] == p.word[n-2] {
+			p.word = append(p.word[:n-2], p.word[n-1])
+		}
+	}
+}
+
+// Removes a final "e" in R1 preceded by a non-vowel, and then undoubles the
+// ending, for the Dutch Snowball algorithm. Returns true if the "e" was
+// removed.
+func (p *Porter2Stemmer) removeEEndingDutch() (removed bool) {
+	if !p.inRegion("e", p.p1) || !p.hasNonVowelAt(len(p.word)-2) {
+		return false
+	}
+	p.deleteSuffix("e")
+	p.undoubleDutch()
+	return true
+}
+
+// Removes a final "en" or "ene" in R1 preceded by a non-vowel but not by
+// "gem", and then undoubles the ending, for the Dutch Snowball algorithm.
+func (p *Porter2Stemmer) removeEnEndingDutch(suffix string) {
+	if !p.inRegion(suffix, p.p1) || !p.hasNonVowelAt(len(p.word)-len([]rune(suffix))-1) || p.precededBy(suffix, "gem") {
+		return
+	}
+	p.deleteSuffix(suffix)
+	p.undoubleDutch()
+}
+
+// Removes the last rune of a final "kk", "dd" or "tt" for the Dutch Snowball
+// algorithm.
+func (p *Porter2Stemmer) undoubleDutch() {
+	if p.longestMatchingSuffix(0, len(p.word), "kk", "dd", "tt") != "" {
+		p.removeSuffix(1)
+	}
+}
@@ -3,6 +3,7 @@ package stem
 import (
 	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"go.rtnl.ai/nlp/language"
 )
@@ -465,8 +466,14 @@ func (p *Porter2Stemmer) step_5_English() {
 // Porter2Stemmer Helpers
 // ############################################################################
 
-// Sets the R1 and R2 region pointers for the current word buffer.
+// Sets the R1 and R2 region pointers for the current word buffer. Only the
+// English regions are updated as the word changes, the other languages set the
+// regions once with [Porter2Stemmer.markRegions].
 func (p *Porter2Stemmer) setRegions() {
+	if p.lang != language.English {
+		return
+	}
+
 	// Find R1
 	p.p1 = p.findRegionStart(0)
 
@@ -489,6 +496,93 @@ func (p *Porter2Stemmer) setRegions() {
 	p.p2 = p.findRegionStart(p.p1)
 }
 
+// Sets the language of the stemmer for a language-specific stem function, in
+// case the user did not call [Porter2Stemmer.Stem] to get there, and returns a
+// function which resets the language when done.
+func (p *Porter2Stemmer) useLanguage(lang language.Language) (reset func()) {
+	oldLang := p.lang
+	p.lang = lang
+	return func() { p.lang = oldLang }
+}
+
+// Sets the R1 and R2 region pointers, and the RV region pointer for the
+// Romance languages, for the current word buffer as defined by the Snowball
+// algorithms. Unlike English, the regions are not moved when suffixes are
+// removed.
+func (p *Porter2Stemmer) markRegions() {
+	// R2 is found after the unadjusted R1
+	p.p1 = p.findRegionStart(0)
+	p.p2 = p.findRegionStart(p.p1)
+	p.pv = len(p.word)
+
+	switch p.lang {
+	case language.Spanish, language.Portuguese, language.Italian:
+		p.pv = p.findRVStart()
+
+	case language.French:
+		p.pv = p.findRVStartFrench()
+
+	case language.German, language.Dutch:
+		// R1 starts after at least three runes
+		if p.p1 < 3 {
+			p.p1 = min(3, len(p.word))
+		}
+	}
+}
+
+// Finds the start of the region RV for Spanish, Portuguese and Italian. If the
+// second rune is a non-vowel, RV is after the next vowel; if the first two
+// runes are vowels, RV is after the next non-vowel; otherwise RV starts after
+// the third rune.
+func (p *Porter2Stemmer) findRVStart() int {
+	if len(p.word) < 2 {
+		return len(p.word)
+	}
+
+	switch {
+	case !p.isVowel(1):
+		for i := 2; i < len(p.word); i++ {
+			if p.isVowel(i) {
+				return i + 1
+			}
+		}
+	case p.isVowel(0):
+		for i := 2; i < len(p.word); i++ {
+			if !p.isVowel(i) {
+				return i + 1
+			}
+		}
+	default:
+		return min(3, len(p.word))
+	}
+
+	// Defaults to "null" region after the word
+	return len(p.word)
+}
+
+// Finds the start of the region RV for French. If the word begins with two
+// vowels, RV starts after the third rune; if the word begins with "par", "col"
+// or "tap", RV starts after the prefix; otherwise RV is after the first vowel
+// which is not at the beginning of the word.
+func (p *Porter2Stemmer) findRVStartFrench() int {
+	if len(p.word) >= 3 && p.isVowel(0) && p.isVowel(1) {
+		return 3
+	}
+
+	if pfx := p.hasAnyPrefix("par", "col", "tap"); pfx != nil {
+		return len(pfx)
+	}
+
+	for i := 1; i < len(p.word); i++ {
+		if p.isVowel(i) {
+			return i + 1
+		}
+	}
+
+	// Defaults to "null" region after the word
+	return len(p.word)
+}
+
 // Finds the start of the next region from the start index in the word buffer.
 // Regions are defined in the Porter2 stemmer algorithm as the region after the
 // next vowel followed by a non-vowel, or the end of the word.
@@ -527,35 +621,51 @@ func (p *Porter2Stemmer) hasAnyPrefix(prefixes ...string) (prefix []rune) {
 // suffix runes provided.
 func (p *Porter2Stemmer) hasSuffix(start, end int, suffix string) (matches bool) {
 	// If the suffix is longer than the word range, it cannot match.
-	if len(p.word[start:end]) < len(suffix) {
+	runes := []rune(suffix)
+	if end-start < len(runes) {
 		return false
 	}
 
-	return slices.Equal(p.word[end-len(suffix):end], []rune(suffix))
+	return slices.Equal(p.word[end-len(runes):end], runes)
 }
 
 // Returns the longest matching suffix of the word buffer slice [start:end].
-func (p *Porter2Stemmer) longestMatchingSuffix(start, end int, suffixes ...string) string {
-	// There can't be a match
-	if start == end {
-		return ""
-	}
-
-	// Sort the suffixes by length and then lexicographically
-	slices.SortFunc(suffixes, func(a, b string) int {
-		if len(a) == len(b) {
-			return strings.Compare(a, b)
-		}
-		return len(b) - len(a)
-	})
-
-	// Find the first matching suffix
+func (p *Porter2Stemmer) longestMatchingSuffix(start, end int, suffixes ...string) (longest string) {
+	// Suffixes which match the same word end are longer in runes if they are
+	// longer in bytes, so compare the byte lengths
 	for _, suffix := range suffixes {
-		if p.hasSuffix(start, end, suffix) {
-			return suffix
+		if len(longest) < len(suffix) && p.hasSuffix(start, end, suffix) {
+			longest = suffix
 		}
 	}
-	return ""
+	return longest
+}
+
+// Returns true if the word buffer ends with the suffix.
+func (p *Porter2Stemmer) endsWith(suffix string) bool {
+	return p.hasSuffix(0, len(p.word), suffix)
+}
+
+// Returns true if the suffix at the end of the word buffer starts in the
+// region which starts at the index given (e.g. [Porter2Stemmer.p1]).
+func (p *Porter2Stemmer) inRegion(suffix string, region int) bool {
+	return p.hasSuffix(region, len(p.word), suffix)
+}
+
+// Returns true if the word buffer part before the suffix at the end of the word
+// buffer ends with the preceding runes provided.
+func (p *Porter2Stemmer) precededBy(suffix, preceding string) bool {
+	return p.hasSuffix(0, len(p.word)-utf8.RuneCountInString(suffix), preceding)
+}
+
+// Removes the suffix from the end of the word buffer.
+func (p *Porter2Stemmer) deleteSuffix(suffix string) {
+	p.removeSuffix(utf8.RuneCountInString(suffix))
+}
+
+// Replaces the suffix at the end of the word buffer with the replacement.
+func (p *Porter2Stemmer) swapSuffix(suffix, replacement string) {
+	p.replaceSuffix(utf8.RuneCountInString(suffix), replacement)
 }
 
 // Removes the last n runes from the word buffer.
@@ -594,10 +704,57 @@ func (p *Porter2Stemmer) isVowel(i int) bool {
 		case 'a', 'e', 'i', 'o', 'u', 'y':
 			return true
 		}
+	case language.Spanish:
+		switch p.word[i] {
+		case 'a', 'e', 'i', 'o', 'u', 'á', 'é', 'í', 'ó', 'ú', 'ü':
+			return true
+		}
+	case language.French:
+		// Capital I, U and Y are marked as non-vowels
+		switch p.word[i] {
+		case 'a', 'e', 'i', 'o', 'u', 'y', 'â', 'à', 'ë', 'é', 'ê', 'è', 'ï', 'î', 'ô', 'û', 'ù':
+			return true
+		}
+	case language.German:
+		// Capital U and Y are marked as non-vowels
+		switch p.word[i] {
+		case 'a', 'e', 'i', 'o', 'u', 'y', 'ä', 'ö', 'ü':
+			return true
+		}
+	case language.Italian:
+		// Capital I and U are marked as non-vowels
+		switch p.word[i] {
+		case 'a', 'e', 'i', 'o', 'u', 'à', 'è', 'ì', 'ò', 'ù':
+			return true
+		}
+	case language.Portuguese:
+		// The nasal vowels are replaced with "a~" and "o~" while stemming
+		switch p.word[i] {
+		case 'a', 'e', 'i', 'o', 'u', 'á', 'é', 'í', 'ó', 'ú', 'â', 'ê', 'ô':
+			return true
+		}
+	case language.Dutch:
+		// Capital I and Y are marked as non-vowels
+		switch p.word[i] {
+		case 'a', 'e', 'i', 'o', 'u', 'y', 'è':
+			return true
+		}
 	}
 	return false
 }
 
+// Returns true if the word buffer index i is in the word and the rune at the
+// index is a vowel; use to check runes around a suffix which may not exist.
+func (p *Porter2Stemmer) hasVowelAt(i int) bool {
+	return 0 <= i && i < len(p.word) && p.isVowel(i)
+}
+
+// Returns true if the word buffer index i is in the word and the rune at the
+// index is not a vowel.
+func (p *Porter2Stemmer) hasNonVowelAt(i int) bool {
+	return 0 <= i && i < len(p.word) && !p.isVowel(i)
+}
+
 // Returns true if runes in the word buffer slice [i:i+1] are a double as
 // defined in the Porter2 algorithm.
 func (p *Porter2Stemmer) isDouble(i int) bool {
@@ -628,6 +785,32 @@ func (p *Porter2Stemmer) isValidLiEnding(i int) bool {
 	return false
 }
 
+// Returns true if the rune in the word buffer at index i is a valid s-ending
+// as defined in the German Snowball algorithm.
+func (p *Porter2Stemmer) isValidSEnding(i int) bool {
+	switch p.lang {
+	case language.German:
+		switch p.word[i] {
+		case 'b', 'd', 'f', 'g', 'h', 'k', 'l', 'm', 'n', 'r', 't':
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the rune in the word buffer at index i is a valid st-ending
+// as defined in the German Snowball algorithm.
+func (p *Porter2Stemmer) isValidStEnding(i int) bool {
+	switch p.lang {
+	case language.German:
+		switch p.word[i] {
+		case 'b', 'd', 'f', 'g', 'h', 'k', 'l', 'm', 'n', 't':
+			return true
+		}
+	}
+	return false
+}
+
 // Returns true if the word buffer slice [:i] ends in a short syllable.
 func (p *Porter2Stemmer) endsShortSyllable(i int) bool {
 	switch p.lang {
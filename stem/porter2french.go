@@ -0,0 +1,390 @@
+package stem
+
+import (
+	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Porter2Stemmer French Steps
+// ############################################################################
+
+// Returns the stem for the selected French word using the French Snowball
+// algorithm. Whitespace will be trimmed and the stem will be returned in all
+// lowercase.
+func (p *Porter2Stemmer) StemFrench(word string) (stem string) {
+	// Ensure the language configured is French, in case the user did not call
+	// [Porter2Stemmer.Stem] to get here.
+	defer p.useLanguage(language.French)()
+
+	// Lowercase and remove any whitespace, then put the word into the buffer
+	p.word = []rune(strings.TrimSpace(strings.ToLower(word)))
+
+	// Mark "u" and "i" between vowels, "y" next to a vowel, and "u" after "q"
+	// as non-vowels
+	for i := 0; i+1 < len(p.word); i++ {
+		switch {
+		case p.isVowel(i) && (p.word[i+1] == 'u' || p.word[i+1] == 'i') && p.hasVowelAt(i+2):
+			p.word[i+1] = unicode.ToUpper(p.word[i+1])
+		case p.isVowel(i) && p.word[i+1] == 'y':
+			p.word[i+1] = 'Y'
+		case p.word[i] == 'y' && p.isVowel(i+1):
+			p.word[i] = 'Y'
+		case p.word[i] == 'q' && p.word[i+1] == 'u':
+			p.word[i+1] = 'U'
+		}
+	}
+
+	// Setup the regions RV, R1 and R2
+	p.markRegions()
+
+	// Run the Snowball algorithm steps in order; step 2 is only done if step 1
+	// did not remove a suffix, and step 3 is done if either removed a suffix,
+	// otherwise step 4 is done
+	if p.step_1_French() || p.step_2a_French() || p.step_2b_French() {
+		p.step_3_French()
+	} else {
+		p.step_4_French()
+	}
+	p.step_5_French()
+	p.step_6_French()
+
+	// Unmark the "i", "u" and "y" non-vowels
+	return strings.ToLower(string(p.word))
+}
+
+// Suffixes for the French standard suffix removal in step 1.
+var frenchStandardSuffixes = []string{
+	"ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes", "ismes", "ables", "istes",
+	"atrice", "ateur", "ation", "atrices", "ateurs", "ations",
+	"logie", "logies",
+	"usion", "ution", "usions", "utions",
+	"ence", "ences",
+	"ement", "ements",
+	"ité", "ités",
+	"if", "ive", "ifs", "ives",
+	"eaux",
+	"aux",
+	"euse", "euses",
+	"issement", "issements",
+	"amment",
+	"emment",
+	"ment", "ments",
+}
+
+// Suffixes for the French verb suffixes beginning with "i" in step 2a.
+var frenchIVerbSuffixes = []string{
+	"îmes", "ît", "îtes", "i", "ie", "ies", "ir", "ira", "irai", "iraIent", "irais", "irait", "iras", "irent", "irez", "iriez", "irions",
+	"irons", "iront", "is", "issaIent", "issais", "issait", "issant", "issante", "issantes", "issants", "isse", "issent", "isses",
+	"issez", "issiez", "issions", "issons", "it",
+}
+
+// Suffixes for the other French verb suffixes in step 2b.
+var frenchVerbSuffixes = []string{
+	"ions",
+	"é", "ée", "ées", "és", "èrent", "er", "era", "erai", "eraIent", "erais", "erait", "eras", "erez", "eriez", "erions", "erons", "eront", "ez", "iez",
+	"âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait", "ant", "ante", "antes", "ants", "as", "asse", "assent", "asses", "assiez", "assions",
+}
+
+// Performs step 1 of the French Snowball algorithm on the word buffer, which
+// removes the standard suffixes. Returns true if a suffix was removed, or false
+// if step 2 should be done, including after replacing the "ment" suffixes.
+func (p *Porter2Stemmer) step_1_French() (removed bool) {
+	longest := p.longestMatchingSuffix(0, len(p.word), frenchStandardSuffixes...)
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+		return false
+
+	case "ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes", "ismes", "ables", "istes":
+		// Delete if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "atrice", "ateur", "ation", "atrices", "ateurs", "ations":
+		// Delete if in R2, then delete a preceding "ic" if in R2 or replace it
+		// with "iqU"
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		p.replaceIcFrench()
+
+	case "logie", "logies":
+		// Replace with "log" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "log")
+
+	case "usion", "ution", "usions", "utions":
+		// Replace with "u" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "u")
+
+	case "ence", "ences":
+		// Replace with "ent" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "ent")
+
+	case "ement", "ements":
+		// Delete if in RV, then handle a preceding "iv", "eus", "abl", "iqU"
+		// or "ièr"
+		if !p.inRegion(longest, p.pv) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+		switch preceding := p.longestMatchingSuffix(0, len(p.word), "iv", "eus", "abl", "iqU", "ièr", "Ièr"); preceding {
+		case "iv":
+			// Delete if in R2, then delete a preceding "at" if in R2
+			if p.inRegion(preceding, p.p2) {
+				p.deleteSuffix(preceding)
+				if p.inRegion("at", p.p2) {
+					p.deleteSuffix("at")
+				}
+			}
+		case "eus":
+			// Delete if in R2, otherwise replace with "eux" if in R1
+			if p.inRegion(preceding, p.p2) {
+				p.deleteSuffix(preceding)
+			} else if p.inRegion(preceding, p.p1) {
+				p.swapSuffix(preceding, "eux")
+			}
+		case "abl", "iqU":
+			// Delete if in R2
+			if p.inRegion(preceding, p.p2) {
+				p.deleteSuffix(preceding)
+			}
+		case "ièr", "Ièr":
+			// Replace with "i" if in RV
+			if p.inRegion(preceding, p.pv) {
+				p.swapSuffix(preceding, "i")
+			}
+		}
+
+	case "ité", "ités":
+		// Delete if in R2, then handle a preceding "abil", "ic" or "iv"
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+		switch preceding := p.longestMatchingSuffix(0, len(p.word), "abil", "ic", "iv"); preceding {
+		case "abil":
+			// Delete if in R2, otherwise replace with "abl"
+			if p.inRegion(preceding, p.p2) {
+				p.deleteSuffix(preceding)
+			} else {
+				p.swapSuffix(preceding, "abl")
+			}
+		case "ic":
+			// Delete if in R2, otherwise replace with "iqU"
+			p.replaceIcFrench()
+		case "iv":
+			// Delete if in R2
+			if p.inRegion(preceding, p.p2) {
+				p.deleteSuffix(preceding)
+			}
+		}
+
+	case "if", "ive", "ifs", "ives":
+		// Delete if in R2, then delete a preceding "at" if in R2 and handle an
+		// "ic" before it
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if p.inRegion("at", p.p2) {
+			p.deleteSuffix("at")
+			p.replaceIcFrench()
+		}
+
+	case "eaux":
+		p.swapSuffix(longest, "eau")
+
+	case "aux":
+		// Replace with "al" if in R1
+		if !p.inRegion(longest, p.p1) {
+			return false
+		}
+		p.swapSuffix(longest, "al")
+
+	case "euse", "euses":
+		// Delete if in R2, otherwise replace with "eux" if in R1
+		switch {
+		case p.inRegion(longest, p.p2):
+			p.deleteSuffix(longest)
+		case p.inRegion(longest, p.p1):
+			p.swapSuffix(longest, "eux")
+		default:
+			return false
+		}
+
+	case "issement", "issements":
+		// Delete if in R1 and preceded by a non-vowel
+		if !p.inRegion(longest, p.p1) || !p.hasNonVowelAt(len(p.word)-len([]rune(longest))-1) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "amment":
+		// Replace with "ant" if in RV, but continue with step 2
+		if p.inRegion(longest, p.pv) {
+			p.swapSuffix(longest, "ant")
+		}
+		return false
+
+	case "emment":
+		// Replace with "ent" if in RV, but continue with step 2
+		if p.inRegion(longest, p.pv) {
+			p.swapSuffix(longest, "ent")
+		}
+		return false
+
+	case "ment", "ments":
+		// Delete if preceded by a vowel in RV, but continue with step 2
+		if idx := len(p.word) - len([]rune(longest)) - 1; idx >= p.pv && p.hasVowelAt(idx) {
+			p.deleteSuffix(longest)
+		}
+		return false
+	}
+
+	return true
+}
+
+// Deletes a final "ic" if in R2, otherwise replaces it with "iqU", for step 1
+// of the French Snowball algorithm.
+func (p *Porter2Stemmer) replaceIcFrench() {
+	switch {
+	case p.inRegion("ic", p.p2):
+		p.deleteSuffix("ic")
+	case p.endsWith("ic"):
+		p.swapSuffix("ic", "iqU")
+	}
+}
+
+// Performs step 2a of the French Snowball algorithm on the word buffer, which
+// removes the verb suffixes beginning with "i" in RV. Returns true if a suffix
+// was removed.
+func (p *Porter2Stemmer) step_2a_French() (removed bool) {
+	longest := p.longestMatchingSuffix(p.pv, len(p.word), frenchIVerbSuffixes...)
+
+	// Delete if preceded by a non-vowel in RV
+	idx := len(p.word) - len([]rune(longest)) - 1
+	if longest == "" || idx < p.pv || !p.hasNonVowelAt(idx) {
+		return false
+	}
+	p.deleteSuffix(longest)
+	return true
+}
+
+// Performs step 2b of the French Snowball algorithm on the word buffer, which
+// removes the other verb suffixes in RV. Returns true if a suffix was removed.
+func (p *Porter2Stemmer) step_2b_French() (removed bool) {
+	longest := p.longestMatchingSuffix(p.pv, len(p.word), frenchVerbSuffixes...)
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+		return false
+
+	case "ions":
+		// Delete if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait", "ant", "ante", "antes", "ants", "as", "asse", "assent", "asses", "assiez", "assions":
+		// Delete, then delete a preceding "e" if in RV
+		p.deleteSuffix(longest)
+		if p.inRegion("e", p.pv) {
+			p.deleteSuffix("e")
+		}
+
+	default:
+		p.deleteSuffix(longest)
+	}
+
+	return true
+}
+
+// Performs step 3 of the French Snowball algorithm on the word buffer, which
+// replaces a final "Y" with "i" or a final "ç" with "c".
+func (p *Porter2Stemmer) step_3_French() {
+	switch {
+	case p.endsWith("Y"):
+		p.swapSuffix("Y", "i")
+	case p.endsWith("ç"):
+		p.swapSuffix("ç", "c")
+	}
+}
+
+// Performs step 4 of the French Snowball algorithm on the word buffer, which
+// removes the residual suffixes.
+func (p *Porter2Stemmer) step_4_French() {
+	// Delete a final "s" not preceded by "a", "i", "o", "u", "è" or "s"
+	if idx := len(p.word) - 2; p.endsWith("s") && idx >= 0 && !strings.ContainsRune("aiouès", p.word[idx]) {
+		p.deleteSuffix("s")
+	}
+
+	// Search for the longest suffix in RV
+	longest := p.longestMatchingSuffix(p.pv, len(p.word), "ion", "ier", "ière", "Ier", "Ière", "e", "ë")
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+
+	case "ion":
+		// Delete if in R2 and preceded by "s" or "t" in RV
+		if p.inRegion(longest, p.p2) && (p.hasSuffix(p.pv, len(p.word), "sion") || p.hasSuffix(p.pv, len(p.word), "tion")) {
+			p.deleteSuffix(longest)
+		}
+
+	case "ier", "ière", "Ier", "Ière":
+		p.swapSuffix(longest, "i")
+
+	case "e":
+		p.deleteSuffix(longest)
+
+	case "ë":
+		// Delete if preceded by "gu" in RV
+		if p.hasSuffix(p.pv, len(p.word), "guë") {
+			p.deleteSuffix(longest)
+		}
+	}
+}
+
+// Performs step 5 of the French Snowball algorithm on the word buffer, which
+// undoubles a final "enn", "onn", "ett", "ell" or "eill".
+func (p *Porter2Stemmer) step_5_French() {
+	if p.longestMatchingSuffix(0, len(p.word), "enn", "onn", "ett", "ell", "eill") != "" {
+		p.removeSuffix(1)
+	}
+}
+
+// Performs step 6 of the French Snowball algorithm on the word buffer, which
+// replaces an "é" or "è" followed by at least one non-vowel at the end of the
+// word with "e".
+func (p *Porter2Stemmer) step_6_French() {
+	i := len(p.word) - 1
+	for i >= 0 && !p.isVowel(i) {
+		i--
+	}
+	if i < len(p.word)-1 && i >= 0 && (p.word[i] == 'é' || p.word[i] == 'è') {
+		p.word[i] = 'e'
+	}
+}
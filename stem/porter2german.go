@@ -0,0 +1,136 @@
+package stem
+
+import (
+	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Porter2Stemmer German Steps
+// ############################################################################
+
+// Returns the stem for the selected German word using the German Snowball
+// algorithm. Whitespace will be trimmed and the stem will be returned in all
+// lowercase.
+func (p *Porter2Stemmer) StemGerman(word string) (stem string) {
+	// Ensure the language configured is German, in case the user did not call
+	// [Porter2Stemmer.Stem] to get here.
+	defer p.useLanguage(language.German)()
+
+	// Lowercase and remove any whitespace, then put the word into the buffer
+	// with "ß" replaced by "ss"
+	word = strings.TrimSpace(strings.ToLower(word))
+	p.word = []rune(strings.ReplaceAll(word, "ß", "ss"))
+
+	// Mark "u" and "y" between vowels as non-vowels
+	for i := 1; i+1 < len(p.word); i++ {
+		if (p.word[i] == 'u' || p.word[i] == 'y') && p.isVowel(i-1) && p.isVowel(i+1) {
+			p.word[i] = unicode.ToUpper(p.word[i])
+		}
+	}
+
+	// Setup the regions R1 and R2
+	p.markRegions()
+
+	// Run the Snowball algorithm steps in order
+	p.step_1_German()
+	p.step_2_German()
+	p.step_3_German()
+
+	// Unmark the "u" and "y" non-vowels and remove the umlauts
+	return germanUmlauts.Replace(strings.ToLower(string(p.word)))
+}
+
+// Replaces the umlauts with unaccented vowels after stemming.
+var germanUmlauts = strings.NewReplacer("ä", "a", "ö", "o", "ü", "u")
+
+// Performs step 1 of the German Snowball algorithm on the word buffer, which
+// removes the suffixes "em", "ern", "er", "e", "en", "es" and "s" in R1.
+func (p *Porter2Stemmer) step_1_German() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "em", "ern", "er", "e", "en", "es", "s")
+	if longest == "" || !p.inRegion(longest, p.p1) {
+		return
+	}
+
+	// Perform the operation
+	switch longest {
+	case "em", "ern", "er":
+		p.deleteSuffix(longest)
+
+	case "e", "en", "es":
+		// Delete, and if preceded by "niss" delete the last "s"
+		p.deleteSuffix(longest)
+		if p.endsWith("niss") {
+			p.removeSuffix(1)
+		}
+
+	case "s":
+		// Delete if preceded by a valid s-ending
+		if idx := len(p.word) - 2; idx >= 0 && p.isValidSEnding(idx) {
+			p.deleteSuffix(longest)
+		}
+	}
+}
+
+// Performs step 2 of the German Snowball algorithm on the word buffer, which
+// removes the suffixes "en", "er", "est" and "st" in R1.
+func (p *Porter2Stemmer) step_2_German() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "en", "er", "est", "st")
+	if longest == "" || !p.inRegion(longest, p.p1) {
+		return
+	}
+
+	// Perform the operation
+	switch longest {
+	case "en", "er", "est":
+		p.deleteSuffix(longest)
+
+	case "st":
+		// Delete if preceded by a valid st-ending, itself preceded by at least
+		// three runes
+		if idx := len(p.word) - 3; idx >= 3 && p.isValidStEnding(idx) {
+			p.deleteSuffix(longest)
+		}
+	}
+}
+
+// Performs step 3 of the German Snowball algorithm on the word buffer, which
+// removes the derivational suffixes in R2.
+func (p *Porter2Stemmer) step_3_German() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "end", "ung", "ig", "ik", "isch", "lich", "heit", "keit")
+	if longest == "" || !p.inRegion(longest, p.p2) {
+		return
+	}
+
+	// Perform the operation
+	switch longest {
+	case "end", "ung":
+		// Delete, then delete a preceding "ig" in R2 if not preceded by "e"
+		p.deleteSuffix(longest)
+		if p.inRegion("ig", p.p2) && !p.precededBy("ig", "e") {
+			p.deleteSuffix("ig")
+		}
+
+	case "ig", "ik", "isch":
+		// Delete if not preceded by "e"
+		if !p.precededBy(longest, "e") {
+			p.deleteSuffix(longest)
+		}
+
+	case "lich", "heit":
+		// Delete, then delete a preceding "er" or "en" in R1
+		p.deleteSuffix(longest)
+		if p.inRegion("er", p.p1) || p.inRegion("en", p.p1) {
+			p.removeSuffix(2)
+		}
+
+	case "keit":
+		// Delete, then delete a preceding "lich" or "ig" in R2
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "lich", "ig"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+		}
+	}
+}
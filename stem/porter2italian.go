@@ -0,0 +1,233 @@
+package stem
+
+import (
+	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Porter2Stemmer Italian Steps
+// ############################################################################
+
+// Returns the stem for the selected Italian word using the Italian Snowball
+// algorithm. Whitespace will be trimmed and the stem will be returned in all
+// lowercase.
+func (p *Porter2Stemmer) StemItalian(word string) (stem string) {
+	// Ensure the language configured is Italian, in case the user did not call
+	// [Porter2Stemmer.Stem] to get here.
+	defer p.useLanguage(language.Italian)()
+
+	// Lowercase and remove any whitespace
+	word = strings.TrimSpace(strings.ToLower(word))
+
+	// Put the word into the word buffer with the acute accents replaced by
+	// grave accents and the "u" after "q" marked as a non-vowel
+	p.word = []rune(italianPrelude.Replace(word))
+
+	// Mark "i" and "u" between vowels as non-vowels
+	for i := 1; i+1 < len(p.word); i++ {
+		if (p.word[i] == 'u' || p.word[i] == 'i') && p.isVowel(i-1) && p.isVowel(i+1) {
+			p.word[i] = unicode.ToUpper(p.word[i])
+		}
+	}
+
+	// Setup the regions RV, R1 and R2
+	p.markRegions()
+
+	// Run the Snowball algorithm steps in order; step 2 is only done if step 1
+	// did not remove a suffix
+	p.step_0_Italian()
+	if !p.step_1_Italian() {
+		p.step_2_Italian()
+	}
+	p.step_3a_Italian()
+	p.step_3b_Italian()
+
+	// Unmark the "i" and "u" non-vowels
+	return strings.ToLower(string(p.word))
+}
+
+// Suffixes for the Italian standard suffix removal in step 1.
+var italianStandardSuffixes = []string{
+	"anza", "anze", "ico", "ici", "ica", "ice", "iche", "ichi", "ismo", "ismi", "abile", "abili", "ibile", "ibili", "ista", "iste", "isti", "istà", "istè", "istì", "oso", "osi", "osa", "ose", "mente", "atrice", "atrici", "ante", "anti",
+	"azione", "azioni", "atore", "atori",
+	"logia", "logie",
+	"uzione", "uzioni", "usione", "usioni",
+	"enza", "enze",
+	"amento", "amenti", "imento", "imenti",
+	"amente",
+	"ità",
+	"ivo", "ivi", "iva", "ive",
+}
+
+// Suffixes for the Italian verb suffixes in step 2.
+var italianVerbSuffixes = []string{
+	"ammo", "ando", "ano", "are", "arono", "asse", "assero", "assi", "assimo", "ata", "ate", "ati", "ato", "ava", "avamo", "avano", "avate", "avi", "avo",
+	"emmo", "enda", "ende", "endi", "endo", "erà", "erai", "eranno", "ere", "erebbe", "erebbero", "erei", "eremmo", "eremo", "ereste", "eresti", "erete",
+	"erò", "erono", "essero", "ete", "eva", "evamo", "evano", "evate", "evi", "evo", "iamo", "immo", "irà", "irai", "iranno", "ire", "irebbe", "irebbero",
+	"irei", "iremmo", "iremo", "ireste", "iresti", "irete", "irò", "irono", "isca", "iscano", "isce", "isci", "isco", "iscono", "issero", "ita", "ite",
+	"iti", "ito", "iva", "ivamo", "ivano", "ivate", "ivi", "ivo", "ono", "uta", "ute", "uti", "uto", "ar", "ir",
+}
+
+// Replaces the acute accents with grave accents and marks the "u" after "q" as
+// a non-vowel before stemming.
+var italianPrelude = strings.NewReplacer("á", "à", "é", "è", "í", "ì", "ó", "ò", "ú", "ù", "qu", "qU")
+
+// Performs step 0 of the Italian Snowball algorithm on the word buffer, which
+// removes attached pronouns after a gerund or infinitive in RV.
+func (p *Porter2Stemmer) step_0_Italian() {
+	pronoun := p.longestMatchingSuffix(0, len(p.word),
+		"ci", "gli", "la", "le", "li", "lo", "mi", "ne", "si", "ti", "vi",
+		"sene", "gliela", "gliele", "glieli", "glielo", "gliene", "mela", "mele", "meli", "melo", "mene",
+		"tela", "tele", "teli", "telo", "tene", "cela", "cele", "celi", "celo", "cene", "vela", "vele", "veli", "velo", "vene",
+	)
+	if pronoun == "" {
+		return
+	}
+
+	// The pronoun must follow a gerund or infinitive in RV
+	end := len(p.word) - len([]rune(pronoun))
+	ending := p.longestMatchingSuffix(0, end, "ando", "endo", "ar", "er", "ir")
+	if ending == "" || !p.hasSuffix(p.pv, end, ending) {
+		return
+	}
+
+	// Perform the operation
+	switch ending {
+	case "ando", "endo":
+		p.deleteSuffix(pronoun)
+
+	case "ar", "er", "ir":
+		p.swapSuffix(pronoun, "e")
+	}
+}
+
+// Performs step 1 of the Italian Snowball algorithm on the word buffer, which
+// removes the standard suffixes. Returns true if a suffix was removed.
+func (p *Porter2Stemmer) step_1_Italian() (removed bool) {
+	longest := p.longestMatchingSuffix(0, len(p.word), italianStandardSuffixes...)
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+		return false
+
+	case "anza", "anze", "ico", "ici", "ica", "ice", "iche", "ichi", "ismo", "ismi", "abile", "abili", "ibile", "ibili", "ista", "iste", "isti", "istà", "istè", "istì", "oso", "osi", "osa", "ose", "mente", "atrice", "atrici", "ante", "anti":
+		// Delete if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "azione", "azioni", "atore", "atori":
+		// Delete if in R2, then delete a preceding "ic" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if p.inRegion("ic", p.p2) {
+			p.deleteSuffix("ic")
+		}
+
+	case "logia", "logie":
+		// Replace with "log" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "log")
+
+	case "uzione", "uzioni", "usione", "usioni":
+		// Replace with "u" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "u")
+
+	case "enza", "enze":
+		// Replace with "ente" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "ente")
+
+	case "amento", "amenti", "imento", "imenti":
+		// Delete if in RV
+		if !p.inRegion(longest, p.pv) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "amente":
+		// Delete if in R1, then delete a preceding "iv" (and an "at" before
+		// it), "os", "ic" or "abil" if in R2
+		if !p.inRegion(longest, p.p1) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "iv", "os", "ic", "abil"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+			if preceding == "iv" && p.inRegion("at", p.p2) {
+				p.deleteSuffix("at")
+			}
+		}
+
+	case "ità":
+		// Delete if in R2, then delete a preceding "abil", "ic" or "iv" if in
+		// R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "abil", "ic", "iv"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+		}
+
+	case "ivo", "ivi", "iva", "ive":
+		// Delete if in R2, then delete a preceding "at" if in R2 (and an "ic"
+		// before it if in R2)
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if p.inRegion("at", p.p2) {
+			p.deleteSuffix("at")
+			if p.inRegion("ic", p.p2) {
+				p.deleteSuffix("ic")
+			}
+		}
+	}
+
+	return true
+}
+
+// Performs step 2 of the Italian Snowball algorithm on the word buffer, which
+// removes the verb suffixes in RV.
+func (p *Porter2Stemmer) step_2_Italian() {
+	if longest := p.longestMatchingSuffix(p.pv, len(p.word), italianVerbSuffixes...); longest != "" {
+		p.deleteSuffix(longest)
+	}
+}
+
+// Performs step 3a of the Italian Snowball algorithm on the word buffer, which
+// deletes a final vowel in RV and then a preceding "i" in RV.
+func (p *Porter2Stemmer) step_3a_Italian() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "a", "e", "i", "o", "à", "è", "ì", "ò")
+	if longest == "" || !p.inRegion(longest, p.pv) {
+		return
+	}
+	p.deleteSuffix(longest)
+	if p.inRegion("i", p.pv) {
+		p.deleteSuffix("i")
+	}
+}
+
+// Performs step 3b of the Italian Snowball algorithm on the word buffer, which
+// replaces a final "ch" or "gh" in RV with "c" or "g".
+func (p *Porter2Stemmer) step_3b_Italian() {
+	if p.inRegion("ch", p.pv) || p.inRegion("gh", p.pv) {
+		p.removeSuffix(1)
+	}
+}
@@ -0,0 +1,224 @@
+package stem
+
+import (
+	"strings"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Porter2Stemmer Portuguese Steps
+// ############################################################################
+
+// Returns the stem for the selected Portuguese word using the Portuguese
+// Snowball algorithm. Whitespace will be trimmed and the stem will be returned
+// in all lowercase.
+func (p *Porter2Stemmer) StemPortuguese(word string) (stem string) {
+	// Ensure the language configured is Portuguese, in case the user did not
+	// call [Porter2Stemmer.Stem] to get here.
+	defer p.useLanguage(language.Portuguese)()
+
+	// Lowercase and remove any whitespace
+	word = strings.TrimSpace(strings.ToLower(word))
+
+	// Put the word into the word buffer with the nasal vowels "ã" and "õ"
+	// replaced by "a~" and "o~"
+	p.word = []rune(portugueseNasalVowels.Replace(word))
+
+	// Setup the regions RV, R1 and R2
+	p.markRegions()
+
+	// Run the Snowball algorithm steps in order; step 3 is only done if step 1
+	// or step 2 removed a suffix, otherwise step 4 is done
+	if p.step_1_Portuguese() || p.step_2_Portuguese() {
+		p.step_3_Portuguese()
+	} else {
+		p.step_4_Portuguese()
+	}
+	p.step_5_Portuguese()
+
+	// Restore the nasal vowels
+	return portugueseNasalVowelsRestore.Replace(string(p.word))
+}
+
+// Suffixes for the Portuguese standard suffix removal in step 1.
+var portugueseStandardSuffixes = []string{
+	"eza", "ezas", "ico", "ica", "icos", "icas", "ismo", "ismos", "ável", "ível", "ista", "istas", "oso", "osa", "osos", "osas", "amento", "amentos", "imento", "imentos", "adora", "ador", "aça~o", "adoras", "adores", "aço~es", "ante", "antes", "ância",
+	"logia", "logias",
+	"uça~o", "uço~es",
+	"ência", "ências",
+	"amente",
+	"mente",
+	"idade", "idades",
+	"iva", "ivo", "ivas", "ivos",
+	"ira", "iras",
+}
+
+// Suffixes for the Portuguese verb suffixes in step 2.
+var portugueseVerbSuffixes = []string{
+	"ada", "ida", "ia", "aria", "eria", "iria", "ará", "ara", "erá", "era", "irá", "ava", "asse", "esse", "isse", "aste", "este", "iste",
+	"ei", "arei", "erei", "irei", "am", "iam", "ariam", "eriam", "iriam", "aram", "eram", "iram", "avam", "em", "arem", "erem", "irem",
+	"assem", "essem", "issem", "ado", "ido", "ando", "endo", "indo", "ara~o", "era~o", "ira~o", "ar", "er", "ir", "as", "adas", "idas",
+	"ias", "arias", "erias", "irias", "arás", "aras", "erás", "eras", "irás", "avas", "es", "ardes", "erdes", "irdes", "ares", "eres",
+	"ires", "asses", "esses", "isses", "astes", "estes", "istes", "is", "ais", "eis", "íeis", "aríeis", "eríeis", "iríeis", "áreis",
+	"areis", "éreis", "ereis", "íreis", "ireis", "ásseis", "ésseis", "ísseis", "áveis", "ados", "idos", "ámos", "amos", "íamos",
+	"aríamos", "eríamos", "iríamos", "áramos", "éramos", "íramos", "ávamos", "emos", "aremos", "eremos", "iremos", "ássemos",
+	"êssemos", "íssemos", "imos", "armos", "ermos", "irmos", "eu", "iu", "ou", "ira", "iras",
+}
+
+// Replaces the nasal vowels with a vowel and a "~" for stemming, and restores
+// them after stemming.
+var (
+	portugueseNasalVowels        = strings.NewReplacer("ã", "a~", "õ", "o~")
+	portugueseNasalVowelsRestore = strings.NewReplacer("a~", "ã", "o~", "õ")
+)
+
+// Performs step 1 of the Portuguese Snowball algorithm on the word buffer,
+// which removes the standard suffixes. Returns true if a suffix was removed.
+func (p *Porter2Stemmer) step_1_Portuguese() (removed bool) {
+	longest := p.longestMatchingSuffix(0, len(p.word), portugueseStandardSuffixes...)
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+		return false
+
+	case "eza", "ezas", "ico", "ica", "icos", "icas", "ismo", "ismos", "ável", "ível", "ista", "istas", "oso", "osa", "osos", "osas", "amento", "amentos", "imento", "imentos", "adora", "ador", "aça~o", "adoras", "adores", "aço~es", "ante", "antes", "ância":
+		// Delete if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "logia", "logias":
+		// Replace with "log" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "log")
+
+	case "uça~o", "uço~es":
+		// Replace with "u" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "u")
+
+	case "ência", "ências":
+		// Replace with "ente" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "ente")
+
+	case "amente":
+		// Delete if in R1, then delete a preceding "iv" (and an "at" before
+		// it), "os", "ic" or "ad" if in R2
+		if !p.inRegion(longest, p.p1) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "iv", "os", "ic", "ad"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+			if preceding == "iv" && p.inRegion("at", p.p2) {
+				p.deleteSuffix("at")
+			}
+		}
+
+	case "mente":
+		// Delete if in R2, then delete a preceding "ante", "avel" or "ível" if
+		// in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "ante", "avel", "ível"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+		}
+
+	case "idade", "idades":
+		// Delete if in R2, then delete a preceding "abil", "ic" or "iv" if in
+		// R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "abil", "ic", "iv"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+		}
+
+	case "iva", "ivo", "ivas", "ivos":
+		// Delete if in R2, then delete a preceding "at" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if p.inRegion("at", p.p2) {
+			p.deleteSuffix("at")
+		}
+
+	case "ira", "iras":
+		// Replace with "ir" if in RV and preceded by "e"
+		if !p.inRegion(longest, p.pv) || !p.precededBy(longest, "e") {
+			return false
+		}
+		p.swapSuffix(longest, "ir")
+	}
+
+	return true
+}
+
+// Performs step 2 of the Portuguese Snowball algorithm on the word buffer,
+// which removes the verb suffixes in RV. Returns true if a suffix was removed.
+func (p *Porter2Stemmer) step_2_Portuguese() (removed bool) {
+	longest := p.longestMatchingSuffix(p.pv, len(p.word), portugueseVerbSuffixes...)
+	if longest == "" {
+		return false
+	}
+	p.deleteSuffix(longest)
+	return true
+}
+
+// Performs step 3 of the Portuguese Snowball algorithm on the word buffer,
+// which deletes a final "i" in RV preceded by "c".
+func (p *Porter2Stemmer) step_3_Portuguese() {
+	if p.inRegion("i", p.pv) && p.endsWith("ci") {
+		p.removeSuffix(1)
+	}
+}
+
+// Performs step 4 of the Portuguese Snowball algorithm on the word buffer,
+// which removes the residual suffixes in RV.
+func (p *Porter2Stemmer) step_4_Portuguese() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "os", "a", "i", "o", "á", "í", "ó")
+	if longest != "" && p.inRegion(longest, p.pv) {
+		p.deleteSuffix(longest)
+	}
+}
+
+// Performs step 5 of the Portuguese Snowball algorithm on the word buffer,
+// which removes a residual "e" in RV and replaces a final "ç" with "c".
+func (p *Porter2Stemmer) step_5_Portuguese() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "e", "é", "ê", "ç")
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+
+	case "e", "é", "ê":
+		// Delete if in RV, then if preceded by "gu" or "ci" delete the "u" or
+		// "i" if in RV
+		if !p.inRegion(longest, p.pv) {
+			return
+		}
+		p.deleteSuffix(longest)
+		if (p.endsWith("gu") || p.endsWith("ci")) && p.pv < len(p.word) {
+			p.removeSuffix(1)
+		}
+
+	case "ç":
+		p.swapSuffix(longest, "c")
+	}
+}
@@ -0,0 +1,261 @@
+package stem
+
+import (
+	"strings"
+
+	"go.rtnl.ai/nlp/language"
+)
+
+// ############################################################################
+// Porter2Stemmer Spanish Steps
+// ############################################################################
+
+// Returns the stem for the selected Spanish word using the Spanish Snowball
+// algorithm. Whitespace will be trimmed and the stem will be returned in all
+// lowercase.
+func (p *Porter2Stemmer) StemSpanish(word string) (stem string) {
+	// Ensure the language configured is Spanish, in case the user did not call
+	// [Porter2Stemmer.Stem] to get here.
+	defer p.useLanguage(language.Spanish)()
+
+	// Lowercase and remove any whitespace, then put the word into the buffer
+	p.word = []rune(strings.TrimSpace(strings.ToLower(word)))
+
+	// Setup the regions RV, R1 and R2
+	p.markRegions()
+
+	// Run the Snowball algorithm steps in order; step 2 is only done if step 1
+	// did not remove a suffix
+	p.step_0_Spanish()
+	if !p.step_1_Spanish() && !p.step_2a_Spanish() {
+		p.step_2b_Spanish()
+	}
+	p.step_3_Spanish()
+
+	// Remove acute accents
+	return spanishAccents.Replace(string(p.word))
+}
+
+// Suffixes for the Spanish standard suffix removal in step 1.
+var spanishStandardSuffixes = []string{
+	"anza", "anzas", "ico", "ica", "icos", "icas", "ismo", "ismos", "able", "ables", "ible", "ibles", "ista", "istas", "oso", "osa", "osos", "osas", "amiento", "amientos", "imiento", "imientos",
+	"adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias",
+	"logía", "logías",
+	"ución", "uciones",
+	"encia", "encias",
+	"amente",
+	"mente",
+	"idad", "idades",
+	"iva", "ivo", "ivas", "ivos",
+}
+
+// Suffixes for the Spanish verb suffixes beginning with y in step 2a.
+var spanishYVerbSuffixes = []string{
+	"ya", "ye", "yan", "yen", "yeron", "yendo", "yo", "yó", "yas", "yes", "yais", "yamos",
+}
+
+// Suffixes for the Spanish verb suffixes in step 2b.
+var spanishVerbSuffixes = []string{
+	"en", "es", "éis", "emos",
+	"arían", "arías", "arán", "arás", "aríais", "aría", "aréis", "aríamos", "aremos", "ará", "aré",
+	"erían", "erías", "erán", "erás", "eríais", "ería", "eréis", "eríamos", "eremos", "erá", "eré",
+	"irían", "irías", "irán", "irás", "iríais", "iría", "iréis", "iríamos", "iremos", "irá", "iré",
+	"aba", "ada", "ida", "ía", "ara", "iera", "ad", "ed", "id", "ase", "iese", "aste", "iste",
+	"an", "aban", "ían", "aran", "ieran", "asen", "iesen", "aron", "ieron", "ado", "ido",
+	"ando", "iendo", "ió", "ar", "er", "ir", "as", "abas", "adas", "idas", "ías", "aras",
+	"ieras", "ases", "ieses", "ís", "áis", "abais", "íais", "arais", "ierais", "aseis",
+	"ieseis", "asteis", "isteis", "ados", "idos", "amos", "ábamos", "íamos", "imos",
+	"áramos", "iéramos", "iésemos", "ásemos",
+}
+
+// Replaces the acute accents with unaccented vowels after stemming.
+var spanishAccents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u")
+
+// Performs step 0 of the Spanish Snowball algorithm on the word buffer, which
+// removes attached pronouns after a gerund or infinitive in RV.
+func (p *Porter2Stemmer) step_0_Spanish() {
+	pronoun := p.longestMatchingSuffix(0, len(p.word),
+		"me", "se", "sela", "selo", "selas", "selos", "la", "le", "lo", "las", "les", "los", "nos",
+	)
+	if pronoun == "" {
+		return
+	}
+
+	// The pronoun must follow a gerund or infinitive in RV
+	end := len(p.word) - len([]rune(pronoun))
+	ending := p.longestMatchingSuffix(0, end,
+		"iéndo", "ándo", "ár", "ér", "ír",
+		"ando", "iendo", "ar", "er", "ir",
+		"yendo",
+	)
+	if ending == "" || !p.hasSuffix(p.pv, end, ending) {
+		return
+	}
+
+	// Perform the operation
+	switch ending {
+	case "iéndo", "ándo", "ár", "ér", "ír":
+		// Remove the pronoun and the accent
+		p.replaceSuffix(len([]rune(pronoun))+len([]rune(ending)), spanishAccents.Replace(ending))
+
+	case "ando", "iendo", "ar", "er", "ir":
+		p.deleteSuffix(pronoun)
+
+	case "yendo":
+		// Only if preceded by "u"
+		if p.hasSuffix(0, end, "uyendo") {
+			p.deleteSuffix(pronoun)
+		}
+	}
+}
+
+// Performs step 1 of the Spanish Snowball algorithm on the word buffer, which
+// removes the standard suffixes. Returns true if a suffix was removed.
+func (p *Porter2Stemmer) step_1_Spanish() (removed bool) {
+	longest := p.longestMatchingSuffix(0, len(p.word), spanishStandardSuffixes...)
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+		return false
+
+	case "anza", "anzas", "ico", "ica", "icos", "icas", "ismo", "ismos", "able", "ables", "ible", "ibles", "ista", "istas", "oso", "osa", "osos", "osas", "amiento", "amientos", "imiento", "imientos":
+		// Delete if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+
+	case "adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias":
+		// Delete if in R2, then delete a preceding "ic" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if p.inRegion("ic", p.p2) {
+			p.deleteSuffix("ic")
+		}
+
+	case "logía", "logías":
+		// Replace with "log" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "log")
+
+	case "ución", "uciones":
+		// Replace with "u" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "u")
+
+	case "encia", "encias":
+		// Replace with "ente" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.swapSuffix(longest, "ente")
+
+	case "amente":
+		// Delete if in R1, then delete a preceding "iv" (and an "at" before
+		// it), "os", "ic" or "ad" if in R2
+		if !p.inRegion(longest, p.p1) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "iv", "os", "ic", "ad"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+			if preceding == "iv" && p.inRegion("at", p.p2) {
+				p.deleteSuffix("at")
+			}
+		}
+
+	case "mente":
+		// Delete if in R2, then delete a preceding "ante", "able" or "ible" if
+		// in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "ante", "able", "ible"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+		}
+
+	case "idad", "idades":
+		// Delete if in R2, then delete a preceding "abil", "ic" or "iv" if in
+		// R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if preceding := p.longestMatchingSuffix(0, len(p.word), "abil", "ic", "iv"); preceding != "" && p.inRegion(preceding, p.p2) {
+			p.deleteSuffix(preceding)
+		}
+
+	case "iva", "ivo", "ivas", "ivos":
+		// Delete if in R2, then delete a preceding "at" if in R2
+		if !p.inRegion(longest, p.p2) {
+			return false
+		}
+		p.deleteSuffix(longest)
+		if p.inRegion("at", p.p2) {
+			p.deleteSuffix("at")
+		}
+	}
+
+	return true
+}
+
+// Performs step 2a of the Spanish Snowball algorithm on the word buffer, which
+// removes the verb suffixes beginning with "y" in RV. Returns true if a suffix
+// was removed.
+func (p *Porter2Stemmer) step_2a_Spanish() (removed bool) {
+	longest := p.longestMatchingSuffix(p.pv, len(p.word), spanishYVerbSuffixes...)
+
+	// Delete if preceded by "u"
+	if longest == "" || !p.precededBy(longest, "u") {
+		return false
+	}
+	p.deleteSuffix(longest)
+	return true
+}
+
+// Performs step 2b of the Spanish Snowball algorithm on the word buffer, which
+// removes the other verb suffixes in RV.
+func (p *Porter2Stemmer) step_2b_Spanish() {
+	longest := p.longestMatchingSuffix(p.pv, len(p.word), spanishVerbSuffixes...)
+
+	// Perform the operation
+	switch longest {
+	case "":
+		// No match
+
+	case "en", "es", "éis", "emos":
+		// Delete, and if preceded by "gu" delete the "u"
+		p.deleteSuffix(longest)
+		if p.endsWith("gu") {
+			p.removeSuffix(1)
+		}
+
+	default:
+		p.deleteSuffix(longest)
+	}
+}
+
+// Performs step 3 of the Spanish Snowball algorithm on the word buffer, which
+// removes the residual suffixes in RV.
+func (p *Porter2Stemmer) step_3_Spanish() {
+	longest := p.longestMatchingSuffix(0, len(p.word), "os", "a", "o", "á", "í", "ó", "e", "é")
+	if longest == "" || !p.inRegion(longest, p.pv) {
+		return
+	}
+
+	// Delete, and for "e" or "é" if preceded by "gu" with the "u" in RV delete
+	// the "u"
+	p.deleteSuffix(longest)
+	if (longest == "e" || longest == "é") && p.inRegion("u", p.pv) && p.endsWith("gu") {
+		p.removeSuffix(1)
+	}
+}
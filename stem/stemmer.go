@@ -37,7 +37,9 @@ func (p *NoOpStemmer) Stem(word string) (stem string) {
 // Ensure [Porter2Stemmer] meets the [stemming.Stemmer] interface requirements.
 var _ Stemmer = &Porter2Stemmer{}
 
-// Implements the Porter2 stemming algorithm.
+// Implements the Porter2 stemming algorithm for English and the Snowball
+// stemming algorithms (the Porter2 algorithms for other languages) for
+// Spanish, French, German, Italian, Portuguese and Dutch.
 type Porter2Stemmer struct {
 	lang language.Language
 
@@ -49,6 +51,8 @@ type Porter2Stemmer struct {
 	p1 int
 	// Pointer to the start of the word region R2
 	p2 int
+	// Pointer to the start of the word region RV (Romance languages)
+	pv int
 }
 
 // Returns a new [Porter2Stemmer] which supports the [language.Language] given, or an
@@ -63,6 +67,24 @@ func NewPorter2Stemmer(lang language.Language) (stemmer *Porter2Stemmer, err err
 		// Use English stemmer
 		stemmer.impl = stemmer.StemEnglish
 
+	case language.Spanish:
+		stemmer.impl = stemmer.StemSpanish
+
+	case language.French:
+		stemmer.impl = stemmer.StemFrench
+
+	case language.German:
+		stemmer.impl = stemmer.StemGerman
+
+	case language.Italian:
+		stemmer.impl = stemmer.StemItalian
+
+	case language.Portuguese:
+		stemmer.impl = stemmer.StemPortuguese
+
+	case language.Dutch:
+		stemmer.impl = stemmer.StemDutch
+
 	default: // unsupported language
 		return nil, errors.ErrLanguageNotSupported
 	}
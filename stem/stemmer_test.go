@@ -35,6 +35,42 @@ func TestStemmers(t *testing.T) {
 			InputPath:    "testdata/Porter2Stemmer/voc.txt",
 			ExpectedPath: "testdata/Porter2Stemmer/output.txt",
 		},
+		{
+			TestName:     "Porter2Stemmer [LanguageSpanish]",
+			Stemmer:      mustNewPorter2Stemmer(language.Spanish),
+			InputPath:    "testdata/Porter2StemmerSpanish/voc.txt",
+			ExpectedPath: "testdata/Porter2StemmerSpanish/output.txt",
+		},
+		{
+			TestName:     "Porter2Stemmer [LanguageFrench]",
+			Stemmer:      mustNewPorter2Stemmer(language.French),
+			InputPath:    "testdata/Porter2StemmerFrench/voc.txt",
+			ExpectedPath: "testdata/Porter2StemmerFrench/output.txt",
+		},
+		{
+			TestName:     "Porter2Stemmer [LanguageGerman]",
+			Stemmer:      mustNewPorter2Stemmer(language.German),
+			InputPath:    "testdata/Porter2StemmerGerman/voc.txt",
+			ExpectedPath: "testdata/Porter2StemmerGerman/output.txt",
+		},
+		{
+			TestName:     "Porter2Stemmer [LanguageItalian]",
+			Stemmer:      mustNewPorter2Stemmer(language.Italian),
+			InputPath:    "testdata/Porter2StemmerItalian/voc.txt",
+			ExpectedPath: "testdata/Porter2StemmerItalian/output.txt",
+		},
+		{
+			TestName:     "Porter2Stemmer [LanguagePortuguese]",
+			Stemmer:      mustNewPorter2Stemmer(language.Portuguese),
+			InputPath:    "testdata/Porter2StemmerPortuguese/voc.txt",
+			ExpectedPath: "testdata/Porter2StemmerPortuguese/output.txt",
+		},
+		{
+			TestName:     "Porter2Stemmer [LanguageDutch]",
+			Stemmer:      mustNewPorter2Stemmer(language.Dutch),
+			InputPath:    "testdata/Porter2StemmerDutch/voc.txt",
+			ExpectedPath: "testdata/Porter2StemmerDutch/output.txt",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -0,0 +1,199 @@
+package stopwords
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// ############################################################################
+// StopwordBuilder
+// ############################################################################
+
+// The default number of stop words in a [StopwordSet] built by a
+// [StopwordBuilder].
+const DefaultTopN = 100
+
+/*
+StopwordBuilder can be used to derive a [StopwordSet] from a corpus of chunks,
+so the stop words can be tailored to a domain: the words which occur in the most
+documents (chunks) carry the least information about any one of them; create
+with [NewStopwordBuilder].
+
+Usage example:
+
+	// Create a builder which keeps the 50 most common words
+	builder, err := stopwords.NewStopwordBuilder(
+		stopwords.StopwordBuilderWithTopN(50),
+	)
+
+	// Add the corpus; this can be called as many times as needed
+	err = builder.Add(
+		"the patient was seen by the doctor",
+		"the doctor prescribed rest for the patient",
+		"a patient asked about the prescription",
+	)
+
+	// Build the stop words, which can be combined with the standard ones
+	set := builder.Build() // "the", "patient", "doctor", ...
+	set.Add(stopwords.English[:]...)
+*/
+type StopwordBuilder struct {
+	lang      language.Language
+	tokenizer tokenize.Tokenizer
+	topN      int
+	docCount  int
+	docFreqs  map[string]int // word -> number of documents
+	termFreqs map[string]int // word -> number of instances
+}
+
+// Returns a new [StopwordBuilder] instance.
+//
+// Defaults:
+//   - Lang: [language.English]
+//   - Tokenizer: [tokenize.RegexTokenizer] for the language
+//   - Top N: [DefaultTopN]
+func NewStopwordBuilder(opts ...StopwordBuilderOption) (builder *StopwordBuilder, err error) {
+	builder = &StopwordBuilder{}
+	for _, fn := range opts {
+		fn(builder)
+	}
+
+	// Set defaults
+
+	if builder.lang == language.Unknown {
+		builder.lang = language.English
+	}
+
+	if builder.tokenizer == nil {
+		builder.tokenizer = tokenize.NewRegexTokenizer(
+			tokenize.RegexTokenizerWithLanguage(builder.lang),
+		)
+	}
+
+	if builder.topN == 0 {
+		builder.topN = DefaultTopN
+	}
+
+	// Validate options
+	if builder.topN < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the number of stop words cannot be negative"))
+	}
+
+	builder.Reset()
+
+	return builder, nil
+}
+
+// Returns the [StopwordBuilder]s configured [language.Language].
+func (b *StopwordBuilder) Language() language.Language {
+	return b.lang
+}
+
+// Returns the [StopwordBuilder]s configured [tokenize.Tokenizer].
+func (b *StopwordBuilder) Tokenizer() tokenize.Tokenizer {
+	return b.tokenizer
+}
+
+// Returns the [StopwordBuilder]s configured number of stop words to build.
+func (b *StopwordBuilder) TopN() int {
+	return b.topN
+}
+
+// Returns the number of documents added to the [StopwordBuilder].
+func (b *StopwordBuilder) DocumentCount() int {
+	return b.docCount
+}
+
+// Returns the number of documents added to the [StopwordBuilder] which contain
+// the given word (ignoring case).
+func (b *StopwordBuilder) DocumentFrequency(word string) int {
+	return b.docFreqs[normalize(word)]
+}
+
+// Removes all documents which were added to the [StopwordBuilder].
+func (b *StopwordBuilder) Reset() {
+	b.docCount = 0
+	b.docFreqs = make(map[string]int)
+	b.termFreqs = make(map[string]int)
+}
+
+// Add tokenizes and counts the lowercase words of each of the chunks as a
+// separate document.
+func (b *StopwordBuilder) Add(chunks ...string) (err error) {
+	for _, chunk := range chunks {
+		var tokens []string
+		if tokens, err = b.tokenizer.Tokenize(chunk); err != nil {
+			return err
+		}
+
+		seen := make(map[string]struct{}, len(tokens))
+		for _, tok := range tokens {
+			word := strings.ToLower(tok)
+			b.termFreqs[word] += 1
+			if _, ok := seen[word]; !ok {
+				seen[word] = struct{}{}
+				b.docFreqs[word] += 1
+			}
+		}
+		b.docCount += 1
+	}
+	return nil
+}
+
+// Build returns a [StopwordSet] of the configured number of words which occur
+// in the most documents added so far. Ties in the document frequency are broken
+// by the number of instances of the words across the corpus, then by the
+// alphabetical order of the words, so the set is deterministic for the same
+// corpus.
+func (b *StopwordBuilder) Build() StopwordSet {
+	words := make([]string, 0, len(b.docFreqs))
+	for word := range b.docFreqs {
+		words = append(words, word)
+	}
+
+	slices.SortFunc(words, func(x, y string) int {
+		return cmp.Or(
+			cmp.Compare(b.docFreqs[y], b.docFreqs[x]),
+			cmp.Compare(b.termFreqs[y], b.termFreqs[x]),
+			cmp.Compare(x, y),
+		)
+	})
+
+	return NewStopwordSet(words[:min(b.topN, len(words))]...)
+}
+
+// ############################################################################
+// StopwordBuilderOption
+// ############################################################################
+
+// StopwordBuilderOption functions modify a [StopwordBuilder].
+type StopwordBuilderOption func(b *StopwordBuilder)
+
+// StopwordBuilderWithLanguage sets the [language.Language] to use with the
+// [StopwordBuilder].
+func StopwordBuilderWithLanguage(lang language.Language) StopwordBuilderOption {
+	return func(b *StopwordBuilder) {
+		b.lang = lang
+	}
+}
+
+// StopwordBuilderWithTokenizer sets the [tokenize.Tokenizer] used to split each
+// document into words.
+func StopwordBuilderWithTokenizer(tokenizer tokenize.Tokenizer) StopwordBuilderOption {
+	return func(b *StopwordBuilder) {
+		b.tokenizer = tokenizer
+	}
+}
+
+// StopwordBuilderWithTopN sets the number of stop words in the [StopwordSet]
+// built, keeping the words which occur in the most documents.
+func StopwordBuilderWithTopN(n int) StopwordBuilderOption {
+	return func(b *StopwordBuilder) {
+		b.topN = n
+	}
+}
@@ -0,0 +1,131 @@
+package stopwords
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ############################################################################
+// StopwordSet
+// ############################################################################
+
+/*
+StopwordSet is a set of stop words with constant time lookups. Words are stored
+lowercase and without surrounding whitespace, and lookups are normalized the
+same way, so "The", " the " and "THE" are all in a set containing "the".
+
+Usage example:
+
+	// Start from the stop words for a language and tailor them to a domain
+	set, err := stopwords.ForLanguage(language.English)
+	set.Add("patient", "doctor")
+	set.Remove("not", "no")
+
+	// Or load them from a file with one word per line
+	set, err = stopwords.Load("stop.txt")
+
+	set.Contains("Doctor") // true
+*/
+type StopwordSet map[string]struct{}
+
+// Returns a new [StopwordSet] containing the words given.
+func NewStopwordSet(words ...string) StopwordSet {
+	set := make(StopwordSet, len(words))
+	set.Add(words...)
+	return set
+}
+
+// Returns a [StopwordSet] read from r, which has one stop word on each line.
+// Blank lines are ignored, as is any text after a '|' or '#' so the
+// [snowballstem.org] stop word files (which use '|' for comments) can be read
+// as is.
+//
+// [snowballstem.org]: https://snowballstem.org
+func Read(r io.Reader) (set StopwordSet, err error) {
+	set = make(StopwordSet)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexAny(line, "|#"); i >= 0 {
+			line = line[:i]
+		}
+		for _, word := range strings.Fields(line) {
+			set.Add(word)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Returns a [StopwordSet] loaded from the file at path (see [Read] for the
+// format of the file).
+func Load(path string) (set StopwordSet, err error) {
+	var file *os.File
+	if file, err = os.Open(path); err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// Adds the words to the [StopwordSet].
+func (s StopwordSet) Add(words ...string) {
+	for _, word := range words {
+		if word = normalize(word); word != "" {
+			s[word] = struct{}{}
+		}
+	}
+}
+
+// Removes the words from the [StopwordSet].
+func (s StopwordSet) Remove(words ...string) {
+	for _, word := range words {
+		delete(s, normalize(word))
+	}
+}
+
+// Returns true if the word is in the [StopwordSet]. A nil set contains no
+// words.
+func (s StopwordSet) Contains(word string) bool {
+	_, ok := s[normalize(word)]
+	return ok
+}
+
+// Returns the number of words in the [StopwordSet].
+func (s StopwordSet) Len() int {
+	return len(s)
+}
+
+// Returns the words in the [StopwordSet] in alphabetical order.
+func (s StopwordSet) Words() (words []string) {
+	words = make([]string, 0, len(s))
+	for word := range s {
+		words = append(words, word)
+	}
+	slices.Sort(words)
+	return words
+}
+
+// Writes the words in the [StopwordSet] to w in alphabetical order with one
+// word on each line, which can be read back with [Read].
+func (s StopwordSet) WriteTo(w io.Writer) (n int64, err error) {
+	for _, word := range s.Words() {
+		var written int
+		if written, err = fmt.Fprintln(w, word); err != nil {
+			return n, err
+		}
+		n += int64(written)
+	}
+	return n, nil
+}
+
+// Returns the word lowercase and without surrounding whitespace.
+func normalize(word string) string {
+	return strings.TrimSpace(strings.ToLower(word))
+}
@@ -1,9 +1,7 @@
 package stopwords
 
 import (
-	"slices"
-	"strings"
-
+	"go.rtnl.ai/nlp/errors"
 	"go.rtnl.ai/nlp/language"
 )
 
@@ -11,14 +9,33 @@ import (
 // Stop Words Functions
 // ############################################################################
 
+// The stop word sets for each supported language, built once from the lists.
+var languageSets = map[language.Language]StopwordSet{
+	language.English:    NewStopwordSet(English[:]...),
+	language.Spanish:    NewStopwordSet(Spanish[:]...),
+	language.French:     NewStopwordSet(French[:]...),
+	language.German:     NewStopwordSet(German[:]...),
+	language.Italian:    NewStopwordSet(Italian[:]...),
+	language.Portuguese: NewStopwordSet(Portuguese[:]...),
+	language.Dutch:      NewStopwordSet(Dutch[:]...),
+}
+
 // Returns true if word is a stop word for the given language. If the language
 // is not implemented this function will return false.
 func IsStopWord(word string, lang language.Language) bool {
-	switch lang {
-	case language.English:
-		return slices.Contains(English[:], strings.TrimSpace(strings.ToLower(word)))
+	return languageSets[lang].Contains(word)
+}
+
+// Returns a new [StopwordSet] with a copy of the stop words for the language,
+// which can be extended or trimmed without changing the stop words used by
+// [IsStopWord]. If the language is not implemented this function will return
+// [errors.ErrLanguageNotSupported].
+func ForLanguage(lang language.Language) (StopwordSet, error) {
+	set, ok := languageSets[lang]
+	if !ok {
+		return nil, errors.ErrLanguageNotSupported
 	}
-	return false
+	return NewStopwordSet(set.Words()...), nil
 }
 
 // ############################################################################
@@ -1,11 +1,16 @@
 package stopwords_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
 	"go.rtnl.ai/nlp/language"
 	"go.rtnl.ai/nlp/stopwords"
+	"go.rtnl.ai/nlp/tokenize"
 )
 
 func TestIsStopWordEnglish(t *testing.T) {
@@ -24,3 +29,144 @@ func TestIsStopWordEnglish(t *testing.T) {
 		require.False(t, stopwords.IsStopWord(word, language.English), "this is not a stop word")
 	}
 }
+
+func TestIsStopWordLanguages(t *testing.T) {
+	testcases := []struct {
+		Language language.Language
+		Words    []string
+		Stops    []string
+		NonStops []string
+	}{
+		{language.Spanish, stopwords.Spanish[:], []string{"Que", "MÁS", " estábamos "}, []string{"perro", "casa", "the"}},
+		{language.French, stopwords.French[:], []string{"Nous", "ÉTAIENT", "à"}, []string{"chien", "maison", "the"}},
+		{language.German, stopwords.German[:], []string{"Über", "daß", "dass"}, []string{"Hund", "Haus", "the"}},
+		{language.Italian, stopwords.Italian[:], []string{"Perché", "della", "è"}, []string{"cane", "casa", "the"}},
+		{language.Portuguese, stopwords.Portuguese[:], []string{"Não", "você", "estávamos"}, []string{"cão", "casa", "the"}},
+		{language.Dutch, stopwords.Dutch[:], []string{"Het", "omdat", "geweest"}, []string{"hond", "huis", "the"}},
+	}
+
+	for _, tc := range testcases {
+		for _, word := range tc.Words {
+			require.True(t, stopwords.IsStopWord(word, tc.Language), "stop word not recognized: %q", word)
+		}
+		for _, word := range tc.Stops {
+			require.True(t, stopwords.IsStopWord(word, tc.Language), "stop word not recognized: %q", word)
+		}
+		for _, word := range tc.NonStops {
+			require.False(t, stopwords.IsStopWord(word, tc.Language), "this is not a stop word: %q", word)
+		}
+	}
+
+	require.False(t, stopwords.IsStopWord("the", language.Unknown))
+}
+
+func TestForLanguage(t *testing.T) {
+	set, err := stopwords.ForLanguage(language.English)
+	require.NoError(t, err)
+	require.Equal(t, len(stopwords.English), set.Len())
+
+	// The set is a copy so changing it does not change IsStopWord
+	set.Remove("not")
+	set.Add("Patient")
+	require.False(t, set.Contains("not"))
+	require.True(t, set.Contains("patient"))
+	require.True(t, stopwords.IsStopWord("not", language.English))
+	require.False(t, stopwords.IsStopWord("patient", language.English))
+
+	t.Run("ErrorLanguage", func(t *testing.T) {
+		set, err := stopwords.ForLanguage(language.Unknown)
+		require.ErrorIs(t, err, errors.ErrLanguageNotSupported)
+		require.Nil(t, set)
+	})
+}
+
+func TestStopwordSet(t *testing.T) {
+	set := stopwords.NewStopwordSet("The", " a ", "", "an", "the")
+	require.Equal(t, 3, set.Len())
+	require.Equal(t, []string{"a", "an", "the"}, set.Words())
+	require.True(t, set.Contains("THE"))
+	require.False(t, set.Contains("them"))
+
+	// A nil set contains no words
+	var empty stopwords.StopwordSet
+	require.False(t, empty.Contains("the"))
+	require.Zero(t, empty.Len())
+
+	// The set round trips through its text format
+	var buf bytes.Buffer
+	_, err := set.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "a\nan\nthe\n", buf.String())
+
+	loaded, err := stopwords.Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, set, loaded)
+}
+
+func TestLoad(t *testing.T) {
+	// Snowball stop word files have comments after a '|'
+	path := filepath.Join(t.TempDir(), "stop.txt")
+	data := "| A small list\n\nder   | masculine article\nDie\n# a comment\ndas  # neuter article\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	set, err := stopwords.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"das", "der", "die"}, set.Words())
+
+	_, err = stopwords.Load(filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestStopwordBuilder(t *testing.T) {
+	corpus := []string{
+		"The patient was seen by the doctor.",
+		"The doctor prescribed rest for the patient.",
+		"A patient asked the nurse about the prescription.",
+		"The nurse called the doctor.",
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		builder, err := stopwords.NewStopwordBuilder()
+		require.NoError(t, err)
+		require.Equal(t, language.English, builder.Language())
+		require.IsType(t, &tokenize.RegexTokenizer{}, builder.Tokenizer())
+		require.Equal(t, stopwords.DefaultTopN, builder.TopN())
+
+		require.NoError(t, builder.Add(corpus...))
+		require.Equal(t, 4, builder.DocumentCount())
+		require.Equal(t, 4, builder.DocumentFrequency("THE"))
+		require.Equal(t, 3, builder.DocumentFrequency("doctor"))
+
+		// There are fewer words than the default top N
+		require.Equal(t, 15, builder.Build().Len())
+
+		builder.Reset()
+		require.Zero(t, builder.DocumentCount())
+		require.Zero(t, builder.Build().Len())
+	})
+
+	t.Run("TopN", func(t *testing.T) {
+		tokenizer := tokenize.NewWhitespaceTokenizer()
+		builder, err := stopwords.NewStopwordBuilder(
+			stopwords.StopwordBuilderWithLanguage(language.English),
+			stopwords.StopwordBuilderWithTokenizer(tokenizer),
+			stopwords.StopwordBuilderWithTopN(3),
+		)
+		require.NoError(t, err)
+		require.Equal(t, tokenizer, builder.Tokenizer())
+		require.Equal(t, 3, builder.TopN())
+		require.NoError(t, builder.Add(corpus...))
+
+		// "the" is in 4 documents; the whitespace tokenizer keeps punctuation
+		// so "doctor." (2 documents) and "doctor" (1 document) are different
+		// words, and "doctor." ties with "nurse" and "patient", which has the
+		// same number of instances but sorts last
+		require.Equal(t, []string{"doctor.", "nurse", "the"}, builder.Build().Words())
+	})
+
+	t.Run("ErrorTopN", func(t *testing.T) {
+		builder, err := stopwords.NewStopwordBuilder(stopwords.StopwordBuilderWithTopN(-1))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, builder)
+	})
+}
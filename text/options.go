@@ -1,9 +1,13 @@
 package text
 
 import (
+	"go.rtnl.ai/nlp/langdetect"
 	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/normalize"
 	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/stopwords"
 	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vectorize"
 )
 
 // An [Option] configures a [Text] in [New].
@@ -16,6 +20,14 @@ func WithVocabulary(vocab []string) Option {
 	}
 }
 
+// Returns a function that sets the vocabulary on a [Text] to the vocabulary
+// built from the documents added to the [vectorize.VocabularyBuilder].
+func WithVocabularyBuilder(builder *vectorize.VocabularyBuilder) Option {
+	return func(text *Text) {
+		text.vocab = builder.Build()
+	}
+}
+
 // Returns a function that sets the [language.Language] on a [Text].
 func WithLanguage(lang language.Language) Option {
 	return func(text *Text) {
@@ -23,6 +35,36 @@ func WithLanguage(lang language.Language) Option {
 	}
 }
 
+// Returns a function that detects the [language.Language] of a [Text] with a
+// [langdetect.Detector] (configured with the options given, if any) and uses
+// it to select the default stemmer, tokenizer, sentence segmenter, syllable
+// rules and stop words. The detected language overrides the language set with
+// [WithLanguage], which is used instead if the text has no letters to detect
+// a language from.
+func WithAutoLanguage(opts ...langdetect.DetectorOption) Option {
+	return func(text *Text) {
+		text.detector = langdetect.NewDetector(opts...)
+	}
+}
+
+// Returns a function that sets the [stopwords.StopwordSet] on a [Text], e.g. to
+// extend the stop words of its language or to use ones derived from a corpus.
+func WithStopWords(set stopwords.StopwordSet) Option {
+	return func(text *Text) {
+		text.stopWords = set
+	}
+}
+
+// Returns a function that sets the [normalize.Normalizer] on a [Text], such as
+// a [normalize.Pipeline], which is applied to the text before it is tokenized
+// by [Text.Tokens], [Text.Words] and [Text.Sentences]. The tokens and words
+// keep the spans of their source text before normalization.
+func WithNormalizer(normalizer normalize.Normalizer) Option {
+	return func(text *Text) {
+		text.normalizer = normalizer
+	}
+}
+
 // Returns a function that sets the [stem.Stemmer] on a [Text].
 func WithStemmer(stemmer stem.Stemmer) Option {
 	return func(text *Text) {
@@ -36,3 +78,27 @@ func WithTokenizer(tokenizer tokenize.Tokenizer) Option {
 		text.tokenizer = tokenizer
 	}
 }
+
+// Returns a function that sets the sentence tokenizer on a [Text], which is a
+// [tokenize.Tokenizer] that returns sentences such as a
+// [tokenize.PunktSentenceSegmenter]. The sentences are used for the sentence
+// counts in the readability scores; if the tokenizer returns an error the
+// [Text] has no sentences. If the tokenizer is a [tokenize.SentenceSegmenter]
+// it is also returned by [Text.SentenceSegmenter].
+func WithSentenceTokenizer(tokenizer tokenize.Tokenizer) Option {
+	return func(text *Text) {
+		text.sentenceTokenizer = tokenizer
+		if segmenter, ok := tokenizer.(*tokenize.SentenceSegmenter); ok {
+			text.sentenceSegmenter = segmenter
+		}
+	}
+}
+
+// Returns a function that sets the familiar word list used for the
+// [Text.SpacheScore], which should be the published revised Spache word list;
+// the list is not bundled.
+func WithSpacheWords(words []string) Option {
+	return func(text *Text) {
+		text.spacheList = words
+	}
+}
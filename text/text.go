@@ -1,12 +1,17 @@
 package text
 
 import (
+	"unicode"
 	"unicode/utf8"
 
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/langdetect"
 	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/normalize"
 	"go.rtnl.ai/nlp/readability"
 	"go.rtnl.ai/nlp/similarity"
 	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/stopwords"
 	"go.rtnl.ai/nlp/token"
 	"go.rtnl.ai/nlp/tokenize"
 	"go.rtnl.ai/nlp/tokenlist"
@@ -38,6 +43,10 @@ Usage example:
 		panic("this should never occur")
 	}
 
+	// Each token (and its stem) has the location of its source text in the Text
+	// as byte and rune offsets ("aardvarks" is myText.Text()[6:15])
+	span, ok := myTokens[1].Span() // token.Span{Start: 6, End: 15, ...}, true
+
 	// You can also get a type count, which returns the count of each unique
 	// word stem (ignoring errors) ("aardvark" has a 2 count for this example)
 	myCount, err := myText.TypeCount() // map[string]int
@@ -81,6 +90,8 @@ Usage example:
 	// sentence count is zero)
 	ease := myText.FleschKincaidReadingEase() // -5.727
 	grade := myText.FleschKincaidGradeLevel() // 15.797
+	grade = myText.GunningFogIndex() // 19.943
+	grade = myText.SMOGGrade() // 13.024
 
 	// Get the counts of various things
 	count := myText.WordsCount() // 7
@@ -91,14 +102,23 @@ type Text struct {
 	// The string representation of the text
 	text string
 
+	// The text after normalization, which is tokenized, and the map from its
+	// offsets back to the text
+	normalized string
+	offsets    *normalize.OffsetMap
+
 	// ==============================
 	// Options
 	// ==============================
 
-	vocab     []string // used for the [vectorize.CountVectorizer]
-	lang      language.Language
-	stemmer   stem.Stemmer
-	tokenizer tokenize.Tokenizer
+	vocab      []string // used for the [vectorize.CountVectorizer]
+	lang       language.Language
+	detector   *langdetect.Detector // used to detect the language if set
+	guesses    []langdetect.Guess
+	stopWords  stopwords.StopwordSet
+	normalizer normalize.Normalizer
+	stemmer    stem.Stemmer
+	tokenizer  tokenize.Tokenizer
 
 	// ==============================
 	// Standard Tools
@@ -109,7 +129,11 @@ type Text struct {
 	cosineSimilarizer    *similarity.CosineSimilarizer
 	whitespaceTokenizer  *tokenize.WhitespaceTokenizer
 	sentenceSegmenter    *tokenize.SentenceSegmenter
+	sentenceTokenizer    tokenize.Tokenizer // the sentenceSegmenter unless set with WithSentenceTokenizer
 	sspSyllableTokenizer *tokenize.SSPSyllableTokenizer
+	daleChallWords       *readability.FamiliarWords // lazily initialized
+	spacheWords          *readability.FamiliarWords // lazily initialized
+	spacheList           []string                   // set with WithSpacheWords
 
 	// ==============================
 	// Caching (lazy initialization)
@@ -128,9 +152,12 @@ type Text struct {
 //
 // Defaults:
 //   - Vocabulary (use [WithVocabulary]): nil (errors will be returned from certain functions if a vocabulary is not added)
-//   - Language (use [WithLanguage]): [language.English]
+//   - Language (use [WithLanguage] or [WithAutoLanguage]): [language.English]
+//   - Stop words (use [WithStopWords]): the [stopwords.StopwordSet] for the language
+//   - Normalizer (use [WithNormalizer]): nil (the text is tokenized as is)
 //   - Stemmer (use [WithStemmer]): [stem.Porter2Stemmer]
 //   - Tokenizer (use [WithTokenizer]): [tokenize.RegexTokenizer]
+//   - Sentence tokenizer (use [WithSentenceTokenizer]): [tokenize.SentenceSegmenter]
 func New(t string, options ...Option) (text *Text, err error) {
 	// Initialize text
 	text = &Text{
@@ -144,11 +171,30 @@ func New(t string, options ...Option) (text *Text, err error) {
 		opt(text)
 	}
 
+	// Detected language
+	if text.detector != nil {
+		text.guesses = text.detector.Detect(text.text)
+		if len(text.guesses) > 0 {
+			text.lang = text.guesses[0].Language
+		}
+	}
+
 	// Default languge
 	if text.lang == language.Unknown {
 		text.lang = language.English
 	}
 
+	// Normalize the text
+	text.normalized = text.text
+	if text.normalizer != nil {
+		text.normalized, text.offsets = text.normalizer.Normalize(text.text)
+	}
+
+	// Default stop words (an unsupported language has none)
+	if text.stopWords == nil {
+		text.stopWords, _ = stopwords.ForLanguage(text.lang)
+	}
+
 	// Default stemmer
 	if text.stemmer == nil {
 		if text.stemmer, err = stem.NewPorter2Stemmer(text.lang); err != nil {
@@ -210,6 +256,9 @@ func New(t string, options ...Option) (text *Text, err error) {
 			tokenize.SentenceSegmenterWithLanguage(text.lang),
 		)
 	}
+	if text.sentenceTokenizer == nil {
+		text.sentenceTokenizer = text.sentenceSegmenter
+	}
 
 	// Initialize the [tokenize.SSPSyllableTokenizer]
 	if text.sspSyllableTokenizer == nil {
@@ -226,12 +275,22 @@ func New(t string, options ...Option) (text *Text, err error) {
 // ############################################################################
 
 // Returns a [tokenlist.TokenList] for the [Text]s tokens using the configured
-// [tokenize.Tokenizer]. This function cache the result of the operation for
-// subsequent calls.
+// [tokenize.Tokenizer] on the normalized text. If the tokenizer is a
+// [tokenize.SpanTokenizer] each token has the [token.Span] of its source text in
+// the [Text] (before normalization). This function cache the result of the
+// operation for subsequent calls.
 func (t *Text) Tokens() (tokens tokenlist.TokenList, err error) {
 	if t.tokens == nil {
+		if spanTokenizer, ok := t.tokenizer.(tokenize.SpanTokenizer); ok {
+			if tokens, err = spanTokenizer.TokenizeSpans(t.normalized); err != nil {
+				return nil, err
+			}
+			t.tokens = t.originalSpans(tokens)
+			return t.tokens, nil
+		}
+
 		var toks []string
-		if toks, err = t.tokenizer.Tokenize(t.text); err != nil {
+		if toks, err = t.tokenizer.Tokenize(t.normalized); err != nil {
 			return nil, err
 		}
 		t.tokens = tokenlist.New(toks)
@@ -240,8 +299,9 @@ func (t *Text) Tokens() (tokens tokenlist.TokenList, err error) {
 }
 
 // Returns a [tokenlist.TokenList] for the [Text]s stems using the configured
-// [stem.Stemmer]. This function cache the result of the operation for
-// subsequent calls.
+// [stem.Stemmer]. Each stem keeps the [token.Span] of the token it was stemmed
+// from, if any. This function cache the result of the operation for subsequent
+// calls.
 func (t *Text) Stems() (stems tokenlist.TokenList, err error) {
 	if t.stems == nil {
 		// Initialize the stems with the tokens
@@ -253,29 +313,32 @@ func (t *Text) Stems() (stems tokenlist.TokenList, err error) {
 
 		// Perform stemming by replacing each token with it's stem
 		for i, tok := range t.stems {
-			t.stems[i] = token.New(t.stemmer.Stem(tok.String()))
+			if span, ok := tok.Span(); ok {
+				t.stems[i] = token.NewWithSpan(t.stemmer.Stem(tok.String()), span)
+			} else {
+				t.stems[i] = token.New(t.stemmer.Stem(tok.String()))
+			}
 		}
 	}
 	return t.stems, nil
 }
 
-// Returns the words in the [Text] as a [tokenlist.TokenList]. Cached for faster
-// subsequent calls.
+// Returns the words in the normalized [Text] as a [tokenlist.TokenList], each
+// with the [token.Span] of the word in the [Text] (before normalization).
+// Cached for faster subsequent calls.
 func (t *Text) Words() tokenlist.TokenList {
 	if t.words == nil {
-		words, _ := t.whitespaceTokenizer.Tokenize(t.text) // error is ALWAYS nil
-		for _, word := range words {
-			t.words = append(t.words, token.New(word))
-		}
+		words, _ := t.whitespaceTokenizer.TokenizeSpans(t.normalized) // error is ALWAYS nil
+		t.words = t.originalSpans(words)
 	}
 	return t.words
 }
 
-// Returns the sentences in the [Text] as a [tokenlist.TokenList]. Cached for
-// faster subsequent calls.
+// Returns the sentences in the normalized [Text] as a [tokenlist.TokenList].
+// Cached for faster subsequent calls.
 func (t *Text) Sentences() tokenlist.TokenList {
 	if t.sentences == nil {
-		sentences, _ := t.sentenceSegmenter.Tokenize(t.text) // error is ALWAYS nil
+		sentences, _ := t.sentenceTokenizer.Tokenize(t.normalized) // an error means no sentences
 		for _, sentence := range sentences {
 			t.sentences = append(t.sentences, token.New(sentence))
 		}
@@ -296,6 +359,38 @@ func (t *Text) Syllables() [][]string {
 	return t.syllables
 }
 
+// Returns the tokens with their spans in the normalized text replaced by the
+// spans of their source text in the [Text], using the normalizer's offsets.
+func (t *Text) originalSpans(tokens tokenlist.TokenList) tokenlist.TokenList {
+	if t.offsets == nil {
+		return tokens
+	}
+
+	// Count runes incrementally since the tokens are usually in order
+	var bytes, runes int
+	for i, tok := range tokens {
+		span, ok := tok.Span()
+		if !ok {
+			continue
+		}
+
+		start, end := t.offsets.Original(span.Start, span.End)
+		if start < bytes {
+			bytes, runes = 0, 0
+		}
+		runes += utf8.RuneCountInString(t.text[bytes:start])
+		bytes = start
+
+		tokens[i] = token.NewWithSpan(tok.String(), token.Span{
+			Start:     start,
+			End:       end,
+			RuneStart: runes,
+			RuneEnd:   runes + utf8.RuneCountInString(t.text[start:end]),
+		})
+	}
+	return tokens
+}
+
 // ############################################################################
 // Count
 // ############################################################################
@@ -319,6 +414,42 @@ func (t *Text) SyllableCount() int {
 	return count
 }
 
+// Returns the count of the letters and digits in the words of the [Text],
+// ignoring punctuation and whitespace.
+func (t *Text) CharacterCount() int {
+	count := 0
+	for _, word := range t.Words() { // Words is cached
+		for _, r := range word.String() {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Returns the count of the words in the [Text] with three or more syllables.
+func (t *Text) PolysyllableCount() int {
+	count := 0
+	for _, wordSyllables := range t.Syllables() { // Syllables is cached
+		if len(wordSyllables) >= 3 {
+			count++
+		}
+	}
+	return count
+}
+
+// Returns the count of the words in the [Text] with exactly one syllable.
+func (t *Text) MonosyllableCount() int {
+	count := 0
+	for _, wordSyllables := range t.Syllables() { // Syllables is cached
+		if len(wordSyllables) == 1 {
+			count++
+		}
+	}
+	return count
+}
+
 // Returns a map of the types (unique word stems) and their counts for this
 // [Text]. This function cache the result of the operation for subsequent calls.
 func (t *Text) TypeCount() (types map[string]int, err error) {
@@ -335,6 +466,11 @@ func (t *Text) TypeCount() (types map[string]int, err error) {
 	return t.typecount, nil
 }
 
+// Returns true if the word is in the stop words configured on this [Text].
+func (t *Text) IsStopWord(word string) bool {
+	return t.stopWords.Contains(word)
+}
+
 // ############################################################################
 // Vectorize
 // ############################################################################
@@ -345,7 +481,7 @@ func (t *Text) TypeCount() (types map[string]int, err error) {
 // NOTE: You must set the vocabulary on the [Text] using [WithVocabulary] during
 // creation or an error will be returned.
 func (t *Text) VectorizeFrequency() (vector.Vector, error) {
-	return t.countVectorizer.VectorizeFrequency(t.text, t.vocab)
+	return t.countVectorizer.VectorizeFrequency(t.normalized, t.vocab)
 }
 
 // VectorizeOneHot returns a one-hot encoding vector for the [Text] and
@@ -354,7 +490,7 @@ func (t *Text) VectorizeFrequency() (vector.Vector, error) {
 // NOTE: You must set the vocabulary on the [Text] using [WithVocabulary] during
 // creation or an error will be returned.
 func (t *Text) VectorizeOneHot() (vector.Vector, error) {
-	return t.countVectorizer.VectorizeOneHot(t.text, t.vocab)
+	return t.countVectorizer.VectorizeOneHot(t.normalized, t.vocab)
 }
 
 // Retruns a value in the range [-1.0, 1.0] that indicates if two [Text] are
@@ -363,7 +499,62 @@ func (t *Text) VectorizeOneHot() (vector.Vector, error) {
 // on the [Text] using [WithVocabulary] during creation or an error will be
 // returned.
 func (t *Text) CosineSimilarity(other *Text) (similarity float64, err error) {
-	return t.cosineSimilarizer.Similarity(t.text, other.text)
+	return t.cosineSimilarizer.Similarity(t.normalized, other.normalized)
+}
+
+// Returns the Jaccard index of the sets of types (unique word stems) in two
+// [Text]s in the range [0.0, 1.0]. Unlike [Text.CosineSimilarity], this does
+// not require a vocabulary. See [similarity.Jaccard].
+func (t *Text) JaccardSimilarity(other *Text) (score float64, err error) {
+	var a, b similarity.Set[string]
+	if a, b, err = t.typeSets(other); err != nil {
+		return 0.0, err
+	}
+	return similarity.Jaccard(a, b)
+}
+
+// Returns the Sørensen–Dice coefficient of the sets of types (unique word
+// stems) in two [Text]s in the range [0.0, 1.0]. See [similarity.Dice].
+func (t *Text) DiceSimilarity(other *Text) (score float64, err error) {
+	var a, b similarity.Set[string]
+	if a, b, err = t.typeSets(other); err != nil {
+		return 0.0, err
+	}
+	return similarity.Dice(a, b)
+}
+
+// Returns the overlap coefficient of the sets of types (unique word stems) in
+// two [Text]s in the range [0.0, 1.0]. See [similarity.Overlap].
+func (t *Text) OverlapSimilarity(other *Text) (score float64, err error) {
+	var a, b similarity.Set[string]
+	if a, b, err = t.typeSets(other); err != nil {
+		return 0.0, err
+	}
+	return similarity.Overlap(a, b)
+}
+
+// Returns the Tversky index of the sets of types (unique word stems) in two
+// [Text]s in the range [0.0, 1.0], where alpha weights the types only in this
+// [Text] and beta weights the types only in the other. See
+// [similarity.Tversky].
+func (t *Text) TverskySimilarity(other *Text, alpha, beta float64) (score float64, err error) {
+	var a, b similarity.Set[string]
+	if a, b, err = t.typeSets(other); err != nil {
+		return 0.0, err
+	}
+	return similarity.Tversky(a, b, alpha, beta)
+}
+
+// Returns the sets of types for this [Text] and the other [Text].
+func (t *Text) typeSets(other *Text) (a, b similarity.Set[string], err error) {
+	var typesA, typesB map[string]int
+	if typesA, err = t.TypeCount(); err != nil {
+		return nil, nil, err
+	}
+	if typesB, err = other.TypeCount(); err != nil {
+		return nil, nil, err
+	}
+	return similarity.NewSetFromKeys(typesA), similarity.NewSetFromKeys(typesB), nil
 }
 
 // ###########################################################################
@@ -382,6 +573,72 @@ func (t *Text) FleschKincaidGradeLevel() (score float64) {
 	return readability.FleschKincaidGradeLevel(t.WordCount(), t.SentenceCount(), t.SyllableCount())
 }
 
+// Returns the Gunning Fog index, counting words with three or more syllables as
+// complex words. Returns the value 0.0 when the sentence and/or word count is
+// zero.
+func (t *Text) GunningFogIndex() (score float64) {
+	return readability.GunningFogIndex(t.WordCount(), t.SentenceCount(), t.PolysyllableCount())
+}
+
+// Returns the SMOG grade. Returns the value 0.0 when the sentence count is
+// zero.
+func (t *Text) SMOGGrade() (score float64) {
+	return readability.SMOGGrade(t.SentenceCount(), t.PolysyllableCount())
+}
+
+// Returns the Coleman-Liau index. Returns the value 0.0 when the word count is
+// zero.
+func (t *Text) ColemanLiauIndex() (score float64) {
+	return readability.ColemanLiauIndex(t.CharacterCount(), t.WordCount(), t.SentenceCount())
+}
+
+// Returns the Automated Readability Index. Returns the value 0.0 when the
+// sentence and/or word count is zero.
+func (t *Text) AutomatedReadabilityIndex() (score float64) {
+	return readability.AutomatedReadabilityIndex(t.CharacterCount(), t.WordCount(), t.SentenceCount())
+}
+
+// Returns the Linsear Write grade level over the whole [Text]. Returns the
+// value 0.0 when the sentence count is zero.
+func (t *Text) LinsearWriteFormula() (score float64) {
+	hard := t.PolysyllableCount()
+	return readability.LinsearWriteFormula(t.WordCount()-hard, hard, t.SentenceCount())
+}
+
+// Returns the FORCAST grade level. Returns the value 0.0 when the word count is
+// zero.
+func (t *Text) FORCASTGradeLevel() (score float64) {
+	return readability.FORCASTGradeLevel(t.WordCount(), t.MonosyllableCount())
+}
+
+// Returns the New Dale-Chall readability score, matching the words against
+// [readability.DaleChallWords] with the configured [stem.Stemmer] so that
+// inflections of familiar words are familiar. Returns the value 0.0 when the
+// sentence and/or word count is zero.
+func (t *Text) DaleChallScore() (score float64) {
+	if t.daleChallWords == nil {
+		t.daleChallWords = readability.NewFamiliarWords(readability.DaleChallWords, t.stemmer)
+	}
+	difficult, _ := t.daleChallWords.DifficultWordCount(t.Words().Strings())
+	return readability.DaleChallScore(t.WordCount(), t.SentenceCount(), difficult)
+}
+
+// Returns the revised Spache grade level, matching the words against the
+// revised Spache word list set with [WithSpacheWords] with the configured
+// [stem.Stemmer] so that inflections of familiar words are familiar. The list
+// is not bundled, so [errors.ErrMissingConfig] is returned if it was not set.
+// Returns the value 0.0 when the sentence and/or word count is zero.
+func (t *Text) SpacheScore() (score float64, err error) {
+	if t.spacheList == nil {
+		return 0.0, errors.Join(errors.ErrMissingConfig, errors.New("the Spache word list is required; use option 'WithSpacheWords()'"))
+	}
+	if t.spacheWords == nil {
+		t.spacheWords = readability.NewFamiliarWords(t.spacheList, t.stemmer)
+	}
+	_, unique := t.spacheWords.DifficultWordCount(t.Words().Strings())
+	return readability.SpacheScore(t.WordCount(), t.SentenceCount(), unique), nil
+}
+
 // ###########################################################################
 // Misc. Properties
 // ###########################################################################
@@ -430,6 +687,35 @@ func (t *Text) Language() language.Language {
 	return t.lang
 }
 
+// Returns the languages detected for this [Text] ranked from the most to the
+// least likely, or nil if it was not created with [WithAutoLanguage] or has no
+// letters to detect a language from.
+func (t *Text) LanguageGuesses() []langdetect.Guess {
+	return t.guesses
+}
+
+// Returns the [stopwords.StopwordSet] configured on this [Text].
+func (t *Text) StopWords() stopwords.StopwordSet {
+	return t.stopWords
+}
+
+// Returns the [normalize.Normalizer] configured on this [Text], or nil.
+func (t *Text) Normalizer() normalize.Normalizer {
+	return t.normalizer
+}
+
+// Returns the [Text] after normalization, which is the text that is tokenized.
+// This is the same as [Text.Text] if no [normalize.Normalizer] is configured.
+func (t *Text) Normalized() string {
+	return t.normalized
+}
+
+// Returns the map from byte offsets in the normalized [Text] back to byte
+// offsets in the [Text], or nil if no [normalize.Normalizer] is configured.
+func (t *Text) Offsets() *normalize.OffsetMap {
+	return t.offsets
+}
+
 // Returns the [stem.Stemmer] configured on this [Text].
 func (t *Text) Stemmer() stem.Stemmer {
 	return t.stemmer
@@ -465,6 +751,13 @@ func (t *Text) SentenceSegmenter() *tokenize.SentenceSegmenter {
 	return t.sentenceSegmenter
 }
 
+// Returns the sentence tokenizer used to split this [Text] into sentences, which
+// is the [Text.SentenceSegmenter] unless one was set with
+// [WithSentenceTokenizer].
+func (t *Text) SentenceTokenizer() tokenize.Tokenizer {
+	return t.sentenceTokenizer
+}
+
 // Returns the [tokenize.SSPSyllableTokenizer] configured on this [Text].
 func (t *Text) SSPSyllableTokenizer() *tokenize.SSPSyllableTokenizer {
 	return t.sspSyllableTokenizer
@@ -1,15 +1,21 @@
 package text_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
 	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/normalize"
 	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/stopwords"
 	"go.rtnl.ai/nlp/text"
+	"go.rtnl.ai/nlp/token"
 	"go.rtnl.ai/nlp/tokenize"
 	"go.rtnl.ai/nlp/tokenlist"
 	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
 )
 
 func TestNew(t *testing.T) {
@@ -28,6 +34,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, myText.CosineSimilarizer())
 		require.NotNil(t, myText.WhitespaceTokenizer())
 		require.NotNil(t, myText.SentenceSegmenter())
+		require.Equal(t, myText.SentenceSegmenter(), myText.SentenceTokenizer())
 		require.NotNil(t, myText.SSPSyllableTokenizer())
 	})
 
@@ -39,6 +46,17 @@ func TestNew(t *testing.T) {
 		require.Equal(t, vocab, myText.Vocab())
 	})
 
+	t.Run("VocabularyBuilderOption", func(t *testing.T) {
+		builder, err := vectorize.NewVocabularyBuilder()
+		require.NoError(t, err)
+		require.NoError(t, builder.Add("two ones", "one"))
+
+		myText, err := text.New("testing text.New()", text.WithVocabularyBuilder(builder))
+		require.NoError(t, err)
+		require.NotNil(t, myText)
+		require.Equal(t, []string{"one", "two"}, myText.Vocab())
+	})
+
 	t.Run("LanguageOption", func(t *testing.T) {
 		lang := language.English
 		myText, err := text.New("testing text.New()", text.WithLanguage(lang))
@@ -47,6 +65,81 @@ func TestNew(t *testing.T) {
 		require.Equal(t, lang, myText.Language())
 	})
 
+	t.Run("AutoLanguageOption", func(t *testing.T) {
+		myText, err := text.New("Les enfants mangeaient des pommes. Ils étaient très contents", text.WithAutoLanguage())
+		require.NoError(t, err)
+		require.Equal(t, language.French, myText.Language())
+		require.Equal(t, language.French, myText.LanguageGuesses()[0].Language)
+		require.Equal(t, language.French, myText.Stemmer().(*stem.Porter2Stemmer).Language())
+		require.Equal(t, language.French, myText.Tokenizer().(*tokenize.RegexTokenizer).Language())
+
+		stems, err := myText.Stems()
+		require.NoError(t, err)
+		require.Equal(t, []string{"le", "enfant", "mang", "de", "pomm", "il", "étaient", "tres", "content"}, stems.Strings())
+		require.Equal(t, 2, myText.SentenceCount())
+		require.Equal(t, 17, myText.SyllableCount())
+		require.True(t, myText.IsStopWord("étaient"))
+		require.False(t, myText.IsStopWord("the"))
+	})
+
+	t.Run("AutoLanguageOptionFallback", func(t *testing.T) {
+		myText, err := text.New("12 + 30 = 42", text.WithLanguage(language.German), text.WithAutoLanguage())
+		require.NoError(t, err)
+		require.Equal(t, language.German, myText.Language())
+		require.Nil(t, myText.LanguageGuesses())
+
+		myText, err = text.New("The quick brown fox jumps over the lazy dog.", text.WithLanguage(language.German), text.WithAutoLanguage())
+		require.NoError(t, err)
+		require.Equal(t, language.English, myText.Language())
+		require.True(t, myText.IsStopWord("over"))
+	})
+
+	t.Run("StopWordsOption", func(t *testing.T) {
+		set := stopwords.NewStopwordSet("apple", "zebra")
+		myText, err := text.New("testing text.New()", text.WithStopWords(set))
+		require.NoError(t, err)
+		require.Equal(t, set, myText.StopWords())
+		require.True(t, myText.IsStopWord("Apple"))
+		require.False(t, myText.IsStopWord("the"))
+	})
+
+	t.Run("NormalizerOption", func(t *testing.T) {
+		pipeline := normalize.NewPipeline(
+			normalize.NFKC(),
+			normalize.FoldQuotes(),
+			normalize.MaskURLs("URL"),
+			normalize.StripAccents(),
+			normalize.CollapseWhitespace(),
+		)
+		original := "  Le  ﬁlm “Café” est sur https://example.com/film"
+		myText, err := text.New(original, text.WithNormalizer(pipeline), text.WithTokenizer(tokenize.NewWhitespaceTokenizer()))
+		require.NoError(t, err)
+		require.Equal(t, pipeline, myText.Normalizer())
+		require.Equal(t, original, myText.Text())
+		require.Equal(t, `Le film "Cafe" est sur URL`, myText.Normalized())
+		require.NotNil(t, myText.Offsets())
+
+		// The tokens are normalized but their spans are in the original text
+		tokens, err := myText.Tokens()
+		require.NoError(t, err)
+		require.Equal(t, []string{"Le", "film", `"Cafe"`, "est", "sur", "URL"}, tokens.Strings())
+
+		spans, ok := tokens.Spans()
+		require.True(t, ok)
+		sources := make([]string, 0, len(spans))
+		for _, span := range spans {
+			sources = append(sources, original[span.Start:span.End])
+			require.Equal(t, original[span.Start:span.End], string(myText.Runes()[span.RuneStart:span.RuneEnd]))
+		}
+		require.Equal(t, []string{"Le", "ﬁlm", "“Café”", "est", "sur", "https://example.com/film"}, sources)
+
+		// The words and sentences are also normalized
+		require.Equal(t, tokens.Strings(), myText.Words().Strings())
+		wordSpans, _ := myText.Words().Spans()
+		require.Equal(t, spans, wordSpans)
+		require.Equal(t, []string{`Le film "Cafe" est sur URL`}, myText.Sentences().Strings())
+	})
+
 	t.Run("StemmerOption", func(t *testing.T) {
 		stemmer, err := stem.NewPorter2Stemmer(language.English)
 		require.NoError(t, err)
@@ -67,6 +160,27 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, myText)
 		require.Equal(t, tokenizer, myText.Tokenizer())
 	})
+
+	t.Run("SentenceTokenizerOption", func(t *testing.T) {
+		segmenter := tokenize.NewPunktSentenceSegmenter()
+		myText, err := text.New("I like fruit, e.g. apples and pears. It costs $3.50 at the store.", text.WithSentenceTokenizer(segmenter))
+		require.NoError(t, err)
+		require.Equal(t, segmenter, myText.SentenceTokenizer())
+		require.IsType(t, &tokenize.SentenceSegmenter{}, myText.SentenceSegmenter(), "the default segmenter is kept")
+		require.Equal(t, []string{"I like fruit, e.g. apples and pears.", "It costs $3.50 at the store."}, myText.Sentences().Strings())
+		require.Equal(t, 2, myText.SentenceCount())
+	})
+
+	t.Run("UnicodeTokenizerOption", func(t *testing.T) {
+		tokenizer := tokenize.NewUnicodeWordTokenizer()
+		myText, err := text.New("The café can’t open ’til 9.30 👍", text.WithTokenizer(tokenizer))
+		require.NoError(t, err)
+		require.Equal(t, tokenizer, myText.Tokenizer())
+
+		tokens, err := myText.Tokens()
+		require.NoError(t, err)
+		require.Equal(t, []string{"The", "café", "can’t", "open", "til", "9.30", "👍"}, tokens.Strings())
+	})
 }
 
 func TestTextTypeGetters(t *testing.T) {
@@ -94,12 +208,63 @@ func TestTokens(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, myText)
 
-	expected := tokenlist.New([]string{"apple", "bananna", "aardvark", "aardvarks", "zebra"})
+	expected := []string{"apple", "bananna", "aardvark", "aardvarks", "zebra"}
 	require.Nil(t, myText.TokensCache())
 	tokens, err := myText.Tokens()
 	require.NoError(t, err)
-	require.Equal(t, expected, tokens)
-	require.Equal(t, expected, myText.TokensCache())
+	require.Equal(t, expected, tokens.Strings())
+	require.Equal(t, tokens, myText.TokensCache())
+
+	// The default tokenizer returns the source offsets of each token
+	spans, ok := tokens.Spans()
+	require.True(t, ok)
+	for i, span := range spans {
+		require.Equal(t, expected[i], myText.Text()[span.Start:span.End])
+	}
+}
+
+func TestTokenSpans(t *testing.T) {
+	myText, err := text.New("Ça va? Très bien, café-au-lait!", text.WithTokenizer(tokenize.NewUnicodeWordTokenizer()))
+	require.NoError(t, err)
+
+	tokens, err := myText.Tokens()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Ça", "va", "Très", "bien", "café", "au", "lait"}, tokens.Strings())
+
+	spans, ok := tokens.Spans()
+	require.True(t, ok)
+	require.Equal(t, []token.Span{
+		{Start: 0, End: 3, RuneStart: 0, RuneEnd: 2},
+		{Start: 4, End: 6, RuneStart: 3, RuneEnd: 5},
+		{Start: 8, End: 13, RuneStart: 7, RuneEnd: 11},
+		{Start: 14, End: 18, RuneStart: 12, RuneEnd: 16},
+		{Start: 20, End: 25, RuneStart: 18, RuneEnd: 22},
+		{Start: 26, End: 28, RuneStart: 23, RuneEnd: 25},
+		{Start: 29, End: 33, RuneStart: 26, RuneEnd: 30},
+	}, spans)
+
+	// The stems keep the spans of their tokens
+	stems, err := myText.Stems()
+	require.NoError(t, err)
+	stemSpans, ok := stems.Spans()
+	require.True(t, ok)
+	require.Equal(t, spans, stemSpans)
+
+	// Tokenizers without spans still work but the tokens have no spans
+	myText, err = text.New("apple zebra", text.WithTokenizer(&stringsTokenizer{}))
+	require.NoError(t, err)
+	stems, err = myText.Stems()
+	require.NoError(t, err)
+	require.Equal(t, []string{"appl", "zebra"}, stems.Strings())
+	_, ok = stems.Spans()
+	require.False(t, ok)
+}
+
+// A [tokenize.Tokenizer] which is not a [tokenize.SpanTokenizer].
+type stringsTokenizer struct{}
+
+func (t *stringsTokenizer) Tokenize(chunk string) ([]string, error) {
+	return strings.Fields(chunk), nil
 }
 
 func TestStems(t *testing.T) {
@@ -107,12 +272,12 @@ func TestStems(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, myText)
 
-	expected := tokenlist.New([]string{"appl", "bananna", "aardvark", "aardvark", "zebra"})
+	expected := []string{"appl", "bananna", "aardvark", "aardvark", "zebra"}
 	require.Nil(t, myText.StemsCache())
 	stems, err := myText.Stems()
 	require.NoError(t, err)
-	require.Equal(t, expected, stems)
-	require.Equal(t, expected, myText.StemsCache())
+	require.Equal(t, expected, stems.Strings())
+	require.Equal(t, stems, myText.StemsCache())
 }
 
 func TestWordsAndCount(t *testing.T) {
@@ -120,11 +285,11 @@ func TestWordsAndCount(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, myText)
 
-	expected := tokenlist.New([]string{"apple", "bananna", "aardvark", "aardvarks", "zebra"})
+	expected := []string{"apple", "bananna", "aardvark", "aardvarks", "zebra"}
 	require.Nil(t, myText.WordsCache())
 	words := myText.Words()
-	require.Equal(t, expected, words)
-	require.Equal(t, expected, myText.WordsCache())
+	require.Equal(t, expected, words.Strings())
+	require.Equal(t, words, myText.WordsCache())
 	require.Equal(t, len(expected), myText.WordCount())
 }
 
@@ -183,6 +348,18 @@ func TestTypeCount(t *testing.T) {
 	require.Equal(t, expected, myText.TypeCountCache())
 }
 
+func TestTypeCountLemmatizer(t *testing.T) {
+	lemmatizer, err := stem.NewLemmatizer()
+	require.NoError(t, err)
+
+	myText, err := text.New("The children were running; a child runs generations ahead.", text.WithStemmer(lemmatizer))
+	require.NoError(t, err)
+
+	typeCount, err := myText.TypeCount()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"the": 1, "child": 2, "be": 1, "run": 2, "a": 1, "generation": 1, "ahead": 1}, typeCount)
+}
+
 func TestVectorizeFrequency(t *testing.T) {
 	vocab := []string{"one", "two"}
 	myText, err := text.New("one one three", text.WithVocabulary(vocab))
@@ -225,6 +402,31 @@ func TestCosineSimilarity(t *testing.T) {
 	require.InDelta(t, expected, similarity, 1e-12)
 }
 
+func TestSetSimilarities(t *testing.T) {
+	// No vocabulary is required for the set similarities
+	myText, err := text.New("the cats sat on the mat")
+	require.NoError(t, err)
+	otherText, err := text.New("a cat sat on a log")
+	require.NoError(t, err)
+
+	// {the, cat, sat, on, mat} and {a, cat, sat, on, log} share 3 types
+	jaccard, err := myText.JaccardSimilarity(otherText)
+	require.NoError(t, err)
+	require.InDelta(t, 3.0/7.0, jaccard, 1e-12)
+
+	dice, err := myText.DiceSimilarity(otherText)
+	require.NoError(t, err)
+	require.InDelta(t, 6.0/10.0, dice, 1e-12)
+
+	overlap, err := myText.OverlapSimilarity(otherText)
+	require.NoError(t, err)
+	require.InDelta(t, 3.0/5.0, overlap, 1e-12)
+
+	tversky, err := myText.TverskySimilarity(otherText, 1.0, 1.0)
+	require.NoError(t, err)
+	require.InDelta(t, jaccard, tversky, 1e-12)
+}
+
 func TestFleschKincaidErrorsOnly(t *testing.T) {
 	myText, err := text.New("The cat sat on the mat.")
 	require.NoError(t, err)
@@ -238,6 +440,80 @@ func TestFleschKincaidErrorsOnly(t *testing.T) {
 	require.NotEqual(t, 0.0, score)
 }
 
+func TestReadabilityCounts(t *testing.T) {
+	myText, err := text.New("cars have engines like motorcycles, have engines")
+	require.NoError(t, err)
+	require.NotNil(t, myText)
+
+	// "engines" and "motorcycles" have three or more syllables, "cars" has one,
+	// and the comma is not counted as a character
+	require.Equal(t, 3, myText.PolysyllableCount())
+	require.Equal(t, 1, myText.MonosyllableCount())
+	require.Equal(t, 41, myText.CharacterCount())
+}
+
+func TestReadabilityGradeLevels(t *testing.T) {
+	myText, err := text.New("cars have engines like motorcycles have engines")
+	require.NoError(t, err)
+	require.NotNil(t, myText)
+
+	// 7 words, 1 sentence, 3 polysyllables, 1 monosyllable, and 41 letters;
+	// the formulas themselves are tested in the [readability_test] package.
+	require.InDelta(t, 19.943, myText.GunningFogIndex(), 1e-3)
+	require.InDelta(t, 13.024, myText.SMOGGrade(), 1e-3)
+	require.InDelta(t, 14.411, myText.ColemanLiauIndex(), 1e-3)
+	require.InDelta(t, 9.657, myText.AutomatedReadabilityIndex(), 1e-3)
+	require.InDelta(t, 5.5, myText.LinsearWriteFormula(), 1e-3)
+	require.InDelta(t, 17.857, myText.FORCASTGradeLevel(), 1e-3)
+
+	// Empty text has zero scores
+	empty, err := text.New("")
+	require.NoError(t, err)
+	require.Equal(t, 0.0, empty.GunningFogIndex())
+	require.Equal(t, 0.0, empty.SMOGGrade())
+	require.Equal(t, 0.0, empty.ColemanLiauIndex())
+	require.Equal(t, 0.0, empty.AutomatedReadabilityIndex())
+	require.Equal(t, 0.0, empty.LinsearWriteFormula())
+	require.Equal(t, 0.0, empty.FORCASTGradeLevel())
+}
+
+func TestFamiliarWordScores(t *testing.T) {
+	// "motorcycles" is not on the Dale-Chall list; "cars" matches "car"
+	myText, err := text.New("cars have engines like motorcycles have engines")
+	require.NoError(t, err)
+	require.InDelta(t, 6.239, myText.DaleChallScore(), 1e-3)
+
+	// Every word, or its stem, is familiar
+	myText, err = text.New("The cats were playing with the balloons in the garden")
+	require.NoError(t, err)
+	require.InDelta(t, 0.0496*10.0, myText.DaleChallScore(), 1e-12)
+
+	// Empty text has zero scores
+	empty, err := text.New("", text.WithSpacheWords([]string{"car"}))
+	require.NoError(t, err)
+	require.Equal(t, 0.0, empty.DaleChallScore())
+	score, err := empty.SpacheScore()
+	require.NoError(t, err)
+	require.Equal(t, 0.0, score)
+
+	// 7 words in 1 sentence and "motorcycles" is the only unique difficult
+	// word of the Spache list
+	myText, err = text.New(
+		"cars have engines like motorcycles have engines",
+		text.WithSpacheWords([]string{"car", "have", "engine", "like"}),
+	)
+	require.NoError(t, err)
+	score, err = myText.SpacheScore()
+	require.NoError(t, err)
+	require.InDelta(t, 0.121*7.0+0.082*100.0/7.0+0.659, score, 1e-12)
+
+	// The Spache list is not bundled
+	myText, err = text.New("cars have engines")
+	require.NoError(t, err)
+	_, err = myText.SpacheScore()
+	require.ErrorIs(t, err, errors.ErrMissingConfig)
+}
+
 // Tests that the docstring for [text.Text] work properly; if this ever fails
 // please fix it and then copy the lines that do not have the 'require' checks
 // into that functions docstring.
@@ -331,6 +607,10 @@ func TestTextDocs(t *testing.T) {
 	require.InDelta(t, -5.727, ease, 1e-3)
 	grade := myText.FleschKincaidGradeLevel()
 	require.InDelta(t, 15.797, grade, 1e-3)
+	grade = myText.GunningFogIndex()
+	require.InDelta(t, 19.943, grade, 1e-3)
+	grade = myText.SMOGGrade()
+	require.InDelta(t, 13.024, grade, 1e-3)
 
 	// Get the counts of various things
 	count := myText.WordCount()
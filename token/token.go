@@ -6,7 +6,9 @@ import (
 
 // A single word token.
 type Token struct {
-	token string
+	token   string
+	span    Span
+	hasSpan bool
 }
 
 /*
@@ -32,6 +34,26 @@ func New(token string) Token {
 	}
 }
 
+// Returns a new [Token] with the [Span] of the source text it came from. The
+// token does not need to equal the spanned text, e.g. a stem keeps the span of
+// the word it was stemmed from.
+//
+// Usage example:
+//
+//	chunk := "The aardvarks ran."
+//	tok := token.NewWithSpan("aardvarks", token.Span{Start: 4, End: 13, RuneStart: 4, RuneEnd: 13})
+//
+//	// Get the source text for the token
+//	span, ok := tok.Span() // ok is true
+//	source := chunk[span.Start:span.End] // "aardvarks"
+func NewWithSpan(token string, span Span) Token {
+	return Token{
+		token:   token,
+		span:    span,
+		hasSpan: true,
+	}
+}
+
 // Returns the number of UTF-8 runes in the token.
 func (t *Token) Len() int {
 	return utf8.RuneCountInString(t.token)
@@ -56,3 +78,38 @@ func (t *Token) Runes() []rune {
 func (t *Token) Bytes() []byte {
 	return []byte(t.token)
 }
+
+// Returns the [Span] of the source text the token came from and true, or a
+// zero [Span] and false if the token was created without one.
+func (t *Token) Span() (span Span, ok bool) {
+	return t.span, t.hasSpan
+}
+
+// Returns true if the token has a [Span] of the source text.
+func (t *Token) HasSpan() bool {
+	return t.hasSpan
+}
+
+// ############################################################################
+// Span
+// ############################################################################
+
+// The location of a token in the source text as half-open byte and rune
+// offsets, so that the bytes of the source are source[Start:End] and the runes
+// are []rune(source)[RuneStart:RuneEnd].
+type Span struct {
+	Start     int // byte offset of the first byte
+	End       int // byte offset after the last byte
+	RuneStart int // rune offset of the first rune
+	RuneEnd   int // rune offset after the last rune
+}
+
+// Returns the number of bytes in the span.
+func (s Span) ByteLen() int {
+	return s.End - s.Start
+}
+
+// Returns the number of runes in the span.
+func (s Span) Len() int {
+	return s.RuneEnd - s.RuneStart
+}
@@ -0,0 +1,613 @@
+package tokenize
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/tokenlist"
+)
+
+// ############################################################################
+// PunktSentenceSegmenter
+// ############################################################################
+
+// Ensure [PunktSentenceSegmenter] meets the [Tokenizer] and [SpanTokenizer]
+// interface requirements.
+var _ Tokenizer = &PunktSentenceSegmenter{}
+var _ SpanTokenizer = &PunktSentenceSegmenter{}
+
+/*
+PunktSentenceSegmenter splits text into sentences with the unsupervised Punkt
+algorithm (Kiss & Strunk, 2006), which uses learned [PunktParameters] to decide
+whether a period ends a sentence or belongs to an abbreviation, an initial, an
+ordinal number, or an ellipsis; create with [NewPunktSentenceSegmenter].
+
+The segmenter works untrained with a list of common abbreviations for the
+language, but works best with parameters learned from a corpus similar to the
+text being segmented with a [PunktTrainer].
+
+Usage example:
+
+	// Learn the parameters from a corpus
+	trainer := tokenize.NewPunktTrainer()
+	trainer.Train(corpus)
+
+	// Segment text into sentences
+	segmenter := tokenize.NewPunktSentenceSegmenter(
+		tokenize.PunktSentenceSegmenterWithParameters(trainer.Parameters()),
+	)
+	sentences, err := segmenter.Tokenize("Mr. Smith paid $3.50 for the U.S. map. It was cheap.")
+	// {"Mr. Smith paid $3.50 for the U.S. map.", "It was cheap."}
+*/
+type PunktSentenceSegmenter struct {
+	lang   language.Language
+	params *PunktParameters
+}
+
+// Returns a new [PunktSentenceSegmenter] instance.
+//
+// Defaults:
+//   - Language: [language.English]
+//   - Parameters: the language's abbreviations (e.g. [language.ABBREVIATIONS_ENGLISH])
+func NewPunktSentenceSegmenter(opts ...PunktSentenceSegmenterOption) *PunktSentenceSegmenter {
+	// Set options
+	segmenter := &PunktSentenceSegmenter{}
+	for _, fn := range opts {
+		fn(segmenter)
+	}
+
+	// Set defaults
+	if segmenter.lang == language.Unknown {
+		segmenter.lang = language.English
+	}
+	if segmenter.params == nil {
+		segmenter.params = NewPunktParameters()
+		switch segmenter.lang {
+		case language.English:
+			segmenter.params.AddAbbreviations(language.ABBREVIATIONS_ENGLISH...)
+		}
+	}
+
+	return segmenter
+}
+
+// Returns the [PunktSentenceSegmenter]s configured [language.Language].
+func (s *PunktSentenceSegmenter) Language() language.Language {
+	return s.lang
+}
+
+// Returns the [PunktSentenceSegmenter]s configured [PunktParameters].
+func (s *PunktSentenceSegmenter) Parameters() *PunktParameters {
+	return s.params
+}
+
+// Returns the sentences in the text chunk as they appear in the chunk, without
+// the whitespace between them. ALWAYS returns nil for the error.
+func (s *PunktSentenceSegmenter) Tokenize(chunk string) (sentences []string, alwaysNil error) {
+	sentences = make([]string, 0)
+	for _, loc := range s.sentenceIndices(chunk) {
+		sentences = append(sentences, chunk[loc[0]:loc[1]])
+	}
+	return sentences, nil
+}
+
+// Returns the sentences in the text chunk like [PunktSentenceSegmenter.Tokenize]
+// with the [token.Span] of each sentence in the chunk. ALWAYS returns nil for
+// the error.
+func (s *PunktSentenceSegmenter) TokenizeSpans(chunk string) (sentences tokenlist.TokenList, alwaysNil error) {
+	counter := newSpanCounter(chunk)
+	sentences = make(tokenlist.TokenList, 0)
+	for _, loc := range s.sentenceIndices(chunk) {
+		sentences = append(sentences, counter.token(loc[0], loc[1]))
+	}
+	return sentences, nil
+}
+
+// Returns the start and end byte offsets of the sentences in the chunk.
+func (s *PunktSentenceSegmenter) sentenceIndices(chunk string) (indices [][2]int) {
+	tokens := punktTokenize(chunk)
+	s.params.annotateFirstPass(tokens)
+	s.params.annotateSecondPass(tokens)
+
+	start := -1
+	for i := 0; i < len(tokens); i++ {
+		if start < 0 {
+			start = tokens[i].start
+		}
+		if !tokens[i].sentBreak {
+			continue
+		}
+
+		// Sentences end with the whitespace after the break, so closing quotes
+		// and brackets stay with the sentence; if a word follows the break
+		// without whitespace, there is no sentence break.
+		j := i + 1
+		for j < len(tokens) && tokens[j].fieldEnd == tokens[i].fieldEnd && !tokens[j].isNonPunct() {
+			j++
+		}
+		if j < len(tokens) && tokens[j].fieldEnd == tokens[i].fieldEnd {
+			continue
+		}
+
+		indices = append(indices, [2]int{start, tokens[i].fieldEnd})
+		start = -1
+		i = j - 1
+	}
+
+	// The last sentence may not end with a sentence break
+	if start >= 0 {
+		indices = append(indices, [2]int{start, tokens[len(tokens)-1].fieldEnd})
+	}
+	return indices
+}
+
+// ############################################################################
+// PunktSentenceSegmenterOption
+// ############################################################################
+
+// PunktSentenceSegmenterOption functions modify a [PunktSentenceSegmenter].
+type PunktSentenceSegmenterOption func(s *PunktSentenceSegmenter)
+
+// Returns a function which sets the [language.Language] to use with the
+// [PunktSentenceSegmenter].
+func PunktSentenceSegmenterWithLanguage(lang language.Language) PunktSentenceSegmenterOption {
+	return func(s *PunktSentenceSegmenter) {
+		s.lang = lang
+	}
+}
+
+// Returns a function which sets the [PunktParameters] to use with the
+// [PunktSentenceSegmenter], e.g. parameters learned by a [PunktTrainer] or
+// loaded from JSON.
+func PunktSentenceSegmenterWithParameters(params *PunktParameters) PunktSentenceSegmenterOption {
+	return func(s *PunktSentenceSegmenter) {
+		s.params = params
+	}
+}
+
+// ############################################################################
+// PunktParameters
+// ############################################################################
+
+// Orthographic context flags, recording whether a word type was seen with an
+// uppercase or lowercase first letter at the beginning of a sentence, in the
+// middle of a sentence, or where it is unknown if a sentence begins.
+const (
+	orthoBegUC = 1 << 1
+	orthoMidUC = 1 << 2
+	orthoUnkUC = 1 << 3
+	orthoBegLC = 1 << 4
+	orthoMidLC = 1 << 5
+	orthoUnkLC = 1 << 6
+	orthoUC    = orthoBegUC | orthoMidUC | orthoUnkUC
+	orthoLC    = orthoBegLC | orthoMidLC | orthoUnkLC
+)
+
+/*
+PunktParameters are the parameters used by a [PunktSentenceSegmenter]: the
+known abbreviations, collocations (pairs of words which are rarely split by a
+sentence break, e.g. "Jan. 1"), frequent sentence starters, and the
+orthographic context (capitalization) of each word type. Parameters are
+usually learned by a [PunktTrainer] and can be saved and loaded as JSON; create
+empty parameters with [NewPunktParameters].
+
+Usage example:
+
+	// Save the learned parameters
+	data, err := json.Marshal(trainer.Parameters())
+
+	// Load the parameters
+	params := tokenize.NewPunktParameters()
+	err = json.Unmarshal(data, params)
+*/
+type PunktParameters struct {
+	abbreviations    map[string]struct{}
+	collocations     map[[2]string]struct{}
+	sentenceStarters map[string]struct{}
+	orthoContext     map[string]int
+}
+
+// Returns new, empty [PunktParameters].
+func NewPunktParameters() *PunktParameters {
+	return &PunktParameters{
+		abbreviations:    make(map[string]struct{}),
+		collocations:     make(map[[2]string]struct{}),
+		sentenceStarters: make(map[string]struct{}),
+		orthoContext:     make(map[string]int),
+	}
+}
+
+// Adds the abbreviations, which are lowercased and have any final period
+// removed (e.g. "Dr." is added as "dr").
+func (p *PunktParameters) AddAbbreviations(abbreviations ...string) {
+	for _, abbr := range abbreviations {
+		p.abbreviations[strings.TrimSuffix(strings.ToLower(abbr), ".")] = struct{}{}
+	}
+}
+
+// Returns true if the word is a known abbreviation, ignoring case and a final
+// period.
+func (p *PunktParameters) IsAbbreviation(word string) bool {
+	_, ok := p.abbreviations[strings.TrimSuffix(strings.ToLower(word), ".")]
+	return ok
+}
+
+// Returns the known abbreviations in sorted order.
+func (p *PunktParameters) Abbreviations() []string {
+	return sortedKeys(p.abbreviations)
+}
+
+// Adds a collocation of two lowercase words which should not be split by a
+// sentence break after the first word's period.
+func (p *PunktParameters) AddCollocation(first, second string) {
+	p.collocations[[2]string{strings.ToLower(first), strings.ToLower(second)}] = struct{}{}
+}
+
+// Returns the collocations in sorted order.
+func (p *PunktParameters) Collocations() (collocations [][2]string) {
+	collocations = make([][2]string, 0, len(p.collocations))
+	for colloc := range p.collocations {
+		collocations = append(collocations, colloc)
+	}
+	slices.SortFunc(collocations, func(a, b [2]string) int {
+		if c := strings.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		return strings.Compare(a[1], b[1])
+	})
+	return collocations
+}
+
+// Adds words which frequently start sentences; they are lowercased.
+func (p *PunktParameters) AddSentenceStarters(starters ...string) {
+	for _, starter := range starters {
+		p.sentenceStarters[strings.ToLower(starter)] = struct{}{}
+	}
+}
+
+// Returns the frequent sentence starters in sorted order.
+func (p *PunktParameters) SentenceStarters() []string {
+	return sortedKeys(p.sentenceStarters)
+}
+
+// Returns a deep copy of the [PunktParameters].
+func (p *PunktParameters) Copy() *PunktParameters {
+	other := NewPunktParameters()
+	for abbr := range p.abbreviations {
+		other.abbreviations[abbr] = struct{}{}
+	}
+	for colloc := range p.collocations {
+		other.collocations[colloc] = struct{}{}
+	}
+	for starter := range p.sentenceStarters {
+		other.sentenceStarters[starter] = struct{}{}
+	}
+	for typ, flags := range p.orthoContext {
+		other.orthoContext[typ] = flags
+	}
+	return other
+}
+
+// The JSON representation of [PunktParameters].
+type punktParametersJSON struct {
+	Abbreviations    []string       `json:"abbreviations"`
+	Collocations     [][2]string    `json:"collocations"`
+	SentenceStarters []string       `json:"sentence_starters"`
+	OrthoContext     map[string]int `json:"ortho_context"`
+}
+
+// Marshals the [PunktParameters] to JSON with sorted lists.
+func (p *PunktParameters) MarshalJSON() ([]byte, error) {
+	return json.Marshal(punktParametersJSON{
+		Abbreviations:    p.Abbreviations(),
+		Collocations:     p.Collocations(),
+		SentenceStarters: p.SentenceStarters(),
+		OrthoContext:     p.orthoContext,
+	})
+}
+
+// Unmarshals the [PunktParameters] from JSON, replacing any existing
+// parameters.
+func (p *PunktParameters) UnmarshalJSON(data []byte) (err error) {
+	var params punktParametersJSON
+	if err = json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+
+	*p = *NewPunktParameters()
+	p.AddAbbreviations(params.Abbreviations...)
+	for _, colloc := range params.Collocations {
+		p.AddCollocation(colloc[0], colloc[1])
+	}
+	p.AddSentenceStarters(params.SentenceStarters...)
+	for typ, flags := range params.OrthoContext {
+		p.orthoContext[typ] = flags
+	}
+	return nil
+}
+
+// Returns the keys of the set in sorted order.
+func sortedKeys(set map[string]struct{}) (keys []string) {
+	keys = make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Annotates the tokens with sentence breaks, abbreviations, and ellipses from
+// the tokens alone (the first pass of the Punkt algorithm).
+func (p *PunktParameters) annotateFirstPass(tokens []punktToken) {
+	for i := range tokens {
+		tok := &tokens[i]
+		switch {
+		case tok.tok == "!" || tok.tok == "?":
+			tok.sentBreak = true
+		case tok.isEllipsis():
+			tok.ellipsis = true
+		case tok.periodFinal && !strings.HasSuffix(tok.tok, ".."):
+			word := strings.ToLower(strings.TrimSuffix(tok.tok, "."))
+			parts := strings.Split(word, "-")
+			if _, ok := p.abbreviations[word]; ok {
+				tok.abbr = true
+			} else if _, ok := p.abbreviations[parts[len(parts)-1]]; ok {
+				tok.abbr = true
+			} else {
+				tok.sentBreak = true
+			}
+		}
+	}
+}
+
+// Corrects the first pass annotations using the collocations, sentence
+// starters, and orthographic context of the following token (the second pass
+// of the Punkt algorithm).
+func (p *PunktParameters) annotateSecondPass(tokens []punktToken) {
+	for i := 0; i+1 < len(tokens); i++ {
+		tok, next := &tokens[i], &tokens[i+1]
+		if !tok.periodFinal {
+			continue
+		}
+
+		typ := tok.typeNoPeriod()
+		nextTyp := next.typeNoSentPeriod()
+		isInitial := tok.isInitial()
+
+		// Collocation heuristic: a known collocation is not split
+		if _, ok := p.collocations[[2]string{typ, nextTyp}]; ok {
+			tok.sentBreak = false
+			tok.abbr = true
+			continue
+		}
+
+		// Abbreviations and ellipses also end a sentence if the next word
+		// starts a sentence according to its orthography or is a frequent
+		// sentence starter
+		if (tok.abbr || tok.ellipsis) && !isInitial {
+			if p.orthoHeuristic(next) == orthoStarter {
+				tok.sentBreak = true
+				continue
+			}
+			if _, ok := p.sentenceStarters[nextTyp]; ok && next.firstUpper {
+				tok.sentBreak = true
+				continue
+			}
+		}
+
+		// Initials and ordinal numbers do not end a sentence unless the next
+		// word starts a sentence according to its orthography
+		if isInitial || typ == punktNumber {
+			heuristic := p.orthoHeuristic(next)
+			if heuristic == orthoNotStarter {
+				tok.sentBreak = false
+				tok.abbr = true
+				continue
+			}
+
+			// Initials followed by a word which is always capitalized are
+			// abbreviations (e.g. "J. S. Bach")
+			if heuristic == orthoUnknown && isInitial && next.firstUpper && p.orthoContext[nextTyp]&orthoLC == 0 {
+				tok.sentBreak = false
+				tok.abbr = true
+			}
+		}
+	}
+}
+
+// Results of the orthographic heuristic.
+const (
+	orthoUnknown = iota
+	orthoStarter
+	orthoNotStarter
+)
+
+// Decides if the token starts a sentence from the capitalization of its word
+// type elsewhere in the training data.
+func (p *PunktParameters) orthoHeuristic(tok *punktToken) int {
+	if len(tok.tok) == 1 && strings.Contains(";:,.!?", tok.tok) {
+		return orthoNotStarter
+	}
+
+	ortho := p.orthoContext[tok.typeNoSentPeriod()]
+	if tok.firstUpper && ortho&orthoLC != 0 && ortho&orthoMidUC == 0 {
+		return orthoStarter
+	}
+	if tok.firstLower && (ortho&orthoUC != 0 || ortho&orthoBegLC == 0) {
+		return orthoNotStarter
+	}
+	return orthoUnknown
+}
+
+// ############################################################################
+// Punkt Tokens
+// ############################################################################
+
+// The word type of all numbers.
+const punktNumber = "##number##"
+
+var punktNumberRegex = regexp.MustCompile(`^-?[\.,]?\d[\d,\.\-]*\.?$`)
+
+// A word token used by the Punkt algorithm with its annotations.
+type punktToken struct {
+	tok         string
+	typ         string // lowercase token with numbers replaced by [punktNumber]
+	start       int    // byte offset of the token
+	fieldEnd    int    // byte offset of the end of the token's whitespace-delimited field
+	lineStart   bool   // the token is the first on a line
+	paraStart   bool   // the token is the first in a paragraph
+	periodFinal bool   // the token ends in a period
+	firstUpper  bool   // the first rune is uppercase
+	firstLower  bool   // the first rune is lowercase
+	sentBreak   bool   // annotation: a sentence ends after the token
+	abbr        bool   // annotation: the token is an abbreviation
+	ellipsis    bool   // annotation: the token is an ellipsis
+}
+
+// Returns a new [punktToken] for the token.
+func newPunktToken(tok string, start, fieldEnd int) punktToken {
+	first, _ := utf8.DecodeRuneInString(tok)
+	typ := strings.ToLower(tok)
+	if punktNumberRegex.MatchString(typ) {
+		typ = punktNumber
+	}
+	return punktToken{
+		tok:         tok,
+		typ:         typ,
+		start:       start,
+		fieldEnd:    fieldEnd,
+		periodFinal: strings.HasSuffix(tok, ".") || strings.HasSuffix(tok, "…"),
+		firstUpper:  unicode.IsUpper(first),
+		firstLower:  unicode.IsLower(first),
+	}
+}
+
+// Returns the word type without a final period.
+func (t *punktToken) typeNoPeriod() string {
+	if len(t.typ) > 1 && strings.HasSuffix(t.typ, ".") {
+		return t.typ[:len(t.typ)-1]
+	}
+	return t.typ
+}
+
+// Returns the word type without a final period if the period ends a sentence.
+func (t *punktToken) typeNoSentPeriod() string {
+	if t.sentBreak {
+		return t.typeNoPeriod()
+	}
+	return t.typ
+}
+
+// Returns true if the token is an ellipsis ("..." or "…").
+func (t *punktToken) isEllipsis() bool {
+	return (len(t.tok) > 1 && strings.Trim(t.tok, ".") == "") || strings.Trim(t.tok, "…") == ""
+}
+
+// Returns true if the token is a number.
+func (t *punktToken) isNumber() bool {
+	return strings.HasPrefix(t.typ, punktNumber)
+}
+
+// Returns true if the token is an initial: a single letter and a period.
+func (t *punktToken) isInitial() bool {
+	r, size := utf8.DecodeRuneInString(t.tok)
+	return unicode.IsLetter(r) && t.tok[size:] == "."
+}
+
+// Returns true if the token only contains letters.
+func (t *punktToken) isAlpha() bool {
+	return t.tok != "" && strings.IndexFunc(t.tok, func(r rune) bool { return !unicode.IsLetter(r) }) < 0
+}
+
+// Returns true if the token contains a letter or a digit.
+func (t *punktToken) isNonPunct() bool {
+	return strings.IndexFunc(t.typ, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
+
+// Splits the chunk into [punktToken]s. Each whitespace-delimited field is split
+// into words, which keep any periods, and the punctuation around them.
+func punktTokenize(chunk string) (tokens []punktToken) {
+	prevEnd := 0
+	for i, field := range fieldIndices(chunk) {
+		// Find line and paragraph starts from the whitespace before the field
+		newlines := strings.Count(chunk[prevEnd:field[0]], "\n")
+		lineStart := i == 0 || newlines > 0
+		paraStart := newlines > 1
+		prevEnd = field[1]
+
+		text := chunk[field[0]:field[1]]
+		for j := 0; j < len(text); {
+			n := punktWordLen(text[j:])
+			tok := newPunktToken(text[j:j+n], field[0]+j, field[1])
+			if j == 0 {
+				tok.lineStart = lineStart
+				tok.paraStart = paraStart
+			}
+			tokens = append(tokens, tok)
+			j += n
+		}
+	}
+	return tokens
+}
+
+// Returns the byte length of the word or punctuation at the start of the text,
+// which does not contain whitespace.
+func punktWordLen(text string) int {
+	if n := punktMultiCharLen(text); n > 0 {
+		return n
+	}
+
+	r, size := utf8.DecodeRuneInString(text)
+	if !punktWordStart(r) {
+		return size
+	}
+
+	// Consume runes until the end of the word
+	j := size
+	for j < len(text) {
+		r, size = utf8.DecodeRuneInString(text[j:])
+		if punktNonWord(r) || punktMultiCharLen(text[j:]) > 0 {
+			break
+		}
+
+		// A comma ends a word if it ends the text or is followed by punctuation
+		if r == ',' {
+			if j+size == len(text) {
+				break
+			}
+			next, _ := utf8.DecodeRuneInString(text[j+size:])
+			if punktNonWord(next) || punktMultiCharLen(text[j+size:]) > 0 {
+				break
+			}
+		}
+		j += size
+	}
+	return j
+}
+
+// Returns the byte length of the multi-character punctuation (e.g. "--",
+// "...", or "……") at the start of the text, or 0.
+func punktMultiCharLen(text string) int {
+	for _, s := range []string{"-", ".", "…", "—"} {
+		n := len(text) - len(strings.TrimLeft(text, s))
+		if n > len(s) || (n > 0 && (s == "…" || s == "—")) {
+			return n
+		}
+	}
+	return 0
+}
+
+// Returns true if the rune can start a word.
+func punktWordStart(r rune) bool {
+	return !strings.ContainsRune("(\"`{[:;&#*@)}]-,“”‘’«»", r)
+}
+
+// Returns true if the rune cannot be part of a word.
+func punktNonWord(r rune) bool {
+	return strings.ContainsRune("?!)\";}]*:@'({[“”‘’«»", r)
+}
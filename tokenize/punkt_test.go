@@ -0,0 +1,221 @@
+package tokenize_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// A small training corpus where "approx." and "dept." are always abbreviations
+// and the nouns are seen both inside and at the end of sentences.
+func punktCorpus() string {
+	nouns := []string{"report", "budget", "river", "garden", "letter", "bridge", "market", "school", "window", "engine"}
+	var sb strings.Builder
+	for i := 0; i < 60; i++ {
+		a, b := nouns[i%len(nouns)], nouns[(i*3+1)%len(nouns)]
+		fmt.Fprintf(&sb, "The %s was near the %s. ", a, b)
+		fmt.Fprintf(&sb, "We saw the %s approx. twice a week near the %s. ", b, a)
+		fmt.Fprintf(&sb, "Our dept. bought a %s. ", a)
+		if i%3 == 0 {
+			fmt.Fprintf(&sb, "Then the %s closed. ", b)
+		}
+	}
+	return sb.String()
+}
+
+func TestNewPunktSentenceSegmenter(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		segmenter := tokenize.NewPunktSentenceSegmenter()
+		require.NotNil(t, segmenter)
+		require.Equal(t, language.English, segmenter.Language())
+		require.True(t, segmenter.Parameters().IsAbbreviation("e.g."))
+		require.True(t, segmenter.Parameters().IsAbbreviation("Dr."))
+		require.Len(t, segmenter.Parameters().Abbreviations(), len(language.ABBREVIATIONS_ENGLISH))
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		params := tokenize.NewPunktParameters()
+		segmenter := tokenize.NewPunktSentenceSegmenter(
+			tokenize.PunktSentenceSegmenterWithLanguage(language.English),
+			tokenize.PunktSentenceSegmenterWithParameters(params),
+		)
+		require.Equal(t, params, segmenter.Parameters())
+		require.Empty(t, segmenter.Parameters().Abbreviations())
+	})
+}
+
+func TestPunktSentenceSegmenter(t *testing.T) {
+	segmenter := tokenize.NewPunktSentenceSegmenter()
+
+	testcases := []struct {
+		Name     string
+		Text     string
+		Expected []string
+	}{
+		{
+			Name:     "Empty",
+			Text:     "  ",
+			Expected: []string{},
+		},
+		{
+			Name:     "AbbreviationsAndDecimals",
+			Text:     "Mr. Smith paid $3.50 for the U.S. map. It was cheap.",
+			Expected: []string{"Mr. Smith paid $3.50 for the U.S. map.", "It was cheap."},
+		},
+		{
+			Name:     "ExclamationsAndQuestions",
+			Text:     "Isn't that amazing!?\n I think so!\t Crazy times, indeed. Really? Yes",
+			Expected: []string{"Isn't that amazing!?", "I think so!", "Crazy times, indeed.", "Really?", "Yes"},
+		},
+		{
+			Name:     "ClosingPunctuation",
+			Text:     "He said \"stop.\" Then he left (quietly.) The end.",
+			Expected: []string{"He said \"stop.\"", "Then he left (quietly.)", "The end."},
+		},
+		{
+			Name:     "InitialsAndHyphens",
+			Text:     "See e.g. the docs. J. S. Bach was born in 1685. He wrote music for the ex-Prof. Kim.",
+			Expected: []string{"See e.g. the docs.", "J. S. Bach was born in 1685.", "He wrote music for the ex-Prof. Kim."},
+		},
+		{
+			Name:     "NoWhitespace",
+			Text:     "Visit example.com today.Or not.",
+			Expected: []string{"Visit example.com today.Or not."},
+		},
+		{
+			Name:     "Newlines",
+			Text:     "First line.\nSecond line.\n\nNew paragraph.",
+			Expected: []string{"First line.", "Second line.", "New paragraph."},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sentences, err := segmenter.Tokenize(tc.Text)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, sentences)
+
+			// The spans locate the sentences in the text
+			tokens, err := segmenter.TokenizeSpans(tc.Text)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, tokens.Strings())
+			spans, ok := tokens.Spans()
+			require.True(t, ok)
+			for i, span := range spans {
+				require.Equal(t, tc.Expected[i], tc.Text[span.Start:span.End])
+			}
+		})
+	}
+}
+
+func TestPunktTrainer(t *testing.T) {
+	text := "Our dept. bought approx. ten windows. Then the river rose."
+
+	// Untrained, "dept." and "approx." end sentences
+	untrained := tokenize.NewPunktSentenceSegmenter(tokenize.PunktSentenceSegmenterWithParameters(tokenize.NewPunktParameters()))
+	sentences, err := untrained.Tokenize(text)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Our dept.", "bought approx.", "ten windows.", "Then the river rose."}, sentences)
+
+	// Trained on the corpus the abbreviations and sentence starters are learned
+	trainer := tokenize.NewPunktTrainer()
+	trainer.Train(punktCorpus())
+	params := trainer.Parameters()
+	require.Equal(t, []string{"approx", "dept"}, params.Abbreviations())
+	require.Equal(t, []string{"our", "then", "we"}, params.SentenceStarters())
+	require.Empty(t, params.Collocations())
+
+	trained := tokenize.NewPunktSentenceSegmenter(tokenize.PunktSentenceSegmenterWithParameters(params))
+	sentences, err = trained.Tokenize(text)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Our dept. bought approx. ten windows.", "Then the river rose."}, sentences)
+
+	// An abbreviation followed by a frequent sentence starter ends a sentence
+	sentences, err = trained.Tokenize("We saw the report approx. Then the river rose.")
+	require.NoError(t, err)
+	require.Equal(t, []string{"We saw the report approx.", "Then the river rose."}, sentences)
+
+	// The parameters are a copy, so more training does not change them
+	trainer.Train("The fig. was ripe. The fig. was sweet.")
+	require.Equal(t, []string{"approx", "dept"}, params.Abbreviations())
+}
+
+func TestPunktTrainerCollocations(t *testing.T) {
+	// Ordinal dates are collocations: "5. May" is not split
+	var sb strings.Builder
+	for i := 0; i < 30; i++ {
+		fmt.Fprintf(&sb, "The fair opens on the %d. May in the town. ", i%9+1)
+		fmt.Fprintf(&sb, "Visitors come from the %d. district and the market. ", i%9+1)
+		fmt.Fprintf(&sb, "It ends in %d. The town is quiet then. ", 1990+i)
+	}
+
+	trainer := tokenize.NewPunktTrainer()
+	trainer.Train(sb.String())
+	params := trainer.Parameters()
+	require.Contains(t, params.Collocations(), [2]string{"##number##", "may"})
+
+	segmenter := tokenize.NewPunktSentenceSegmenter(tokenize.PunktSentenceSegmenterWithParameters(params))
+	sentences, err := segmenter.Tokenize("The fair opens on the 3. May in the town. It ends in 2001. The town is quiet then.")
+	require.NoError(t, err)
+	require.Equal(t, []string{"The fair opens on the 3. May in the town.", "It ends in 2001.", "The town is quiet then."}, sentences)
+}
+
+func TestPunktParameters(t *testing.T) {
+	params := tokenize.NewPunktParameters()
+	params.AddAbbreviations("Dr.", "e.g.", "ETC")
+	params.AddCollocation("##number##", "May")
+	params.AddSentenceStarters("However", "the")
+
+	require.Equal(t, []string{"dr", "e.g", "etc"}, params.Abbreviations())
+	require.True(t, params.IsAbbreviation("etc."))
+	require.False(t, params.IsAbbreviation("fig."))
+	require.Equal(t, [][2]string{{"##number##", "may"}}, params.Collocations())
+	require.Equal(t, []string{"however", "the"}, params.SentenceStarters())
+
+	t.Run("Copy", func(t *testing.T) {
+		other := params.Copy()
+		other.AddAbbreviations("fig")
+		require.True(t, other.IsAbbreviation("fig"))
+		require.False(t, params.IsAbbreviation("fig"))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"abbreviations": ["dr", "e.g", "etc"],
+			"collocations": [["##number##", "may"]],
+			"sentence_starters": ["however", "the"],
+			"ortho_context": {}
+		}`, string(data))
+
+		loaded := tokenize.NewPunktParameters()
+		require.NoError(t, json.Unmarshal(data, loaded))
+		require.Equal(t, params, loaded)
+
+		require.Error(t, json.Unmarshal([]byte(`{"abbreviations": 1}`), loaded))
+	})
+
+	t.Run("TrainedJSON", func(t *testing.T) {
+		// The learned orthographic context is also saved
+		trainer := tokenize.NewPunktTrainer()
+		trainer.Train(punktCorpus())
+		params := trainer.Parameters()
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+		loaded := tokenize.NewPunktParameters()
+		require.NoError(t, json.Unmarshal(data, loaded))
+		require.Equal(t, params, loaded)
+
+		text := "We saw the report approx. Then the river rose. Our dept. bought it."
+		expected, _ := tokenize.NewPunktSentenceSegmenter(tokenize.PunktSentenceSegmenterWithParameters(params)).Tokenize(text)
+		actual, _ := tokenize.NewPunktSentenceSegmenter(tokenize.PunktSentenceSegmenterWithParameters(loaded)).Tokenize(text)
+		require.Equal(t, expected, actual)
+	})
+}
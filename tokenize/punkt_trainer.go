@@ -0,0 +1,297 @@
+package tokenize
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// ############################################################################
+// PunktTrainer
+// ############################################################################
+
+// Thresholds used by the [PunktTrainer], from the Punkt paper and NLTK.
+const (
+	punktAbbrevThreshold      = 0.3  // minimum score to learn an abbreviation
+	punktAbbrevBackoff        = 5    // maximum occurrences of a rare abbreviation
+	punktCollocationThreshold = 7.88 // minimum log likelihood of a collocation
+	punktSentStarterThreshold = 30.0 // minimum log likelihood of a sentence starter
+	punktMinCollocationFreq   = 1    // a collocation must occur more often than this
+)
+
+/*
+PunktTrainer learns [PunktParameters] for a [PunktSentenceSegmenter] from
+unannotated text: the abbreviations (words which usually end in a period), the
+collocations of words with periods (e.g. "Jan. 1"), the words which frequently
+start sentences, and the capitalization of each word type; create with
+[NewPunktTrainer]. The more text (e.g. thousands of sentences) from the domain
+which is later segmented, the better the parameters.
+
+Usage example:
+
+	trainer := tokenize.NewPunktTrainer()
+	for _, doc := range corpus {
+		trainer.Train(doc)
+	}
+	params := trainer.Parameters()
+*/
+type PunktTrainer struct {
+	params            *PunktParameters
+	typeCounts        map[string]int
+	tokenCount        int
+	periodTokenCount  int
+	sentBreakCount    int
+	sentStarterCounts map[string]int
+	collocationCounts map[[2]string]int
+}
+
+// Returns a new [PunktTrainer] without any learned parameters.
+func NewPunktTrainer() *PunktTrainer {
+	return &PunktTrainer{
+		params:            NewPunktParameters(),
+		typeCounts:        make(map[string]int),
+		sentStarterCounts: make(map[string]int),
+		collocationCounts: make(map[[2]string]int),
+	}
+}
+
+// Learns from the text; call multiple times to train on a corpus of texts.
+func (t *PunktTrainer) Train(text string) {
+	tokens := punktTokenize(text)
+
+	// Count the word types and tokens with periods
+	types := make(map[string]struct{})
+	for _, tok := range tokens {
+		t.typeCounts[tok.typ]++
+		t.tokenCount++
+		if tok.periodFinal {
+			t.periodTokenCount++
+		}
+		types[tok.typ] = struct{}{}
+	}
+
+	// Learn which types with periods are abbreviations
+	t.reclassifyAbbreviations(types)
+
+	// Annotate the sentence breaks with the abbreviations and learn the
+	// orthographic context of each word type
+	t.params.annotateFirstPass(tokens)
+	t.learnOrthography(tokens)
+	for _, tok := range tokens {
+		if tok.sentBreak {
+			t.sentBreakCount++
+		}
+	}
+
+	// Find rare abbreviations and count the possible sentence starters and
+	// collocations after periods
+	for i := 0; i+1 < len(tokens); i++ {
+		tok, next := &tokens[i], &tokens[i+1]
+		if !tok.periodFinal {
+			continue
+		}
+		if t.isRareAbbreviation(tok, next) {
+			t.params.abbreviations[tok.typeNoPeriod()] = struct{}{}
+		}
+		if tok.sentBreak && !(tok.isNumber() || tok.isInitial()) && next.isAlpha() {
+			t.sentStarterCounts[next.typ]++
+		}
+		if tok.sentBreak && (tok.isNumber() || tok.isInitial()) && tok.isNonPunct() && next.isNonPunct() {
+			t.collocationCounts[[2]string{tok.typeNoPeriod(), next.typeNoSentPeriod()}]++
+		}
+	}
+}
+
+// Returns a copy of the [PunktParameters] learned from all of the training text
+// so far, including the sentence starters and collocations.
+func (t *PunktTrainer) Parameters() *PunktParameters {
+	params := t.params.Copy()
+
+	// Find the frequent sentence starters
+	for typ, atBreak := range t.sentStarterCounts {
+		count := t.typeCounts[typ] + t.typeCounts[typ+"."]
+		if t.sentBreakCount == 0 || count < atBreak {
+			continue
+		}
+		ll := collocationLogLikelihood(t.sentBreakCount, count, atBreak, t.tokenCount)
+		if ll >= punktSentStarterThreshold && float64(t.tokenCount)/float64(t.sentBreakCount) > float64(count)/float64(atBreak) {
+			params.sentenceStarters[typ] = struct{}{}
+		}
+	}
+
+	// Find the collocations, excluding those with frequent sentence starters
+	for colloc, count := range t.collocationCounts {
+		if _, ok := params.sentenceStarters[colloc[1]]; ok {
+			continue
+		}
+		count1 := t.typeCounts[colloc[0]] + t.typeCounts[colloc[0]+"."]
+		count2 := t.typeCounts[colloc[1]] + t.typeCounts[colloc[1]+"."]
+		if count1 > 1 && count2 > 1 && punktMinCollocationFreq < count && count <= min(count1, count2) {
+			ll := collocationLogLikelihood(count1, count2, count, t.tokenCount)
+			if ll >= punktCollocationThreshold && float64(t.tokenCount)/float64(count1) > float64(count2)/float64(count) {
+				params.collocations[colloc] = struct{}{}
+			}
+		}
+	}
+
+	return params
+}
+
+// Adds the word types which end in a period and score as abbreviations, and
+// removes known abbreviations which no longer score as abbreviations.
+func (t *PunktTrainer) reclassifyAbbreviations(types map[string]struct{}) {
+	for typ := range types {
+		tok := punktToken{typ: typ}
+		if typ == punktNumber || !tok.isNonPunct() {
+			continue
+		}
+
+		var add bool
+		if strings.HasSuffix(typ, ".") {
+			if _, ok := t.params.abbreviations[typ]; ok {
+				continue
+			}
+			typ, add = typ[:len(typ)-1], true
+		} else if _, ok := t.params.abbreviations[typ]; !ok {
+			continue
+		}
+
+		// Score the likelihood of the period belonging to the type, favoring
+		// short types with internal periods which are rarely seen without one
+		periods := strings.Count(typ, ".") + 1
+		nonPeriods := utf8.RuneCountInString(typ) - periods + 1
+		withPeriod := t.typeCounts[typ+"."]
+		withoutPeriod := t.typeCounts[typ]
+
+		ll := dunningLogLikelihood(withPeriod+withoutPeriod, t.periodTokenCount, withPeriod, t.tokenCount)
+		score := ll * math.Exp(-float64(nonPeriods)) * float64(periods) * math.Pow(float64(nonPeriods), -float64(withoutPeriod))
+
+		if score >= punktAbbrevThreshold {
+			if add {
+				t.params.abbreviations[typ] = struct{}{}
+			}
+		} else if !add {
+			delete(t.params.abbreviations, typ)
+		}
+	}
+}
+
+// Returns true if the token is an infrequent word which ends in a period but is
+// an abbreviation because the next token is punctuation or a lowercase word
+// which is usually capitalized at the beginning of sentences.
+func (t *PunktTrainer) isRareAbbreviation(tok, next *punktToken) bool {
+	if tok.abbr || !tok.sentBreak {
+		return false
+	}
+
+	typ := tok.typeNoSentPeriod()
+	count := t.typeCounts[typ] + t.typeCounts[typ[:len(typ)-1]]
+	if _, ok := t.params.abbreviations[typ]; ok || count >= punktAbbrevBackoff {
+		return false
+	}
+
+	if strings.ContainsAny(next.tok[:1], ",:;") {
+		return true
+	}
+	if next.firstLower {
+		ortho := t.params.orthoContext[next.typeNoSentPeriod()]
+		return ortho&orthoBegUC != 0 && ortho&orthoMidUC == 0
+	}
+	return false
+}
+
+// Records the capitalization of each word type at the beginning, in the
+// middle, or at an unknown position in a sentence.
+func (t *PunktTrainer) learnOrthography(tokens []punktToken) {
+	const (
+		internal = iota
+		initial
+		unknown
+	)
+
+	context := internal
+	for _, tok := range tokens {
+		if tok.paraStart && context != unknown {
+			context = initial
+		}
+		if tok.lineStart && context == internal {
+			context = unknown
+		}
+
+		var flag int
+		switch {
+		case tok.firstUpper && context == initial:
+			flag = orthoBegUC
+		case tok.firstUpper && context == internal:
+			flag = orthoMidUC
+		case tok.firstUpper && context == unknown:
+			flag = orthoUnkUC
+		case tok.firstLower && context == initial:
+			flag = orthoBegLC
+		case tok.firstLower && context == internal:
+			flag = orthoMidLC
+		case tok.firstLower && context == unknown:
+			flag = orthoUnkLC
+		}
+		if flag != 0 {
+			t.params.orthoContext[tok.typeNoSentPeriod()] |= flag
+		}
+
+		switch {
+		case tok.sentBreak && !(tok.isNumber() || tok.isInitial()):
+			context = initial
+		case tok.sentBreak || tok.ellipsis || tok.abbr:
+			context = unknown
+		default:
+			context = internal
+		}
+	}
+}
+
+// ############################################################################
+// Log Likelihoods
+// ############################################################################
+
+// Returns the Dunning log likelihood that a type (countA) occurs with a period
+// (countB) more often (countAB) than by chance in n tokens, as modified for
+// Punkt where the alternative hypothesis is a 0.99 probability.
+func dunningLogLikelihood(countA, countB, countAB, n int) float64 {
+	p1 := float64(countB) / float64(n)
+	p2 := 0.99
+	null := xlogy(countAB, p1) + xlogy(countA-countAB, 1.0-p1)
+	alt := xlogy(countAB, p2) + xlogy(countA-countAB, 1.0-p2)
+	return -2.0 * (null - alt)
+}
+
+// Returns the Dunning log likelihood that the types (countA and countB) occur
+// together (countAB) more often than by chance in n tokens.
+func collocationLogLikelihood(countA, countB, countAB, n int) float64 {
+	p := float64(countB) / float64(n)
+	p1 := float64(countAB) / float64(countA)
+	p2 := 1.0
+	if n != countA {
+		p2 = float64(countB-countAB) / float64(n-countA)
+	}
+
+	summand1 := xlogy(countAB, p) + xlogy(countA-countAB, 1.0-p)
+	summand2 := xlogy(countB-countAB, p) + xlogy(n-countA-countB+countAB, 1.0-p)
+
+	var summand3, summand4 float64
+	if countA != countAB && p1 > 0 && p1 < 1 {
+		summand3 = xlogy(countAB, p1) + xlogy(countA-countAB, 1.0-p1)
+	}
+	if countB != countAB && p2 > 0 && p2 < 1 {
+		summand4 = xlogy(countB-countAB, p2) + xlogy(n-countA-countB+countAB, 1.0-p2)
+	}
+
+	return -2.0 * (summand1 + summand2 - summand3 - summand4)
+}
+
+// Returns x*log(y), which is 0 if x is 0 and 0 if y is not positive (where a
+// log likelihood term is undefined).
+func xlogy(x int, y float64) float64 {
+	if x == 0 || y <= 0 {
+		return 0.0
+	}
+	return float64(x) * math.Log(y)
+}
@@ -4,14 +4,17 @@ import (
 	"regexp"
 
 	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/tokenlist"
 )
 
 // ############################################################################
 // RegexTokenizer
 // ############################################################################
 
-// Ensure [RegexTokenizer] meets the [Tokenizer] interface requirements.
+// Ensure [RegexTokenizer] meets the [Tokenizer] and [SpanTokenizer] interface
+// requirements.
 var _ Tokenizer = &RegexTokenizer{}
+var _ SpanTokenizer = &RegexTokenizer{}
 
 // RegexTokenizer can be used to tokenize text; create with [NewRegexTokenizer].
 type RegexTokenizer struct {
@@ -23,7 +26,7 @@ type RegexTokenizer struct {
 //
 // Defaults:
 //   - Language: [LanguageEnglish]
-//   - Regex: [REGEX_ENGLISH_WORDS]
+//   - Regex: [REGEX_ENGLISH_WORDS] for [language.English], otherwise [REGEX_UNICODE_WORDS]
 func NewRegexTokenizer(opts ...RegexTokenizerOption) *RegexTokenizer {
 	// Set options
 	tokenizer := &RegexTokenizer{}
@@ -36,7 +39,11 @@ func NewRegexTokenizer(opts ...RegexTokenizerOption) *RegexTokenizer {
 		tokenizer.lang = language.English
 	}
 	if tokenizer.regex == "" {
-		tokenizer.regex = REGEX_ENGLISH_WORDS
+		if tokenizer.lang == language.English {
+			tokenizer.regex = REGEX_ENGLISH_WORDS
+		} else {
+			tokenizer.regex = REGEX_UNICODE_WORDS
+		}
 	}
 
 	return tokenizer
@@ -66,6 +73,26 @@ func (t *RegexTokenizer) Tokenize(chunk string) (tokens []string, err error) {
 	return tokens, nil
 }
 
+// Tokenizes a text string using [regexp.Regexp.FindAllStringIndex], returning
+// the tokens with their [token.Span]s in the chunk.
+func (t *RegexTokenizer) TokenizeSpans(chunk string) (tokens tokenlist.TokenList, err error) {
+	// Compile regexp
+	var r *regexp.Regexp
+	if r, err = regexp.Compile(t.regex); err != nil {
+		return nil, err
+	}
+
+	// Tokenize with regex
+	counter := newSpanCounter(chunk)
+	indices := r.FindAllStringIndex(chunk, -1)
+	tokens = make(tokenlist.TokenList, 0, len(indices))
+	for _, loc := range indices {
+		tokens = append(tokens, counter.token(loc[0], loc[1]))
+	}
+
+	return tokens, nil
+}
+
 // ############################################################################
 // RegexTokenizerOption
 // ############################################################################
@@ -100,6 +127,11 @@ const (
 	// English word tokenization for words using lowercase and uppercase letters
 	REGEX_ENGLISH_ALPHABET_ONLY = `\b[A-Za-z]+\b`
 
+	// Word tokenization for words in any language using Unicode letters
+	// (including combining marks such as accents), numbers, and underscores,
+	// since '\w' only matches ASCII word characters
+	REGEX_UNICODE_WORDS = `[\p{L}\p{M}\p{N}_]+`
+
 	// Tokenize text by whitespace. Splits a string like [strings.Fields]
 	// would do.
 	REGEX_WHITESPACE = `\S+`
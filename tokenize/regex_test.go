@@ -26,6 +26,16 @@ func TestNewRegexTokenizer(t *testing.T) {
 		require.Equal(t, lang, tok.Language())
 	})
 
+	t.Run("SuccessLanguageOption_LanguageFrench", func(t *testing.T) {
+		tok := tokenize.NewRegexTokenizer(tokenize.RegexTokenizerWithLanguage(language.French))
+		require.Equal(t, language.French, tok.Language())
+		require.Equal(t, tokenize.REGEX_UNICODE_WORDS, tok.Regex())
+
+		tokens, err := tok.Tokenize("L'été à Zürich, 2024.")
+		require.NoError(t, err)
+		require.Equal(t, []string{"L", "été", "à", "Zürich", "2024"}, tokens)
+	})
+
 	t.Run("SuccessRegexOption_REGEX_ENGLISH_ALPHABET_ONLY", func(t *testing.T) {
 		//setup
 		regex := tokenize.REGEX_ENGLISH_ALPHABET_ONLY
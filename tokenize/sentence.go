@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/token"
+	"go.rtnl.ai/nlp/tokenlist"
 )
 
 type SentenceSegmenter struct {
@@ -14,8 +16,10 @@ type SentenceSegmenter struct {
 	whitespaceTokenizer *WhitespaceTokenizer
 }
 
-// Ensure [SentenceSegmenter] meets the [Tokenizer] interface requirements.
+// Ensure [SentenceSegmenter] meets the [Tokenizer] and [SpanTokenizer]
+// interface requirements.
 var _ Tokenizer = &SentenceSegmenter{}
+var _ SpanTokenizer = &SentenceSegmenter{}
 
 // Returns a new [SentenceSegmenter]. Default language is [language.English].
 func NewSentenceSegmenter(opts ...SentenceSegmenterOption) *SentenceSegmenter {
@@ -35,7 +39,24 @@ func NewSentenceSegmenter(opts ...SentenceSegmenterOption) *SentenceSegmenter {
 	case language.English:
 		segmenter.punctuation = language.SENTENCE_PUNCTUATION_ENGLISH
 		segmenter.stopWords = language.SENTENCE_STOP_WORDS_ENGLISH
-
+	case language.Spanish:
+		segmenter.punctuation = language.SENTENCE_PUNCTUATION_LATIN
+		segmenter.stopWords = language.SENTENCE_STOP_WORDS_SPANISH
+	case language.French:
+		segmenter.punctuation = language.SENTENCE_PUNCTUATION_LATIN
+		segmenter.stopWords = language.SENTENCE_STOP_WORDS_FRENCH
+	case language.German:
+		segmenter.punctuation = language.SENTENCE_PUNCTUATION_LATIN
+		segmenter.stopWords = language.SENTENCE_STOP_WORDS_GERMAN
+	case language.Italian:
+		segmenter.punctuation = language.SENTENCE_PUNCTUATION_LATIN
+		segmenter.stopWords = language.SENTENCE_STOP_WORDS_ITALIAN
+	case language.Portuguese:
+		segmenter.punctuation = language.SENTENCE_PUNCTUATION_LATIN
+		segmenter.stopWords = language.SENTENCE_STOP_WORDS_PORTUGUESE
+	case language.Dutch:
+		segmenter.punctuation = language.SENTENCE_PUNCTUATION_LATIN
+		segmenter.stopWords = language.SENTENCE_STOP_WORDS_DUTCH
 	}
 
 	// Init WhitespaceTokenizer
@@ -80,6 +101,42 @@ func (s *SentenceSegmenter) Tokenize(chunk string) (sentences []string, alwaysNi
 	return sentences, nil
 }
 
+// Returns the sentences in the text chunk with the [token.Span] of each
+// sentence in the chunk, from the start of its first word to the end of its
+// last word. As with [SentenceSegmenter.Tokenize] the words in each sentence
+// token are joined with a single space. ALWAYS returns nil for the error.
+func (s *SentenceSegmenter) TokenizeSpans(chunk string) (sentences tokenlist.TokenList, alwaysNil error) {
+	var (
+		words      []string
+		start, end int
+	)
+
+	counter := newSpanCounter(chunk)
+	sentences = make(tokenlist.TokenList, 0)
+	for _, loc := range fieldIndices(chunk) {
+		// Note the start of a new sentence
+		word := chunk[loc[0]:loc[1]]
+		if len(words) == 0 {
+			start = loc[0]
+		}
+		words = append(words, word)
+		end = loc[1]
+
+		// Append the sentence if this word ends it
+		if endsSentence(word, s.punctuation, s.stopWords) {
+			sentences = append(sentences, token.NewWithSpan(strings.Join(words, " "), counter.span(start, end)))
+			words = words[:0]
+		}
+	}
+
+	// Append the last sentence
+	if len(words) > 0 {
+		sentences = append(sentences, token.NewWithSpan(strings.Join(words, " "), counter.span(start, end)))
+	}
+
+	return sentences, nil
+}
+
 // ############################################################################
 // Helpers
 // ############################################################################
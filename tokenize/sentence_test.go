@@ -42,3 +42,41 @@ func TestSentenceSegmenter(t *testing.T) {
 	require.Equal(t, expected, sentences)
 
 }
+
+func TestSentenceSegmenterLanguages(t *testing.T) {
+	testcases := []struct {
+		Language language.Language
+		Text     string
+		Expected []string
+	}{
+		{
+			Language: language.Spanish,
+			Text:     "El Sr. García llegó tarde. ¿Dónde estabas? ¡Qué sorpresa! Hasta mañana",
+			Expected: []string{"El Sr. García llegó tarde.", "¿Dónde estabas?", "¡Qué sorpresa!", "Hasta mañana"},
+		},
+		{
+			Language: language.French,
+			Text:     "M. Dupont est arrivé. Où est la gare ? Merci",
+			Expected: []string{"M. Dupont est arrivé.", "Où est la gare ?", "Merci"},
+		},
+		{
+			Language: language.German,
+			Text:     "Hr. Müller wohnt in der Goethe Str. 5, bzw. nebenan. Wo ist der Bahnhof? Danke",
+			Expected: []string{"Hr. Müller wohnt in der Goethe Str. 5, bzw. nebenan.", "Wo ist der Bahnhof?", "Danke"},
+		},
+		{
+			Language: language.Dutch,
+			Text:     "Dhr. de Vries is er. Waar is het station? Dank je",
+			Expected: []string{"Dhr. de Vries is er.", "Waar is het station?", "Dank je"},
+		},
+	}
+
+	for _, tc := range testcases {
+		segmenter := tokenize.NewSentenceSegmenter(tokenize.SentenceSegmenterWithLanguage(tc.Language))
+		require.Equal(t, tc.Language, segmenter.Language())
+
+		sentences, err := segmenter.TokenizeSpans(tc.Text)
+		require.NoError(t, err)
+		require.Equal(t, tc.Expected, sentences.Strings(), tc.Text)
+	}
+}
@@ -28,23 +28,66 @@ var _ Tokenizer = &SSPSyllableTokenizer{}
 // provided. If the language is unsupported, it will return
 // [errors.ErrLanguageNotSupported].
 func NewSSPSyllableTokenizer(lang language.Language) (*SSPSyllableTokenizer, error) {
-	// NOTE: we only need the lower-case OR the upper-case runes to be added
-	// to the `runeScoreMap` and `vowels` fields, and the implementation will
-	// check for both upper and lower cases in the given runes.
-	switch lang {
-	case language.English:
-		return &SSPSyllableTokenizer{
-			lang: lang,
-			runeScoreMap: mapRuneScores(map[int8][]rune{
-				3: []rune("aeiouy"),      // vowels
-				2: []rune("lmnrw"),       // nasals
-				1: []rune("zvsf"),        // fricatives
-				0: []rune("bcdgtkpqxhj"), // stops
-			}),
-			vowels: "aeiouy",
-		}, nil
+	hierarchy, ok := sonorityHierarchies[lang]
+	if !ok {
+		return nil, errors.ErrLanguageNotSupported
 	}
-	return nil, errors.ErrLanguageNotSupported
+	return &SSPSyllableTokenizer{
+		lang:         lang,
+		runeScoreMap: mapRuneScores(hierarchy),
+		vowels:       string(hierarchy[3]),
+	}, nil
+}
+
+// The sonority hierarchy of the letters of each supported language, from the
+// vowels (3) to the stops (0).
+//
+// NOTE: we only need the lower-case OR the upper-case runes to be added to the
+// hierarchies, and the implementation will check for both upper and lower
+// cases in the given runes.
+var sonorityHierarchies = map[language.Language]map[int8][]rune{
+	language.English: {
+		3: []rune("aeiouy"),      // vowels
+		2: []rune("lmnrw"),       // nasals
+		1: []rune("zvsf"),        // fricatives
+		0: []rune("bcdgtkpqxhj"), // stops
+	},
+	language.Spanish: {
+		3: []rune("aeiouáéíóúü"),
+		2: []rune("lmnñrwy"),
+		1: []rune("zvsfj"),
+		0: []rune("bcdgtkpqxh"),
+	},
+	language.French: {
+		3: []rune("aeiouyàâæéèêëîïôœùûüÿ"),
+		2: []rune("lmnrw"),
+		1: []rune("zvsfçj"),
+		0: []rune("bcdgtkpqxh"),
+	},
+	language.German: {
+		3: []rune("aeiouyäöü"),
+		2: []rune("lmnrj"),
+		1: []rune("zvsfwß"),
+		0: []rune("bcdgtkpqxh"),
+	},
+	language.Italian: {
+		3: []rune("aeiouàèéìíîòóù"),
+		2: []rune("lmnrwyj"),
+		1: []rune("zvsf"),
+		0: []rune("bcdgtkpqxh"),
+	},
+	language.Portuguese: {
+		3: []rune("aeiouáâãàéêíóôõúü"),
+		2: []rune("lmnrwy"),
+		1: []rune("zvsfçj"),
+		0: []rune("bcdgtkpqxh"),
+	},
+	language.Dutch: {
+		3: []rune("aeiouyáéíóúäëïöü"),
+		2: []rune("lmnrwj"),
+		1: []rune("zvsf"),
+		0: []rune("bcdgtkpqxh"),
+	},
 }
 
 // Returns word syllables. ALWAYS returns nil for the error.
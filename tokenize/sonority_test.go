@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
 	"go.rtnl.ai/nlp/language"
 	"go.rtnl.ai/nlp/tokenize"
 )
@@ -193,3 +194,39 @@ func TestSSPSyllableTokenizerCountsEnglish(t *testing.T) {
 		require.Equalf(t, tc.Syllables, len(tokens), "Expected %d tokens for '%s', got %d: %s", tc.Syllables, tc.Word, len(tokens), tokens)
 	}
 }
+
+func TestSSPSyllableTokenizerLanguages(t *testing.T) {
+	testcases := []struct {
+		Language  language.Language
+		Word      string
+		Syllables []string
+	}{
+		{language.Spanish, "mañana", []string{"ma", "ña", "na"}},
+		{language.Spanish, "información", []string{"in", "for", "ma", "ción"}},
+		{language.French, "garçon", []string{"gar", "çon"}},
+		{language.French, "fenêtre", []string{"fe", "nê", "tre"}},
+		{language.German, "Hündin", []string{"Hün", "din"}},
+		{language.German, "Bahnhof", []string{"Bahn", "hof"}},
+		{language.Italian, "mattina", []string{"mat", "ti", "na"}},
+		{language.Italian, "caffè", []string{"caf", "fè"}},
+		{language.Portuguese, "coração", []string{"co", "ra", "ção"}},
+		{language.Portuguese, "obrigado", []string{"o", "bri", "ga", "do"}},
+		{language.Dutch, "vergadering", []string{"ver", "ga", "de", "ring"}},
+		{language.Dutch, "zomer", []string{"zo", "mer"}},
+	}
+
+	for _, tc := range testcases {
+		tokenizer, err := tokenize.NewSSPSyllableTokenizer(tc.Language)
+		require.NoError(t, err)
+
+		syllables, err := tokenizer.Tokenize(tc.Word)
+		require.NoError(t, err)
+		require.Equal(t, tc.Syllables, syllables, tc.Word)
+	}
+
+	t.Run("ErrorLanguage", func(t *testing.T) {
+		tokenizer, err := tokenize.NewSSPSyllableTokenizer(language.Unknown)
+		require.ErrorIs(t, err, errors.ErrLanguageNotSupported)
+		require.Nil(t, tokenizer)
+	})
+}
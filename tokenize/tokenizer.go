@@ -1,5 +1,12 @@
 package tokenize
 
+import (
+	"unicode/utf8"
+
+	"go.rtnl.ai/nlp/token"
+	"go.rtnl.ai/nlp/tokenlist"
+)
+
 // ############################################################################
 // Tokenizer interface
 // ############################################################################
@@ -7,3 +14,52 @@ package tokenize
 type Tokenizer interface {
 	Tokenize(chunk string) (tokens []string, err error)
 }
+
+// SpanTokenizer is a [Tokenizer] which can also return the location of each
+// token in the chunk as a [token.Span], e.g. to highlight the tokens in the
+// source text.
+type SpanTokenizer interface {
+	Tokenizer
+
+	// Returns the tokens in the chunk, each with the [token.Span] of its
+	// source text in the chunk.
+	TokenizeSpans(chunk string) (tokens tokenlist.TokenList, err error)
+}
+
+// ############################################################################
+// Span Helpers
+// ############################################################################
+
+// Converts byte offsets in a chunk to [token.Span]s, counting the runes before
+// each offset. Offsets must be passed in increasing order so the chunk is only
+// scanned once.
+type spanCounter struct {
+	chunk string
+	bytes int // the byte offset counted up to
+	runes int // the number of runes before the byte offset
+}
+
+// Returns a new [spanCounter] for the chunk.
+func newSpanCounter(chunk string) *spanCounter {
+	return &spanCounter{chunk: chunk}
+}
+
+// Returns the [token.Span] for the bytes chunk[start:end].
+func (c *spanCounter) span(start, end int) token.Span {
+	span := token.Span{Start: start, End: end}
+	span.RuneStart = c.count(start)
+	span.RuneEnd = c.count(end)
+	return span
+}
+
+// Returns the number of runes before the byte offset.
+func (c *spanCounter) count(offset int) int {
+	c.runes += utf8.RuneCountInString(c.chunk[c.bytes:offset])
+	c.bytes = offset
+	return c.runes
+}
+
+// Returns a [token.Token] for the bytes chunk[start:end] with its span.
+func (c *spanCounter) token(start, end int) token.Token {
+	return token.NewWithSpan(c.chunk[start:end], c.span(start, end))
+}
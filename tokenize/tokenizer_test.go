@@ -0,0 +1,81 @@
+package tokenize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/token"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+func TestSpanTokenizers(t *testing.T) {
+	chunk := "Ünïcode  text—is fün. Isn't it?\n The end"
+
+	testcases := []struct {
+		Name      string
+		Tokenizer tokenize.SpanTokenizer
+	}{
+		{"RegexTokenizer", tokenize.NewRegexTokenizer()},
+		{"RegexTokenizerWhitespace", tokenize.NewRegexTokenizer(tokenize.RegexTokenizerWithRegex(tokenize.REGEX_WHITESPACE))},
+		{"WhitespaceTokenizer", tokenize.NewWhitespaceTokenizer()},
+		{"UnicodeWordTokenizer", tokenize.NewUnicodeWordTokenizer()},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// The span tokens are the same as the string tokens
+			expected, err := tc.Tokenizer.Tokenize(chunk)
+			require.NoError(t, err)
+			tokens, err := tc.Tokenizer.TokenizeSpans(chunk)
+			require.NoError(t, err)
+			require.Equal(t, expected, tokens.Strings())
+
+			// The spans locate each token in the chunk in bytes and runes
+			spans, ok := tokens.Spans()
+			require.True(t, ok)
+			runes := []rune(chunk)
+			for i, span := range spans {
+				require.Equal(t, expected[i], chunk[span.Start:span.End])
+				require.Equal(t, expected[i], string(runes[span.RuneStart:span.RuneEnd]))
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		for _, tc := range testcases {
+			tokens, err := tc.Tokenizer.TokenizeSpans("")
+			require.NoError(t, err)
+			require.Empty(t, tokens)
+		}
+	})
+}
+
+func TestWhitespaceTokenizerSpans(t *testing.T) {
+	tokens, err := tokenize.NewWhitespaceTokenizer().TokenizeSpans(" naïve\tcafé ")
+	require.NoError(t, err)
+	require.Equal(t, []string{"naïve", "café"}, tokens.Strings())
+
+	spans, ok := tokens.Spans()
+	require.True(t, ok)
+	require.Equal(t, []token.Span{
+		{Start: 1, End: 7, RuneStart: 1, RuneEnd: 6},
+		{Start: 8, End: 13, RuneStart: 7, RuneEnd: 11},
+	}, spans)
+}
+
+func TestSentenceSegmenterSpans(t *testing.T) {
+	chunk := "  Hello  wörld. Mr. Fox\nran!  The end  "
+	tokens, err := tokenize.NewSentenceSegmenter().TokenizeSpans(chunk)
+	require.NoError(t, err)
+
+	// Sentences are joined with single spaces but span the source text
+	require.Equal(t, []string{"Hello wörld.", "Mr. Fox ran!", "The end"}, tokens.Strings())
+	spans, ok := tokens.Spans()
+	require.True(t, ok)
+	require.Equal(t, []token.Span{
+		{Start: 2, End: 16, RuneStart: 2, RuneEnd: 15},
+		{Start: 17, End: 29, RuneStart: 16, RuneEnd: 28},
+		{Start: 31, End: 38, RuneStart: 30, RuneEnd: 37},
+	}, spans)
+	require.Equal(t, "Mr. Fox\nran!", chunk[spans[1].Start:spans[1].End])
+}
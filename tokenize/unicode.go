@@ -0,0 +1,181 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/tokenlist"
+)
+
+// ############################################################################
+// UnicodeWordTokenizer
+// ############################################################################
+
+// Ensure [UnicodeWordTokenizer] meets the [Tokenizer] and [SpanTokenizer]
+// interface requirements.
+var _ Tokenizer = &UnicodeWordTokenizer{}
+var _ SpanTokenizer = &UnicodeWordTokenizer{}
+
+// UnicodeWordTokenizer tokenizes text into words at the word boundaries
+// defined by the Unicode Text Segmentation standard (UAX #29), which works for
+// any language which separates words with spaces or punctuation; create with
+// [NewUnicodeWordTokenizer]. Whitespace and punctuation are not returned as
+// tokens.
+type UnicodeWordTokenizer struct {
+	contractions bool
+	hyphenated   bool
+	decimals     bool
+	emoji        bool
+}
+
+// Returns a new [UnicodeWordTokenizer] instance.
+//
+// Defaults:
+//   - Contractions: true (e.g. "can't" is one token)
+//   - Hyphenated words: false (e.g. "ice-nine" is two tokens)
+//   - Decimal numbers: true (e.g. "3.14" and "1,000" are one token)
+//   - Emoji: true (emoji are tokens)
+func NewUnicodeWordTokenizer(opts ...UnicodeWordTokenizerOption) *UnicodeWordTokenizer {
+	// Set defaults
+	tokenizer := &UnicodeWordTokenizer{
+		contractions: true,
+		decimals:     true,
+		emoji:        true,
+	}
+
+	// Set options
+	for _, fn := range opts {
+		fn(tokenizer)
+	}
+
+	return tokenizer
+}
+
+// Returns true if the [UnicodeWordTokenizer] keeps contractions such as "can't"
+// as a single token.
+func (t *UnicodeWordTokenizer) Contractions() bool {
+	return t.contractions
+}
+
+// Returns true if the [UnicodeWordTokenizer] keeps hyphenated words such as
+// "ice-nine" as a single token.
+func (t *UnicodeWordTokenizer) HyphenatedWords() bool {
+	return t.hyphenated
+}
+
+// Returns true if the [UnicodeWordTokenizer] keeps decimal numbers such as
+// "3.14" or "1,000" as a single token.
+func (t *UnicodeWordTokenizer) DecimalNumbers() bool {
+	return t.decimals
+}
+
+// Returns true if the [UnicodeWordTokenizer] returns emoji as tokens.
+func (t *UnicodeWordTokenizer) Emoji() bool {
+	return t.emoji
+}
+
+// Tokenize the chunk of text into words using the UAX #29 word boundaries (see
+// [SegmentWords]), keeping the segments which contain a letter or number and,
+// if configured, emoji. ALWAYS returns nil for the error.
+//
+// Example:
+//
+//	"The quick (“brown”) fox can’t jump 32.3 feet, right? 👍"
+//
+// Tokens:
+//
+//	{"The", "quick", "brown", "fox", "can’t", "jump", "32.3", "feet", "right",
+//	"👍"}
+func (t *UnicodeWordTokenizer) Tokenize(chunk string) (tokens []string, alwaysNil error) {
+	tokens = make([]string, 0)
+	for _, loc := range t.tokenIndices(chunk) {
+		tokens = append(tokens, chunk[loc[0]:loc[1]])
+	}
+	return tokens, nil
+}
+
+// Tokenize the chunk of text into words like [UnicodeWordTokenizer.Tokenize],
+// returning the tokens with their [token.Span]s in the chunk. ALWAYS returns
+// nil for the error.
+func (t *UnicodeWordTokenizer) TokenizeSpans(chunk string) (tokens tokenlist.TokenList, alwaysNil error) {
+	counter := newSpanCounter(chunk)
+	tokens = make(tokenlist.TokenList, 0)
+	for _, loc := range t.tokenIndices(chunk) {
+		tokens = append(tokens, counter.token(loc[0], loc[1]))
+	}
+	return tokens, nil
+}
+
+// Returns the start and end byte offsets of the tokens in the chunk.
+func (t *UnicodeWordTokenizer) tokenIndices(chunk string) (indices [][2]int) {
+	tailoring := wordBreakTailoring{
+		splitApostrophes: !t.contractions,
+		splitDecimals:    !t.decimals,
+		joinHyphens:      t.hyphenated,
+	}
+
+	bounds := wordBoundaries(chunk, tailoring)
+	for i := 1; i < len(bounds); i++ {
+		segment := chunk[bounds[i-1]:bounds[i]]
+		if isWordSegment(segment) || (t.emoji && isEmojiSegment(segment)) {
+			indices = append(indices, [2]int{bounds[i-1], bounds[i]})
+		}
+	}
+	return indices
+}
+
+// Returns true if the segment contains a letter or a number.
+func isWordSegment(segment string) bool {
+	return strings.IndexFunc(segment, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}) >= 0
+}
+
+// Returns true if the segment contains an emoji or a flag.
+func isEmojiSegment(segment string) bool {
+	return strings.IndexFunc(segment, func(r rune) bool {
+		return isExtendedPictographic(r) || wordBreakProperty(r) == wbRegionalIndicator
+	}) >= 0
+}
+
+// ############################################################################
+// UnicodeWordTokenizerOption
+// ############################################################################
+
+// UnicodeWordTokenizerOption functions modify a [UnicodeWordTokenizer].
+type UnicodeWordTokenizerOption func(t *UnicodeWordTokenizer)
+
+// Returns a function which sets whether the [UnicodeWordTokenizer] keeps
+// contractions such as "can't" as a single token (true) or splits them at the
+// apostrophe (false).
+func UnicodeWordTokenizerWithContractions(contractions bool) UnicodeWordTokenizerOption {
+	return func(t *UnicodeWordTokenizer) {
+		t.contractions = contractions
+	}
+}
+
+// Returns a function which sets whether the [UnicodeWordTokenizer] keeps
+// hyphenated words such as "ice-nine" as a single token (true) or splits them
+// at the hyphen (false).
+func UnicodeWordTokenizerWithHyphenatedWords(hyphenated bool) UnicodeWordTokenizerOption {
+	return func(t *UnicodeWordTokenizer) {
+		t.hyphenated = hyphenated
+	}
+}
+
+// Returns a function which sets whether the [UnicodeWordTokenizer] keeps
+// decimal numbers such as "3.14" or "1,000" as a single token (true) or splits
+// them at the separator (false).
+func UnicodeWordTokenizerWithDecimalNumbers(decimals bool) UnicodeWordTokenizerOption {
+	return func(t *UnicodeWordTokenizer) {
+		t.decimals = decimals
+	}
+}
+
+// Returns a function which sets whether the [UnicodeWordTokenizer] returns
+// emoji as tokens (true) or drops them (false).
+func UnicodeWordTokenizerWithEmoji(emoji bool) UnicodeWordTokenizerOption {
+	return func(t *UnicodeWordTokenizer) {
+		t.emoji = emoji
+	}
+}
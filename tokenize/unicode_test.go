@@ -0,0 +1,197 @@
+package tokenize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+func TestSegmentWords(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Text     string
+		Expected []string
+	}{
+		{
+			Name: "QuickBrownFox",
+			Text: "The quick (“brown”) fox can’t jump 32.3 feet, right?",
+			Expected: []string{
+				"The", " ", "quick", " ", "(", "“", "brown", "”", ")", " ", "fox", " ",
+				"can’t", " ", "jump", " ", "32.3", " ", "feet", ",", " ", "right", "?",
+			},
+		},
+		{
+			Name:     "Empty",
+			Text:     "",
+			Expected: nil,
+		},
+		{
+			Name:     "Whitespace",
+			Text:     "a  b\r\nc",
+			Expected: []string{"a", "  ", "b", "\r\n", "c"},
+		},
+		{
+			Name:     "MidLetter",
+			Text:     "e.g. ab:cd",
+			Expected: []string{"e.g", ".", " ", "ab:cd"},
+		},
+		{
+			Name:     "Underscore",
+			Text:     "snake_case_2",
+			Expected: []string{"snake_case_2"},
+		},
+		{
+			Name:     "Ideographs",
+			Text:     "我爱你",
+			Expected: []string{"我", "爱", "你"},
+		},
+		{
+			Name:     "Katakana",
+			Text:     "カタカナ",
+			Expected: []string{"カタカナ"},
+		},
+		{
+			Name:     "Hebrew",
+			Text:     "צה\"ל",
+			Expected: []string{"צה\"ל"},
+		},
+		{
+			Name:     "Flags",
+			Text:     "🇺🇸🇫🇷🇩",
+			Expected: []string{"🇺🇸", "🇫🇷", "🇩"},
+		},
+		{
+			Name:     "Emoji",
+			Text:     "👍🏽👨‍👩‍👧👍",
+			Expected: []string{"👍🏽", "👨‍👩‍👧", "👍"},
+		},
+		{
+			Name:     "CombiningMarks",
+			Text:     "naïve café",
+			Expected: []string{"naïve", " ", "café"},
+		},
+		{
+			Name:     "InvalidUTF8",
+			Text:     "ab\xffcd",
+			Expected: []string{"ab", "\xff", "cd"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := tokenize.SegmentWords(tc.Text)
+			require.Equal(t, tc.Expected, actual)
+			require.Equal(t, tc.Text, strings.Join(actual, ""))
+		})
+	}
+}
+
+func TestNewUnicodeWordTokenizer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		tok := tokenize.NewUnicodeWordTokenizer()
+		require.NotNil(t, tok)
+		require.True(t, tok.Contractions())
+		require.False(t, tok.HyphenatedWords())
+		require.True(t, tok.DecimalNumbers())
+		require.True(t, tok.Emoji())
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		tok := tokenize.NewUnicodeWordTokenizer(
+			tokenize.UnicodeWordTokenizerWithContractions(false),
+			tokenize.UnicodeWordTokenizerWithHyphenatedWords(true),
+			tokenize.UnicodeWordTokenizerWithDecimalNumbers(false),
+			tokenize.UnicodeWordTokenizerWithEmoji(false),
+		)
+		require.NotNil(t, tok)
+		require.False(t, tok.Contractions())
+		require.True(t, tok.HyphenatedWords())
+		require.False(t, tok.DecimalNumbers())
+		require.False(t, tok.Emoji())
+	})
+}
+
+func TestUnicodeWordTokenizer(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Text     string
+		Options  []tokenize.UnicodeWordTokenizerOption
+		Expected []string
+	}{
+		{
+			Name:     "QuickBrownFox",
+			Text:     "The quick (“brown”) fox can’t jump 32.3 feet, right? 👍",
+			Expected: []string{"The", "quick", "brown", "fox", "can’t", "jump", "32.3", "feet", "right", "👍"},
+		},
+		{
+			Name:     "Empty",
+			Text:     "",
+			Expected: []string{},
+		},
+		{
+			Name:     "Contractions",
+			Text:     "don't won’t",
+			Expected: []string{"don't", "won’t"},
+		},
+		{
+			Name:     "SplitContractions",
+			Text:     "don't won’t 'quoted'",
+			Options:  []tokenize.UnicodeWordTokenizerOption{tokenize.UnicodeWordTokenizerWithContractions(false)},
+			Expected: []string{"don", "t", "won", "t", "quoted"},
+		},
+		{
+			Name:     "HyphenatedSplit",
+			Text:     "ice-nine is a well-known 24-7 thing--or not",
+			Expected: []string{"ice", "nine", "is", "a", "well", "known", "24", "7", "thing", "or", "not"},
+		},
+		{
+			Name:     "HyphenatedJoined",
+			Text:     "ice-nine is a well-known 24-7 thing--or not -",
+			Options:  []tokenize.UnicodeWordTokenizerOption{tokenize.UnicodeWordTokenizerWithHyphenatedWords(true)},
+			Expected: []string{"ice-nine", "is", "a", "well-known", "24-7", "thing", "or", "not"},
+		},
+		{
+			Name:     "DecimalNumbers",
+			Text:     "3.14 and 1,000,000 or 1.",
+			Expected: []string{"3.14", "and", "1,000,000", "or", "1"},
+		},
+		{
+			Name:     "SplitDecimalNumbers",
+			Text:     "3.14 and 1,000",
+			Options:  []tokenize.UnicodeWordTokenizerOption{tokenize.UnicodeWordTokenizerWithDecimalNumbers(false)},
+			Expected: []string{"3", "14", "and", "1", "000"},
+		},
+		{
+			Name:     "Accents",
+			Text:     "Naïve café résumé, Straße!",
+			Expected: []string{"Naïve", "café", "résumé", "Straße"},
+		},
+		{
+			Name:     "Emoji",
+			Text:     "I 👍🏽 the 👨‍👩‍👧 in 🇺🇸!",
+			Expected: []string{"I", "👍🏽", "the", "👨‍👩‍👧", "in", "🇺🇸"},
+		},
+		{
+			Name:     "NoEmoji",
+			Text:     "I 👍🏽 the 👨‍👩‍👧 in 🇺🇸!",
+			Options:  []tokenize.UnicodeWordTokenizerOption{tokenize.UnicodeWordTokenizerWithEmoji(false)},
+			Expected: []string{"I", "the", "in"},
+		},
+		{
+			Name:     "Multilingual",
+			Text:     "Привет, мир! Γειά σου κόσμε. 東京タワー",
+			Expected: []string{"Привет", "мир", "Γειά", "σου", "κόσμε", "東", "京", "タワー"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tok := tokenize.NewUnicodeWordTokenizer(tc.Options...)
+			actual, err := tok.Tokenize(tc.Text)
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, actual)
+		})
+	}
+}
@@ -2,12 +2,17 @@ package tokenize
 
 import (
 	"strings"
+	"unicode"
+
+	"go.rtnl.ai/nlp/tokenlist"
 )
 
 type WhitespaceTokenizer struct{}
 
-// Ensure [WhitespaceTokenizer] meets the [Tokenizer] interface requirements.
+// Ensure [WhitespaceTokenizer] meets the [Tokenizer] and [SpanTokenizer]
+// interface requirements.
 var _ Tokenizer = &WhitespaceTokenizer{}
+var _ SpanTokenizer = &WhitespaceTokenizer{}
 
 // Returns a new [WhitespaceTokenizer].
 func NewWhitespaceTokenizer() *WhitespaceTokenizer {
@@ -31,3 +36,35 @@ func NewWhitespaceTokenizer() *WhitespaceTokenizer {
 func (t *WhitespaceTokenizer) Tokenize(chunk string) (tokens []string, alwaysNil error) {
 	return strings.Fields(chunk), nil
 }
+
+// Tokenize the chunk of text using whitespace like [WhitespaceTokenizer.Tokenize],
+// returning the tokens with their [token.Span]s in the chunk. ALWAYS returns nil
+// for the error.
+func (t *WhitespaceTokenizer) TokenizeSpans(chunk string) (tokens tokenlist.TokenList, alwaysNil error) {
+	counter := newSpanCounter(chunk)
+	tokens = make(tokenlist.TokenList, 0)
+	for _, loc := range fieldIndices(chunk) {
+		tokens = append(tokens, counter.token(loc[0], loc[1]))
+	}
+	return tokens, nil
+}
+
+// Returns the start and end byte offsets of the fields that [strings.Fields]
+// would return for the chunk.
+func fieldIndices(chunk string) (indices [][2]int) {
+	start := -1
+	for i, r := range chunk {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				indices = append(indices, [2]int{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		indices = append(indices, [2]int{start, len(chunk)})
+	}
+	return indices
+}
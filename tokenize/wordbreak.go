@@ -0,0 +1,418 @@
+package tokenize
+
+import (
+	"unicode"
+)
+
+// ############################################################################
+// Word Break Property
+// ############################################################################
+
+// The Word_Break property values from Unicode Standard Annex #29 (UAX #29).
+type wordBreak uint8
+
+const (
+	wbOther wordBreak = iota
+	wbCR
+	wbLF
+	wbNewline
+	wbExtend
+	wbZWJ
+	wbRegionalIndicator
+	wbFormat
+	wbKatakana
+	wbHebrewLetter
+	wbALetter
+	wbSingleQuote
+	wbDoubleQuote
+	wbMidNumLet
+	wbMidLetter
+	wbMidNum
+	wbNumeric
+	wbExtendNumLet
+	wbWSegSpace
+)
+
+// Scripts whose letters are not ALetter: ideographic and syllabic scripts where
+// each character is a word on its own, and scripts which need a dictionary to
+// find word boundaries (Line_Break=Complex_Context).
+var wbOtherScripts = []*unicode.RangeTable{
+	unicode.Han, unicode.Hiragana, unicode.Thai, unicode.Lao, unicode.Khmer,
+	unicode.Myanmar, unicode.Tai_Tham, unicode.Tai_Viet, unicode.New_Tai_Lue,
+	unicode.Tai_Le,
+}
+
+// Returns the Word_Break property of the rune. The property is derived from
+// the general categories and scripts in the [unicode] package plus the few
+// code points that UAX #29 lists explicitly, which closely approximates the
+// Unicode Character Database.
+func wordBreakProperty(r rune) wordBreak {
+	switch r {
+	case '\r':
+		return wbCR
+	case '\n':
+		return wbLF
+	case '\v', '\f', 0x85, 0x2028, 0x2029:
+		return wbNewline
+	case 0x200D:
+		return wbZWJ
+	case 0x200C:
+		return wbExtend
+	case '\'':
+		return wbSingleQuote
+	case '"':
+		return wbDoubleQuote
+	case '.', 0x2018, 0x2019, 0x2024, 0xFE52, 0xFF07, 0xFF0E:
+		return wbMidNumLet
+	case ':', 0xB7, 0x387, 0x55F, 0x5F4, 0x2027, 0xFE13, 0xFE55, 0xFF1A:
+		return wbMidLetter
+	case ',', ';', 0x37E, 0x589, 0x60C, 0x60D, 0x66C, 0x7F8, 0x2044, 0xFE10,
+		0xFE14, 0xFE50, 0xFE54, 0xFF0C, 0xFF1B:
+		return wbMidNum
+	case ' ', 0x1680, 0x205F, 0x3000:
+		return wbWSegSpace
+	case 0x202F:
+		return wbExtendNumLet
+	case 0x3031, 0x3032, 0x3033, 0x3034, 0x3035, 0x309B, 0x309C, 0x30A0, 0x30FC, 0xFF70:
+		return wbKatakana
+	case 0x66B:
+		return wbNumeric
+	}
+
+	switch {
+	case r >= 0x2000 && r <= 0x200A && r != 0x2007:
+		return wbWSegSpace
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return wbRegionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF: // emoji skin tone modifiers
+		return wbExtend
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return wbExtend
+	case unicode.Is(unicode.Cf, r):
+		if r == 0x200B { // ZERO WIDTH SPACE
+			return wbOther
+		}
+		return wbFormat
+	case unicode.Is(unicode.Katakana, r):
+		return wbKatakana
+	case unicode.Is(unicode.Hebrew, r) && unicode.IsLetter(r):
+		return wbHebrewLetter
+	case unicode.Is(unicode.Nd, r):
+		return wbNumeric
+	case unicode.Is(unicode.Pc, r):
+		return wbExtendNumLet
+	case unicode.IsLetter(r) || unicode.Is(unicode.Nl, r):
+		if unicode.In(r, wbOtherScripts...) {
+			return wbOther
+		}
+		return wbALetter
+	}
+	return wbOther
+}
+
+// Returns true if the rune is Extended_Pictographic (e.g. most emoji). This is
+// an approximation of the emoji data property by code point ranges.
+func isExtendedPictographic(r rune) bool {
+	switch r {
+	case 0xA9, 0xAE, 0x203C, 0x2049, 0x2122, 0x2139, 0x2328, 0x23CF, 0x24C2,
+		0x25B6, 0x25C0, 0x2B50, 0x2B55, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+	switch {
+	case r >= 0x2194 && r <= 0x2199,
+		r >= 0x21A9 && r <= 0x21AA,
+		r >= 0x231A && r <= 0x231B,
+		r >= 0x23E9 && r <= 0x23F3,
+		r >= 0x23F8 && r <= 0x23FA,
+		r >= 0x25AA && r <= 0x25AB,
+		r >= 0x25FB && r <= 0x25FE,
+		r >= 0x2600 && r <= 0x27BF,
+		r >= 0x2934 && r <= 0x2935,
+		r >= 0x2B05 && r <= 0x2B07,
+		r >= 0x2B1B && r <= 0x2B1C,
+		r >= 0x1F000 && r <= 0x1F0FF,
+		r >= 0x1F10D && r <= 0x1F10F,
+		r >= 0x1F12F && r <= 0x1F12F,
+		r >= 0x1F16C && r <= 0x1F171,
+		r >= 0x1F17E && r <= 0x1F17F,
+		r >= 0x1F18E && r <= 0x1F18E,
+		r >= 0x1F191 && r <= 0x1F19A,
+		r >= 0x1F1AD && r <= 0x1F1E5,
+		r >= 0x1F201 && r <= 0x1F3FA,
+		r >= 0x1F400 && r <= 0x1F53D,
+		r >= 0x1F546 && r <= 0x1F64F,
+		r >= 0x1F680 && r <= 0x1F6FF,
+		r >= 0x1F774 && r <= 0x1F77F,
+		r >= 0x1F7D5 && r <= 0x1F7FF,
+		r >= 0x1F80C && r <= 0x1F80F,
+		r >= 0x1F848 && r <= 0x1F84F,
+		r >= 0x1F85A && r <= 0x1F85F,
+		r >= 0x1F888 && r <= 0x1F88F,
+		r >= 0x1F8AE && r <= 0x1F8FF,
+		r >= 0x1F90C && r <= 0x1F93A,
+		r >= 0x1F93C && r <= 0x1F945,
+		r >= 0x1F947 && r <= 0x1FAFF,
+		r >= 0x1FC00 && r <= 0x1FFFD:
+		return true
+	}
+	return false
+}
+
+// ############################################################################
+// Word Segmentation
+// ############################################################################
+
+// Rule tailorings for [segmentWords]; the zero value follows UAX #29 exactly.
+type wordBreakTailoring struct {
+	splitApostrophes bool // break at apostrophes between letters (WB6, WB7)
+	splitDecimals    bool // break at separators between digits (WB11, WB12)
+	joinHyphens      bool // do not break at single hyphens between letters or digits
+}
+
+// SegmentWords splits the chunk at the word boundaries defined by the Unicode
+// Text Segmentation standard (UAX #29). Every rune is in exactly one segment,
+// so the segments include whitespace and punctuation and can be joined to
+// recreate the chunk; use [UnicodeWordTokenizer] to get only the words.
+//
+// Example:
+//
+//	"The quick (“brown”) fox can’t jump 32.3 feet, right?"
+//
+// Segments:
+//
+//	{"The", " ", "quick", " ", "(", "“", "brown", "”", ")", " ", "fox", " ",
+//	"can’t", " ", "jump", " ", "32.3", " ", "feet", ",", " ", "right", "?"}
+func SegmentWords(chunk string) (segments []string) {
+	return segmentWords(chunk, wordBreakTailoring{})
+}
+
+// Splits the chunk at word boundaries using the tailoring.
+func segmentWords(chunk string, tailoring wordBreakTailoring) (segments []string) {
+	bounds := wordBoundaries(chunk, tailoring)
+	for i := 1; i < len(bounds); i++ {
+		segments = append(segments, chunk[bounds[i-1]:bounds[i]])
+	}
+	return segments
+}
+
+// Returns the byte offsets of the word boundaries in the chunk using the
+// tailoring, including the start and end of the chunk unless it is empty.
+func wordBoundaries(chunk string, tailoring wordBreakTailoring) (bounds []int) {
+	if chunk == "" {
+		return nil
+	}
+
+	// Decode the runes, keeping their byte offsets to find the boundaries
+	var (
+		runes   []rune
+		offsets []int
+	)
+	for offset, r := range chunk {
+		runes = append(runes, r)
+		offsets = append(offsets, offset)
+	}
+
+	props := make([]wordBreak, len(runes))
+	for i, r := range runes {
+		props[i] = wordBreakProperty(r)
+	}
+
+	bounds = append(bounds, 0)
+	for i := 1; i < len(runes); i++ {
+		if isWordBoundary(runes, props, i, tailoring) {
+			bounds = append(bounds, offsets[i])
+		}
+	}
+	return append(bounds, len(chunk))
+}
+
+// Returns true if the word break property is ignored by rule WB4.
+func wbIgnored(p wordBreak) bool {
+	return p == wbExtend || p == wbFormat || p == wbZWJ
+}
+
+// Returns true if the word break property is a letter (AHLetter).
+func wbAHLetter(p wordBreak) bool {
+	return p == wbALetter || p == wbHebrewLetter
+}
+
+// Returns true if the word break property is a mid-word separator
+// (MidNumLetQ).
+func wbMidNumLetQ(p wordBreak) bool {
+	return p == wbMidNumLet || p == wbSingleQuote
+}
+
+// Returns true if the rune is a hyphen joined by [wordBreakTailoring].
+func isHyphen(r rune) bool {
+	return r == '-' || r == 0x2010 || r == 0x2011
+}
+
+// Returns true if there is a word boundary before the rune at index i (which is
+// never 0), applying the UAX #29 rules in order.
+func isWordBoundary(runes []rune, props []wordBreak, i int, tailoring wordBreakTailoring) bool {
+	prev, curr := props[i-1], props[i]
+
+	// WB3: CR × LF
+	if prev == wbCR && curr == wbLF {
+		return false
+	}
+
+	// WB3a and WB3b: break after and before newlines
+	if prev == wbCR || prev == wbLF || prev == wbNewline || curr == wbCR || curr == wbLF || curr == wbNewline {
+		return true
+	}
+
+	// WB3c: ZWJ × \p{Extended_Pictographic}
+	if prev == wbZWJ && isExtendedPictographic(runes[i]) {
+		return false
+	}
+
+	// WB3d: WSegSpace × WSegSpace
+	if prev == wbWSegSpace && curr == wbWSegSpace {
+		return false
+	}
+
+	// WB4: X (Extend | Format | ZWJ)* → X
+	if wbIgnored(curr) {
+		return false
+	}
+
+	// Find the properties around the boundary, skipping ignored runes: ll l | r rr
+	li := skipBack(props, i-1)
+	l := props[li]
+	ll := wbOther
+	if lli := skipBack(props, li-1); lli >= 0 {
+		ll = props[lli]
+	}
+	r := curr
+	rr := wbOther
+	if rri := skipForward(props, i+1); rri < len(props) {
+		rr = props[rri]
+	}
+
+	// The runes on either side of the boundary, for the tailorings
+	lRune, rRune := runes[li], runes[i]
+
+	// WB5: AHLetter × AHLetter
+	if wbAHLetter(l) && wbAHLetter(r) {
+		return false
+	}
+
+	// Tailoring: join words and numbers on either side of a single hyphen
+	if tailoring.joinHyphens {
+		word := func(p wordBreak) bool { return wbAHLetter(p) || p == wbNumeric }
+		if word(l) && isHyphen(rRune) && word(rr) {
+			return false
+		}
+		if word(ll) && isHyphen(lRune) && word(r) {
+			return false
+		}
+	}
+
+	// Apostrophes which may be split by the tailoring
+	apostrophe := func(ch rune) bool { return ch == '\'' || ch == 0x2019 }
+
+	// WB6: AHLetter × (MidLetter | MidNumLetQ) AHLetter
+	if wbAHLetter(l) && (r == wbMidLetter || wbMidNumLetQ(r)) && wbAHLetter(rr) {
+		if !(tailoring.splitApostrophes && apostrophe(rRune)) {
+			return false
+		}
+	}
+
+	// WB7: AHLetter (MidLetter | MidNumLetQ) × AHLetter
+	if wbAHLetter(ll) && (l == wbMidLetter || wbMidNumLetQ(l)) && wbAHLetter(r) {
+		if !(tailoring.splitApostrophes && apostrophe(lRune)) {
+			return false
+		}
+	}
+
+	// WB7a: Hebrew_Letter × Single_Quote
+	if l == wbHebrewLetter && r == wbSingleQuote {
+		return false
+	}
+
+	// WB7b and WB7c: Hebrew_Letter × Double_Quote Hebrew_Letter, and
+	// Hebrew_Letter Double_Quote × Hebrew_Letter
+	if l == wbHebrewLetter && r == wbDoubleQuote && rr == wbHebrewLetter {
+		return false
+	}
+	if ll == wbHebrewLetter && l == wbDoubleQuote && r == wbHebrewLetter {
+		return false
+	}
+
+	// WB8, WB9, and WB10: digits and letters are not separated
+	if (l == wbNumeric || wbAHLetter(l)) && (r == wbNumeric || wbAHLetter(r)) {
+		return false
+	}
+
+	// WB11: Numeric (MidNum | MidNumLetQ) × Numeric
+	// WB12: Numeric × (MidNum | MidNumLetQ) Numeric
+	if !tailoring.splitDecimals {
+		if ll == wbNumeric && (l == wbMidNum || wbMidNumLetQ(l)) && r == wbNumeric {
+			return false
+		}
+		if l == wbNumeric && (r == wbMidNum || wbMidNumLetQ(r)) && rr == wbNumeric {
+			return false
+		}
+	}
+
+	// WB13: Katakana × Katakana
+	if l == wbKatakana && r == wbKatakana {
+		return false
+	}
+
+	// WB13a: (AHLetter | Numeric | Katakana | ExtendNumLet) × ExtendNumLet
+	if (wbAHLetter(l) || l == wbNumeric || l == wbKatakana || l == wbExtendNumLet) && r == wbExtendNumLet {
+		return false
+	}
+
+	// WB13b: ExtendNumLet × (AHLetter | Numeric | Katakana)
+	if l == wbExtendNumLet && (wbAHLetter(r) || r == wbNumeric || r == wbKatakana) {
+		return false
+	}
+
+	// WB15 and WB16: do not break within pairs of regional indicators (flags)
+	if l == wbRegionalIndicator && r == wbRegionalIndicator {
+		count := 0
+		for j := li; j >= 0; j = skipBack(props, j-1) {
+			if props[j] != wbRegionalIndicator {
+				break
+			}
+			count++
+		}
+		return count%2 == 0
+	}
+
+	// WB999: otherwise, break everywhere
+	return true
+}
+
+// Returns the index of the last rune at or before i which is not ignored by
+// WB4, or the index of the first rune if every rune is ignored; returns -1 if i
+// is negative.
+func skipBack(props []wordBreak, i int) int {
+	for j := i; j >= 0; j-- {
+		if !wbIgnored(props[j]) {
+			return j
+		}
+		// WB4 does not apply directly after a newline
+		if j > 0 && (props[j-1] == wbCR || props[j-1] == wbLF || props[j-1] == wbNewline) {
+			return j
+		}
+	}
+	if i >= 0 {
+		return 0
+	}
+	return -1
+}
+
+// Returns the index of the first rune at or after i which is not ignored by
+// WB4, or len(props) if there is none.
+func skipForward(props []wordBreak, i int) int {
+	for j := i; j < len(props); j++ {
+		if !wbIgnored(props[j]) {
+			return j
+		}
+	}
+	return len(props)
+}
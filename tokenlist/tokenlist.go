@@ -64,3 +64,17 @@ func (t TokenList) Strings() []string {
 	}
 	return s
 }
+
+// Returns the [token.Span]s of the tokens in the [TokenList] and true, or nil and
+// false if any of the tokens does not have a span.
+func (t TokenList) Spans() (spans []token.Span, ok bool) {
+	spans = make([]token.Span, 0, len(t))
+	for _, tok := range t {
+		var span token.Span
+		if span, ok = tok.Span(); !ok {
+			return nil, false
+		}
+		spans = append(spans, span)
+	}
+	return spans, true
+}
@@ -80,3 +80,29 @@ func TestNewDocs(t *testing.T) {
 	myTokens[0] = myTokens[1] // "bananna", "bananna", "zebra"
 	require.Equal(t, []string{"bananna", "bananna", "zebra", "apple"}, myTokens.Strings())
 }
+
+func TestSpans(t *testing.T) {
+	spans := []token.Span{
+		{Start: 0, End: 5, RuneStart: 0, RuneEnd: 5},
+		{Start: 6, End: 11, RuneStart: 6, RuneEnd: 10},
+	}
+	tl := tokenlist.TokenList{
+		token.NewWithSpan("apple", spans[0]),
+		token.NewWithSpan("bière", spans[1]),
+	}
+
+	actual, ok := tl.Spans()
+	require.True(t, ok)
+	require.Equal(t, spans, actual)
+
+	// Copies keep the spans
+	actual, ok = tokenlist.NewCopy(tl).Spans()
+	require.True(t, ok)
+	require.Equal(t, spans, actual)
+
+	// Any token without a span means there are no spans
+	tl = append(tl, token.New("zebra"))
+	actual, ok = tl.Spans()
+	require.False(t, ok)
+	require.Nil(t, actual)
+}
@@ -0,0 +1,358 @@
+// Package generic implements the vector math of the vector package for any
+// slice of float32 or float64 elements, such as the Vector and Vector32 types
+// of the vector package, which delegate to it. Scalar results, such as the
+// [Cosine], are calculated and returned as float64.
+package generic
+
+import (
+	"math"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/mathematics"
+)
+
+// Float is the constraint for the element types of vectors, so the vector math
+// functions can be used with both `[]float64` and the half-size `[]float32`
+// vectors (e.g. for storing large numbers of embeddings).
+type Float interface {
+	~float32 | ~float64
+}
+
+// Cosine returns the cosine of the angle between two vectors as a value between
+// [-1.0, 1.0], as defined by SLP 3rd Edition section 6.4 fig 6.10. If the
+// vectors do not have the same number of elements or either of the vectors has
+// a length of zero, an error will be returned.
+func Cosine[V ~[]F, F Float](a, b V) (cosine float64, err error) {
+	// Ensure vectors have the same number of elements
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+
+	// Calculate the dot product
+	var dotprod, vlenprod float64
+	if dotprod, err = DotProduct(a, b); err != nil {
+		return 0.0, err
+	}
+
+	// Calculate the product of the two vector's lengths
+	vlenprod = Magnitude(a) * Magnitude(b)
+	if vlenprod == 0.0 {
+		// Cosine is undefined for zero length vectors
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	// Return final cosine value clamped to [-1.0, 1.0]
+	return mathematics.BoundToRange(dotprod/vlenprod, -1.0, 1.0), nil
+}
+
+// DotProduct returns the dot product of the two vectors (as defined by SLP 3rd
+// Edition section 6.4 fig 6.7). If the vectors do not have the same number
+// of elements, an error will be returned.
+func DotProduct[V ~[]F, F Float](a, b V) (product float64, err error) {
+	// Ensure vectors have the same number of elements
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+
+	for i := range a {
+		product += float64(a[i]) * float64(b[i])
+	}
+	return product, nil
+}
+
+// Magnitude returns the vector length (aka magnitude) (as defined by SLP 3rd
+// Edition section 6.4 fig 6.8).
+func Magnitude[V ~[]F, F Float](v V) (length float64) {
+	for _, e := range v {
+		length += float64(e) * float64(e)
+	}
+	return math.Sqrt(length)
+}
+
+// Len returns the number of elements in the vector.
+func Len[V ~[]F, F Float](v V) (elements int) {
+	return len(v)
+}
+
+// ############################################################################
+// Element-wise Operations
+// ############################################################################
+
+// Add returns a new vector which is the element-wise sum of the two vectors.
+// If the vectors do not have the same number of elements, an error will be
+// returned.
+func Add[V ~[]F, F Float](a, b V) (sum V, err error) {
+	sum = Clone(a)
+	if err = AddInPlace(sum, b); err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+// AddInPlace adds each element of b to the element of a at the same index,
+// modifying a. If the vectors do not have the same number of elements, an error
+// will be returned and a is not modified.
+func AddInPlace[V ~[]F, F Float](a, b V) (err error) {
+	if len(a) != len(b) {
+		return errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		a[i] += b[i]
+	}
+	return nil
+}
+
+// Subtract returns a new vector which is the element-wise difference of the
+// two vectors, `a - b`. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Subtract[V ~[]F, F Float](a, b V) (difference V, err error) {
+	difference = Clone(a)
+	if err = SubtractInPlace(difference, b); err != nil {
+		return nil, err
+	}
+	return difference, nil
+}
+
+// SubtractInPlace subtracts each element of b from the element of a at the same
+// index, modifying a. If the vectors do not have the same number of elements,
+// an error will be returned and a is not modified.
+func SubtractInPlace[V ~[]F, F Float](a, b V) (err error) {
+	if len(a) != len(b) {
+		return errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		a[i] -= b[i]
+	}
+	return nil
+}
+
+// Multiply returns a new vector which is the element-wise (Hadamard) product
+// of the two vectors. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Multiply[V ~[]F, F Float](a, b V) (product V, err error) {
+	product = Clone(a)
+	if err = MultiplyInPlace(product, b); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// MultiplyInPlace multiplies each element of a by the element of b at the same
+// index, modifying a. If the vectors do not have the same number of elements,
+// an error will be returned and a is not modified.
+func MultiplyInPlace[V ~[]F, F Float](a, b V) (err error) {
+	if len(a) != len(b) {
+		return errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		a[i] *= b[i]
+	}
+	return nil
+}
+
+// Scale returns a new vector with each element multiplied by factor.
+func Scale[V ~[]F, F Float](v V, factor float64) (scaled V) {
+	scaled = Clone(v)
+	ScaleInPlace(scaled, factor)
+	return scaled
+}
+
+// ScaleInPlace multiplies each element of the vector by factor, modifying it.
+func ScaleInPlace[V ~[]F, F Float](v V, factor float64) {
+	for i := range v {
+		v[i] = F(float64(v[i]) * factor)
+	}
+}
+
+// Clone returns a copy of the vector which does not share its elements.
+func Clone[V ~[]F, F Float](v V) (clone V) {
+	if v == nil {
+		return nil
+	}
+	clone = make(V, len(v))
+	copy(clone, v)
+	return clone
+}
+
+// Mean returns the element-wise mean (the centroid) of the vectors. If no
+// vectors are given an [errors.ErrUndefinedValue] is returned, and if the
+// vectors do not all have the same number of elements an
+// [errors.ErrUnequalLengthVectors] is returned.
+func Mean[V ~[]F, F Float](vectors ...V) (mean V, err error) {
+	if len(vectors) == 0 {
+		return nil, errors.ErrUndefinedValue
+	}
+
+	mean = make(V, len(vectors[0]))
+	for _, v := range vectors {
+		if err = AddInPlace(mean, v); err != nil {
+			return nil, err
+		}
+	}
+	ScaleInPlace(mean, 1.0/float64(len(vectors)))
+	return mean, nil
+}
+
+// ############################################################################
+// Norms and Normalization
+// ############################################################################
+
+// Norm selects how the length of a vector is measured.
+type Norm uint8
+
+const (
+	NormUnknown Norm = iota
+	// The sum of the absolute values of the elements (taxicab length).
+	NormL1
+	// The square root of the sum of the squares of the elements (Euclidean
+	// length); see [Magnitude].
+	NormL2
+	// The largest absolute value of the elements (maximum or infinity norm).
+	NormMax
+)
+
+// Length returns the length of the vector measured with the [Norm]. Returns
+// [errors.ErrMethodNotSupported] for an unknown norm.
+func Length[V ~[]F, F Float](v V, norm Norm) (length float64, err error) {
+	switch norm {
+	case NormL1:
+		for _, e := range v {
+			length += math.Abs(float64(e))
+		}
+		return length, nil
+	case NormL2:
+		return Magnitude(v), nil
+	case NormMax:
+		for _, e := range v {
+			length = max(length, math.Abs(float64(e)))
+		}
+		return length, nil
+	}
+	return 0.0, errors.ErrMethodNotSupported
+}
+
+// Normalize returns a new vector with the same direction as the vector and a
+// length of one measured with the [Norm]. A vector with a length of zero is
+// returned unchanged. Returns [errors.ErrMethodNotSupported] for an unknown
+// norm.
+func Normalize[V ~[]F, F Float](v V, norm Norm) (normalized V, err error) {
+	normalized = Clone(v)
+	if err = NormalizeInPlace(normalized, norm); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// NormalizeInPlace divides each element of the vector by its length measured
+// with the [Norm], modifying it (see [Normalize]).
+func NormalizeInPlace[V ~[]F, F Float](v V, norm Norm) (err error) {
+	var length float64
+	if length, err = Length(v, norm); err != nil {
+		return err
+	}
+	if length != 0.0 {
+		for i := range v {
+			v[i] = F(float64(v[i]) / length)
+		}
+	}
+	return nil
+}
+
+// ############################################################################
+// Distance Metrics
+// ############################################################################
+
+// Euclidean returns the straight-line (L2) distance between two vectors,
+// `sqrt(sum((a_i - b_i)^2))`. If the vectors do not have the same number of
+// elements, an error will be returned.
+func Euclidean[V ~[]F, F Float](a, b V) (distance float64, err error) {
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		distance += d * d
+	}
+	return math.Sqrt(distance), nil
+}
+
+// Manhattan returns the taxicab (L1) distance between two vectors,
+// `sum(|a_i - b_i|)`. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Manhattan[V ~[]F, F Float](a, b V) (distance float64, err error) {
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		distance += math.Abs(float64(a[i]) - float64(b[i]))
+	}
+	return distance, nil
+}
+
+// Chebyshev returns the maximum (L∞) distance between two vectors,
+// `max(|a_i - b_i|)`. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Chebyshev[V ~[]F, F Float](a, b V) (distance float64, err error) {
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		distance = max(distance, math.Abs(float64(a[i])-float64(b[i])))
+	}
+	return distance, nil
+}
+
+// Minkowski returns the Minkowski (Lp) distance of order p between two vectors,
+// `(sum(|a_i - b_i|^p))^(1/p)`, which is the [Manhattan] distance when p = 1,
+// the [Euclidean] distance when p = 2 and the [Chebyshev] distance when p is
+// positive infinity. If the vectors do not have the same number of elements, an
+// error will be returned, and if p is less than 1 (when it is not a metric) an
+// [errors.ErrUndefinedValue] is returned.
+func Minkowski[V ~[]F, F Float](a, b V, p float64) (distance float64, err error) {
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+
+	switch {
+	case math.IsNaN(p) || p < 1.0:
+		return 0.0, errors.ErrUndefinedValue
+	case p == 1.0:
+		return Manhattan(a, b)
+	case p == 2.0:
+		return Euclidean(a, b)
+	case math.IsInf(p, 1):
+		return Chebyshev(a, b)
+	}
+
+	for i := range a {
+		distance += math.Pow(math.Abs(float64(a[i])-float64(b[i])), p)
+	}
+	return math.Pow(distance, 1.0/p), nil
+}
+
+// Angular returns the angular distance between two vectors, which is the angle
+// between them divided by π, as a value between [0.0, 1.0]. Unlike
+// `1 - cosine`, this is a metric. The angle is calculated from the unit vectors
+// as `2 * atan2(|u_a - u_b|, |u_a + u_b|)`, which is accurate for nearly
+// parallel and nearly opposite vectors, unlike `arccos(cosine)`. If the vectors
+// do not have the same number of elements or either of the vectors has a
+// length of zero, an error will be returned (see [Cosine]).
+func Angular[V ~[]F, F Float](a, b V) (distance float64, err error) {
+	if len(a) != len(b) {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+
+	lengthA, lengthB := Magnitude(a), Magnitude(b)
+	if lengthA == 0.0 || lengthB == 0.0 {
+		// The angle is undefined for zero length vectors
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	var diff, sum float64
+	for i := range a {
+		ua, ub := float64(a[i])/lengthA, float64(b[i])/lengthB
+		diff += (ua - ub) * (ua - ub)
+		sum += (ua + ub) * (ua + ub)
+	}
+	return 2.0 * math.Atan2(math.Sqrt(diff), math.Sqrt(sum)) / math.Pi, nil
+}
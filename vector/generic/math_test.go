@@ -0,0 +1,58 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vector/generic"
+)
+
+// A named vector type which is not defined in the vector package.
+type embedding []float32
+
+func TestElementTypes(t *testing.T) {
+	t.Run("Float32", func(t *testing.T) {
+		cosine, err := generic.Cosine([]float32{1, 0, 1}, []float32{1, 0, 1})
+		require.NoError(t, err)
+		require.InDelta(t, 1.0, cosine, 1e-9)
+
+		sum, err := generic.Add(embedding{1, 2}, embedding{3, 4})
+		require.NoError(t, err)
+		require.Equal(t, embedding{4, 6}, sum)
+	})
+
+	t.Run("Vector32", func(t *testing.T) {
+		mean, err := generic.Mean(vector.Vector32{1, 2}, vector.Vector32{3, 6})
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector32{2, 4}, mean)
+
+		length, err := generic.Length(vector.Vector32{3, -4}, generic.NormL1)
+		require.NoError(t, err)
+		require.Equal(t, 7.0, length)
+
+		_, err = generic.Mean[vector.Vector32]()
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+
+	t.Run("SameAsVector", func(t *testing.T) {
+		// The vector package functions delegate to the generic functions
+		a, b := vector.Vector{1, 2, 3}, vector.Vector{-2, 0.5, 4}
+		for _, fn := range []struct {
+			vector  func(a, b vector.Vector) (float64, error)
+			generic func(a, b vector.Vector) (float64, error)
+		}{
+			{vector.Cosine, generic.Cosine[vector.Vector]},
+			{vector.DotProduct, generic.DotProduct[vector.Vector]},
+			{vector.Euclidean, generic.Euclidean[vector.Vector]},
+			{vector.Angular, generic.Angular[vector.Vector]},
+		} {
+			expected, err := fn.generic(a, b)
+			require.NoError(t, err)
+			actual, err := fn.vector(a, b)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		}
+	})
+}
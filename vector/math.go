@@ -1,10 +1,7 @@
 package vector
 
 import (
-	"math"
-
-	"go.rtnl.ai/nlp/errors"
-	"go.rtnl.ai/nlp/mathematics"
+	"go.rtnl.ai/nlp/vector/generic"
 )
 
 // Cosine returns the cosine of the angle between two vectors as a value between
@@ -12,53 +9,176 @@ import (
 // vectors do not have the same number of elements or either of the vectors has
 // a length of zero, an error will be returned.
 func Cosine(a, b Vector) (cosine float64, err error) {
-	// Ensure vectors have the same number of elements
-	if len(a) != len(b) {
-		return 0.0, errors.ErrUnequalLengthVectors
-	}
-
-	// Calculate the dot product
-	var dotprod, vlenprod float64
-	if dotprod, err = DotProduct(a, b); err != nil {
-		return 0.0, err
-	}
-
-	// Calculate the product of the two vector's lengths
-	vlenprod = Magnitude(a) * Magnitude(b)
-	if vlenprod == 0.0 {
-		// Cosine is undefined for zero length vectors
-		return 0.0, errors.ErrUndefinedValue
-	}
-
-	// Return final cosine value clamped to [-1.0, 1.0]
-	return mathematics.BoundToRange(dotprod/vlenprod, -1.0, 1.0), nil
+	return generic.Cosine(a, b)
 }
 
 // DotProduct returns the dot product of the two vectors (as defined by SLP 3rd
 // Edition section 6.4 fig 6.7). If the vectors do not have the same number
 // of elements, an error will be returned.
 func DotProduct(a, b Vector) (product float64, err error) {
-	// Ensure vectors have the same number of elements
-	if len(a) != len(b) {
-		return 0.0, errors.ErrUnequalLengthVectors
-	}
-
-	for i := range a {
-		product += a[i] * b[i]
-	}
-	return product, nil
+	return generic.DotProduct(a, b)
 }
 
 // Magnitude returns the vector length (aka magnitude) (as defined by SLP 3rd
 // Edition section 6.4 fig 6.8).
 func Magnitude(v Vector) (length float64) {
-	for _, e := range v {
-		length += e * e
-	}
-	return math.Sqrt(length)
+	return generic.Magnitude(v)
 }
 
 // Len returns the number of elements in the [Vector].
 func Len(v Vector) (elements int) {
-	return len(v)
+	return generic.Len(v)
+}
+
+// ############################################################################
+// Element-wise Operations
+// ############################################################################
+
+// Add returns a new [Vector] which is the element-wise sum of the two vectors.
+// If the vectors do not have the same number of elements, an error will be
+// returned.
+func Add(a, b Vector) (sum Vector, err error) {
+	return generic.Add(a, b)
+}
+
+// AddInPlace adds each element of b to the element of a at the same index,
+// modifying a. If the vectors do not have the same number of elements, an error
+// will be returned and a is not modified.
+func AddInPlace(a, b Vector) (err error) {
+	return generic.AddInPlace(a, b)
+}
+
+// Subtract returns a new [Vector] which is the element-wise difference of the
+// two vectors, `a - b`. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Subtract(a, b Vector) (difference Vector, err error) {
+	return generic.Subtract(a, b)
+}
+
+// SubtractInPlace subtracts each element of b from the element of a at the same
+// index, modifying a. If the vectors do not have the same number of elements,
+// an error will be returned and a is not modified.
+func SubtractInPlace(a, b Vector) (err error) {
+	return generic.SubtractInPlace(a, b)
+}
+
+// Multiply returns a new [Vector] which is the element-wise (Hadamard) product
+// of the two vectors. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Multiply(a, b Vector) (product Vector, err error) {
+	return generic.Multiply(a, b)
+}
+
+// MultiplyInPlace multiplies each element of a by the element of b at the same
+// index, modifying a. If the vectors do not have the same number of elements,
+// an error will be returned and a is not modified.
+func MultiplyInPlace(a, b Vector) (err error) {
+	return generic.MultiplyInPlace(a, b)
+}
+
+// Scale returns a new [Vector] with each element multiplied by factor.
+func Scale(v Vector, factor float64) (scaled Vector) {
+	return generic.Scale(v, factor)
+}
+
+// ScaleInPlace multiplies each element of the [Vector] by factor, modifying it.
+func ScaleInPlace(v Vector, factor float64) {
+	generic.ScaleInPlace(v, factor)
+}
+
+// Clone returns a copy of the [Vector] which does not share its elements.
+func Clone(v Vector) (clone Vector) {
+	return generic.Clone(v)
+}
+
+// Mean returns the element-wise mean (the centroid) of the vectors. If no
+// vectors are given an [errors.ErrUndefinedValue] is returned, and if the
+// vectors do not all have the same number of elements an
+// [errors.ErrUnequalLengthVectors] is returned.
+func Mean(vectors ...Vector) (mean Vector, err error) {
+	return generic.Mean(vectors...)
+}
+
+// ############################################################################
+// Norms and Normalization
+// ############################################################################
+
+// Norm selects how the length of a [Vector] is measured.
+type Norm = generic.Norm
+
+const (
+	NormUnknown = generic.NormUnknown
+	// The sum of the absolute values of the elements (taxicab length).
+	NormL1 = generic.NormL1
+	// The square root of the sum of the squares of the elements (Euclidean
+	// length); see [Magnitude].
+	NormL2 = generic.NormL2
+	// The largest absolute value of the elements (maximum or infinity norm).
+	NormMax = generic.NormMax
+)
+
+// Length returns the length of the [Vector] measured with the [Norm]. Returns
+// [errors.ErrMethodNotSupported] for an unknown norm.
+func Length(v Vector, norm Norm) (length float64, err error) {
+	return generic.Length(v, norm)
+}
+
+// Normalize returns a new [Vector] with the same direction as the vector and a
+// length of one measured with the [Norm]. A vector with a length of zero is
+// returned unchanged. Returns [errors.ErrMethodNotSupported] for an unknown
+// norm.
+func Normalize(v Vector, norm Norm) (normalized Vector, err error) {
+	return generic.Normalize(v, norm)
+}
+
+// NormalizeInPlace divides each element of the [Vector] by its length measured
+// with the [Norm], modifying it (see [Normalize]).
+func NormalizeInPlace(v Vector, norm Norm) (err error) {
+	return generic.NormalizeInPlace(v, norm)
+}
+
+// ############################################################################
+// Distance Metrics
+// ############################################################################
+
+// Euclidean returns the straight-line (L2) distance between two vectors,
+// `sqrt(sum((a_i - b_i)^2))`. If the vectors do not have the same number of
+// elements, an error will be returned.
+func Euclidean(a, b Vector) (distance float64, err error) {
+	return generic.Euclidean(a, b)
+}
+
+// Manhattan returns the taxicab (L1) distance between two vectors,
+// `sum(|a_i - b_i|)`. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Manhattan(a, b Vector) (distance float64, err error) {
+	return generic.Manhattan(a, b)
+}
+
+// Chebyshev returns the maximum (L∞) distance between two vectors,
+// `max(|a_i - b_i|)`. If the vectors do not have the same number of elements,
+// an error will be returned.
+func Chebyshev(a, b Vector) (distance float64, err error) {
+	return generic.Chebyshev(a, b)
+}
+
+// Minkowski returns the Minkowski (Lp) distance of order p between two vectors,
+// `(sum(|a_i - b_i|^p))^(1/p)`, which is the [Manhattan] distance when p = 1,
+// the [Euclidean] distance when p = 2 and the [Chebyshev] distance when p is
+// positive infinity. If the vectors do not have the same number of elements, an
+// error will be returned, and if p is less than 1 (when it is not a metric) an
+// [errors.ErrUndefinedValue] is returned.
+func Minkowski(a, b Vector, p float64) (distance float64, err error) {
+	return generic.Minkowski(a, b, p)
+}
+
+// Angular returns the angular distance between two vectors, which is the angle
+// between them divided by π, as a value between [0.0, 1.0]. Unlike
+// `1 - cosine`, this is a metric. The angle is calculated from the unit vectors
+// as `2 * atan2(|u_a - u_b|, |u_a + u_b|)`, which is accurate for nearly
+// parallel and nearly opposite vectors, unlike `arccos(cosine)`. If the vectors
+// do not have the same number of elements or either of the vectors has a
+// length of zero, an error will be returned (see [Cosine]).
+func Angular(a, b Vector) (distance float64, err error) {
+	return generic.Angular(a, b)
 }
@@ -252,3 +252,160 @@ func repeatingVector(n int, values ...float64) (vec vector.Vector) {
 	}
 	return vec
 }
+
+func TestElementWise(t *testing.T) {
+	a := vector.Vector{1, 2, 3}
+	b := vector.Vector{4, -5, 0.5}
+
+	t.Run("Add", func(t *testing.T) {
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{5, -3, 3.5}, sum)
+		require.Equal(t, vector.Vector{1, 2, 3}, a, "a was modified")
+	})
+
+	t.Run("Subtract", func(t *testing.T) {
+		difference, err := a.Subtract(b)
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{-3, 7, 2.5}, difference)
+	})
+
+	t.Run("Multiply", func(t *testing.T) {
+		product, err := a.Multiply(b)
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{4, -10, 1.5}, product)
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		require.Equal(t, vector.Vector{-2, -4, -6}, a.Scale(-2))
+		require.Equal(t, vector.Vector{1, 2, 3}, a, "a was modified")
+	})
+
+	t.Run("InPlace", func(t *testing.T) {
+		v := a.Clone()
+		require.NoError(t, v.AddInPlace(b))
+		require.NoError(t, v.SubtractInPlace(a))
+		require.Equal(t, b, v)
+
+		require.NoError(t, v.MultiplyInPlace(vector.Vector{2, 2, 2}))
+		v.ScaleInPlace(0.5)
+		require.Equal(t, b, v)
+	})
+
+	t.Run("ErrorUnequalLengths", func(t *testing.T) {
+		short := vector.Vector{1, 2}
+
+		_, err := vector.Add(a, short)
+		require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+		_, err = vector.Subtract(a, short)
+		require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+		_, err = vector.Multiply(a, short)
+		require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+
+		v := a.Clone()
+		require.ErrorIs(t, v.AddInPlace(short), errors.ErrUnequalLengthVectors)
+		require.ErrorIs(t, v.SubtractInPlace(short), errors.ErrUnequalLengthVectors)
+		require.ErrorIs(t, v.MultiplyInPlace(short), errors.ErrUnequalLengthVectors)
+		require.Equal(t, a, v, "v was modified")
+	})
+}
+
+func TestMean(t *testing.T) {
+	mean, err := vector.Mean(vector.Vector{1, 2}, vector.Vector{3, 6}, vector.Vector{-1, 1})
+	require.NoError(t, err)
+	require.Equal(t, vector.Vector{1, 3}, mean)
+
+	_, err = vector.Mean()
+	require.ErrorIs(t, err, errors.ErrUndefinedValue)
+
+	_, err = vector.Mean(vector.Vector{1, 2}, vector.Vector{1, 2, 3})
+	require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+}
+
+func TestNormalize(t *testing.T) {
+	v := vector.Vector{3, -4, 0}
+	testcases := []struct {
+		Norm       vector.Norm
+		Length     float64
+		Normalized vector.Vector
+	}{
+		{vector.NormL1, 7, vector.Vector{3.0 / 7.0, -4.0 / 7.0, 0}},
+		{vector.NormL2, 5, vector.Vector{0.6, -0.8, 0}},
+		{vector.NormMax, 4, vector.Vector{0.75, -1, 0}},
+	}
+
+	for _, tc := range testcases {
+		length, err := v.Length(tc.Norm)
+		require.NoError(t, err)
+		require.Equal(t, tc.Length, length)
+
+		normalized, err := v.Normalize(tc.Norm)
+		require.NoError(t, err)
+		require.InDeltaSlice(t, tc.Normalized, normalized, 1e-12)
+
+		length, err = normalized.Length(tc.Norm)
+		require.NoError(t, err)
+		require.InDelta(t, 1.0, length, 1e-12)
+	}
+
+	// Zero vectors are unchanged
+	zero := vector.Vector{0, 0}
+	require.NoError(t, zero.NormalizeInPlace(vector.NormL2))
+	require.Equal(t, vector.Vector{0, 0}, zero)
+
+	_, err := v.Normalize(vector.NormUnknown)
+	require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+}
+
+func TestDistances(t *testing.T) {
+	a := vector.Vector{1, 2, 3}
+	b := vector.Vector{4, 6, 3}
+
+	distance, err := a.Euclidean(b)
+	require.NoError(t, err)
+	require.Equal(t, 5.0, distance)
+
+	distance, err = a.Manhattan(b)
+	require.NoError(t, err)
+	require.Equal(t, 7.0, distance)
+
+	distance, err = a.Chebyshev(b)
+	require.NoError(t, err)
+	require.Equal(t, 4.0, distance)
+
+	t.Run("Minkowski", func(t *testing.T) {
+		for p, expected := range map[float64]float64{1: 7, 2: 5, math.Inf(1): 4, 3: math.Cbrt(27 + 64)} {
+			distance, err := a.Minkowski(b, p)
+			require.NoError(t, err)
+			require.InDelta(t, expected, distance, 1e-12)
+		}
+
+		_, err := a.Minkowski(b, 0.5)
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+
+	t.Run("Angular", func(t *testing.T) {
+		distance, err := vector.Angular(vector.Vector{1, 0}, vector.Vector{0, 1})
+		require.NoError(t, err)
+		require.InDelta(t, 0.5, distance, 1e-12)
+
+		distance, err = vector.Angular(vector.Vector{1, 1}, vector.Vector{-2, -2})
+		require.NoError(t, err)
+		require.InDelta(t, 1.0, distance, 1e-12)
+
+		_, err = vector.Angular(vector.Vector{0, 0}, vector.Vector{1, 1})
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+
+	t.Run("ErrorUnequalLengths", func(t *testing.T) {
+		short := vector.Vector{1, 2}
+		for _, distance := range []func(a, b vector.Vector) (float64, error){
+			vector.Euclidean, vector.Manhattan,
+			vector.Chebyshev, vector.Angular,
+			func(a, b vector.Vector) (float64, error) { return vector.Minkowski(a, b, 3) },
+		} {
+			_, err := distance(a, short)
+			require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+		}
+	})
+}
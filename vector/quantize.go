@@ -0,0 +1,247 @@
+package vector
+
+import (
+	"math"
+	"math/bits"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/mathematics"
+)
+
+// ############################################################################
+// Int8 (scalar quantization)
+// ############################################################################
+
+/*
+Int8 is a vector quantized to one signed byte per element (scalar
+quantization), which uses an eighth of the memory of a [Vector] (a quarter of a
+[Vector32]); create with [QuantizeInt8]. The elements are scaled symmetrically
+so the largest absolute value maps to 127, and each element is approximately
+its int8 value multiplied by the scale factor.
+
+The cosine of two quantized vectors is calculated from their int8 values
+directly, since the scale factors cancel out, and is usually within 0.01 of the
+cosine of the original vectors for embeddings.
+
+Usage example:
+
+	quantized := vector.QuantizeInt8(embedding)
+	cosine, err := quantized.Cosine(vector.QuantizeInt8(other))
+
+	// Store the values and scale factor, then load them again later
+	values, factor := quantized.Values(), quantized.ScaleFactor()
+	loaded := vector.NewInt8(values, factor)
+*/
+type Int8 struct {
+	values []int8
+	factor float64
+}
+
+// Returns the [Int8] scalar quantization of the vector.
+func QuantizeInt8[V ~[]F, F Float](v V) *Int8 {
+	var maxAbs float64
+	for _, e := range v {
+		maxAbs = max(maxAbs, math.Abs(float64(e)))
+	}
+
+	q := &Int8{values: make([]int8, len(v))}
+	if maxAbs == 0.0 {
+		return q
+	}
+
+	q.factor = maxAbs / math.MaxInt8
+	for i, e := range v {
+		q.values[i] = int8(math.Round(float64(e) / q.factor))
+	}
+	return q
+}
+
+// Returns an [Int8] vector with the quantized values and scale factor, such as
+// those stored from [Int8.Values] and [Int8.ScaleFactor].
+func NewInt8(values []int8, factor float64) *Int8 {
+	return &Int8{values: values, factor: factor}
+}
+
+// Returns the number of elements in the [Int8] vector.
+func (q *Int8) Len() (elements int) {
+	return len(q.values)
+}
+
+// Returns the quantized values of the [Int8] vector. The slice must not be
+// modified.
+func (q *Int8) Values() []int8 {
+	return q.values
+}
+
+// Returns the factor each quantized value is multiplied by to approximate the
+// original value.
+func (q *Int8) ScaleFactor() float64 {
+	return q.factor
+}
+
+// Returns the approximate dense [Vector] the [Int8] vector was quantized from.
+func (q *Int8) Dense() Vector {
+	dense := make(Vector, len(q.values))
+	for i, e := range q.values {
+		dense[i] = float64(e) * q.factor
+	}
+	return dense
+}
+
+// DotProduct returns the approximate dot product of the original vectors (see
+// [DotProduct]). Returns [errors.ErrUnequalLengthVectors] if the vectors do not
+// have the same number of elements.
+func (q *Int8) DotProduct(other *Int8) (product float64, err error) {
+	var dot int64
+	if dot, err = dotInt8(q.values, other.values); err != nil {
+		return 0.0, err
+	}
+	return float64(dot) * q.factor * other.factor, nil
+}
+
+// Cosine returns the approximate cosine of the angle between the original
+// vectors as a value between [-1.0, 1.0] (see [Cosine]). Returns
+// [errors.ErrUnequalLengthVectors] if the vectors do not have the same number of
+// elements or [errors.ErrUndefinedValue] if either vector has a length of zero.
+func (q *Int8) Cosine(other *Int8) (cosine float64, err error) {
+	var dot, lenA, lenB int64
+	if dot, err = dotInt8(q.values, other.values); err != nil {
+		return 0.0, err
+	}
+	lenA, _ = dotInt8(q.values, q.values)
+	lenB, _ = dotInt8(other.values, other.values)
+
+	vlenprod := math.Sqrt(float64(lenA)) * math.Sqrt(float64(lenB))
+	if vlenprod == 0.0 {
+		// Cosine is undefined for zero length vectors
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return mathematics.BoundToRange(float64(dot)/vlenprod, -1.0, 1.0), nil
+}
+
+// Returns the dot product of the int8 values without overflow.
+func dotInt8(a, b []int8) (product int64, err error) {
+	if len(a) != len(b) {
+		return 0, errors.ErrUnequalLengthVectors
+	}
+	for i := range a {
+		product += int64(a[i]) * int64(b[i])
+	}
+	return product, nil
+}
+
+// ############################################################################
+// Binary (binary quantization)
+// ############################################################################
+
+/*
+Binary is a vector quantized to one bit per element (binary quantization), set
+when the element is positive, which uses 1/64th of the memory of a [Vector];
+create with [QuantizeBinary]. Binary vectors are compared by the [Binary.Hamming]
+distance, the number of elements whose bits differ, which can be used to
+approximate the cosine of the original vectors (see [Binary.Cosine]); this is
+usually used to quickly find candidates that are then re-ranked with the
+original or [Int8] vectors.
+
+Usage example:
+
+	quantized := vector.QuantizeBinary(embedding)
+	distance, err := quantized.Hamming(vector.QuantizeBinary(other))
+
+	// Store the bits and length, then load them again later
+	bits, length := quantized.Bits(), quantized.Len()
+	loaded, err := vector.NewBinary(bits, length)
+*/
+type Binary struct {
+	bits []uint64
+	dim  int
+}
+
+// Returns the [Binary] quantization of the vector.
+func QuantizeBinary[V ~[]F, F Float](v V) *Binary {
+	q := &Binary{bits: make([]uint64, (len(v)+63)/64), dim: len(v)}
+	for i, e := range v {
+		if e > 0 {
+			q.bits[i/64] |= 1 << (i % 64)
+		}
+	}
+	return q
+}
+
+// Returns a [Binary] vector with dim elements from the bits, such as those
+// stored from [Binary.Bits], where element i is bit i%64 of bits[i/64]. Returns
+// [errors.ErrUnequalLengthInputs] if there are not (dim+63)/64 words of bits.
+func NewBinary(bits []uint64, dim int) (q *Binary, err error) {
+	if dim < 0 || len(bits) != (dim+63)/64 {
+		return nil, errors.ErrUnequalLengthInputs
+	}
+	return &Binary{bits: bits, dim: dim}, nil
+}
+
+// Returns the number of elements in the [Binary] vector.
+func (q *Binary) Len() (elements int) {
+	return q.dim
+}
+
+// Returns the bits of the [Binary] vector (see [NewBinary]). The slice must not
+// be modified.
+func (q *Binary) Bits() []uint64 {
+	return q.bits
+}
+
+// Returns the [Vector] with 1 for each set bit and -1 for each unset bit of
+// the [Binary] vector.
+func (q *Binary) Dense() Vector {
+	dense := make(Vector, q.dim)
+	for i := range dense {
+		if q.bits[i/64]&(1<<(i%64)) != 0 {
+			dense[i] = 1
+		} else {
+			dense[i] = -1
+		}
+	}
+	return dense
+}
+
+// Hamming returns the number of elements whose bits differ between the two
+// vectors. Returns [errors.ErrUnequalLengthVectors] if the vectors do not have
+// the same number of elements.
+func (q *Binary) Hamming(other *Binary) (distance int, err error) {
+	if q.dim != other.dim {
+		return 0, errors.ErrUnequalLengthVectors
+	}
+	for i := range q.bits {
+		distance += bits.OnesCount64(q.bits[i] ^ other.bits[i])
+	}
+	return distance, nil
+}
+
+// Similarity returns the fraction of elements whose bits are the same in both
+// vectors, `1 - hamming / n`, as a value between [0.0, 1.0]. Returns
+// [errors.ErrUnequalLengthVectors] if the vectors do not have the same number of
+// elements or [errors.ErrUndefinedValue] if they have no elements.
+func (q *Binary) Similarity(other *Binary) (similarity float64, err error) {
+	var distance int
+	if distance, err = q.Hamming(other); err != nil {
+		return 0.0, err
+	}
+	if q.dim == 0 {
+		return 0.0, errors.ErrUndefinedValue
+	}
+	return 1.0 - float64(distance)/float64(q.dim), nil
+}
+
+// Cosine returns the approximate cosine of the angle between the original
+// vectors, `cos(π * hamming / n)`, as a value between [-1.0, 1.0]. This
+// estimate assumes the elements are symmetric around zero, like a random
+// hyperplane projection (Charikar, 2002), which holds approximately for most
+// embeddings. Returns [errors.ErrUnequalLengthVectors] if the vectors do not
+// have the same number of elements or [errors.ErrUndefinedValue] if they have
+// no elements.
+func (q *Binary) Cosine(other *Binary) (cosine float64, err error) {
+	var similarity float64
+	if similarity, err = q.Similarity(other); err != nil {
+		return 0.0, err
+	}
+	return math.Cos(math.Pi * (1.0 - similarity)), nil
+}
@@ -0,0 +1,129 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+)
+
+func TestVector32(t *testing.T) {
+	a := vector.Vector{1, 2, 3}.Float32()
+	b := vector.Vector32{4, 6, 3}
+	require.Equal(t, vector.Vector32{1, 2, 3}, a)
+	require.Equal(t, vector.Vector{4, 6, 3}, b.Float64())
+
+	distance, err := a.Euclidean(b)
+	require.NoError(t, err)
+	require.Equal(t, 5.0, distance)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, vector.Vector32{5, 8, 6}, sum)
+
+	normalized, err := b.Normalize(vector.NormMax)
+	require.NoError(t, err)
+	require.Equal(t, vector.Vector32{4.0 / 6.0, 1, 0.5}, normalized)
+
+	// The float32 cosine matches the float64 cosine to float32 precision
+	expected, err := vector.Cosine(a.Float64(), b.Float64())
+	require.NoError(t, err)
+	cosine, err := a.Cosine(b)
+	require.NoError(t, err)
+	require.InDelta(t, expected, cosine, 1e-7)
+
+	_, err = a.DotProduct(vector.Vector32{1})
+	require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+}
+
+func TestQuantizeInt8(t *testing.T) {
+	v := vector.Vector{0.5, -1, 0.25, 0}
+	q := v.QuantizeInt8()
+	require.Equal(t, 4, q.Len())
+	require.Equal(t, []int8{64, -127, 32, 0}, q.Values())
+	require.Equal(t, 1.0/127.0, q.ScaleFactor())
+	require.InDeltaSlice(t, v, q.Dense(), q.ScaleFactor()/2)
+
+	loaded := vector.NewInt8(q.Values(), q.ScaleFactor())
+	require.Equal(t, q.Dense(), loaded.Dense())
+
+	// Zero vectors have no defined cosine
+	zero := vector.QuantizeInt8(vector.Vector32{0, 0, 0, 0})
+	require.Equal(t, []int8{0, 0, 0, 0}, zero.Values())
+	_, err := q.Cosine(zero)
+	require.ErrorIs(t, err, errors.ErrUndefinedValue)
+
+	_, err = q.DotProduct(vector.QuantizeInt8(vector.Vector{1}))
+	require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+}
+
+func TestQuantizeBinary(t *testing.T) {
+	v := make(vector.Vector, 70)
+	for i := range v {
+		v[i] = float64(i%3) - 1 // -1, 0, 1, -1, ...
+	}
+	q := v.QuantizeBinary()
+	require.Equal(t, 70, q.Len())
+	require.Len(t, q.Bits(), 2)
+	require.Equal(t, uint64(0b100), q.Bits()[0]&0b111)
+
+	dense := q.Dense()
+	require.Equal(t, vector.Vector{-1, -1, 1}, dense[:3])
+
+	loaded, err := vector.NewBinary(q.Bits(), q.Len())
+	require.NoError(t, err)
+	distance, err := q.Hamming(loaded)
+	require.NoError(t, err)
+	require.Equal(t, 0, distance)
+
+	// The opposite vector differs in every non-zero element
+	opposite := vector.Scale(v, -1).QuantizeBinary()
+	distance, err = q.Hamming(opposite)
+	require.NoError(t, err)
+	require.Equal(t, 47, distance)
+
+	similarity, err := q.Similarity(opposite)
+	require.NoError(t, err)
+	require.InDelta(t, 23.0/70.0, similarity, 1e-12)
+
+	_, err = vector.NewBinary(q.Bits(), 200)
+	require.ErrorIs(t, err, errors.ErrUnequalLengthInputs)
+
+	_, err = q.Hamming(vector.QuantizeBinary(vector.Vector{1}))
+	require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+
+	_, err = vector.QuantizeBinary(vector.Vector{}).Similarity(vector.QuantizeBinary(vector.Vector{}))
+	require.ErrorIs(t, err, errors.ErrUndefinedValue)
+}
+
+func TestQuantizedCosine(t *testing.T) {
+	// Random embedding-like vectors with a known correlation
+	rng := rand.New(rand.NewPCG(1, 2))
+	base := make(vector.Vector32, 1024)
+	near := make(vector.Vector32, 1024)
+	for i := range base {
+		base[i] = float32(rng.NormFloat64())
+		near[i] = base[i] + float32(rng.NormFloat64())
+	}
+
+	expected, err := base.Cosine(near)
+	require.NoError(t, err)
+	require.InDelta(t, math.Sqrt(0.5), expected, 0.05)
+
+	cosine, err := base.QuantizeInt8().Cosine(near.QuantizeInt8())
+	require.NoError(t, err)
+	require.InDelta(t, expected, cosine, 0.01)
+
+	product, err := base.QuantizeInt8().DotProduct(near.QuantizeInt8())
+	require.NoError(t, err)
+	expectedProduct, err := base.DotProduct(near)
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, product/expectedProduct, 0.01)
+
+	cosine, err = base.QuantizeBinary().Cosine(near.QuantizeBinary())
+	require.NoError(t, err)
+	require.InDelta(t, expected, cosine, 0.1)
+}
@@ -0,0 +1,281 @@
+package vector
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/mathematics"
+)
+
+// ############################################################################
+// Sparse
+// ############################################################################
+
+/*
+Sparse is a vector which only stores its non-zero elements, as index/value
+pairs sorted by index, so a vector over a large vocabulary only costs memory for
+the words that are present; create with [NewSparse] or [NewSparseFromDense].
+
+Sparse vectors are not modified by their methods; the arithmetic methods return
+new vectors.
+
+Usage example:
+
+	// A vector with 50,000 elements, of which 3 are non-zero
+	a, err := vector.NewSparse(50000, []int{7, 42, 1999}, []float64{1, 2, 1})
+	b, err := vector.NewSparse(50000, []int{42, 30000}, []float64{3, 1})
+
+	product, err := a.DotProduct(b) // 6
+	cosine, err := a.Cosine(b)
+	dense := a.Dense() // a [Vector] with 50,000 elements
+*/
+type Sparse struct {
+	dim     int
+	indices []int
+	values  []float64
+}
+
+// Returns a new [Sparse] vector with dim elements, where the element at each of
+// the indices has the value at the same position in values. The indices do not
+// need to be sorted; the values of repeated indices are summed (e.g. for hash
+// collisions) and elements which are zero are not stored. Returns
+// [errors.ErrUnequalLengthInputs] if there are not as many values as indices,
+// or [errors.ErrInvalidIndex] if an index is not in the range [0, dim).
+func NewSparse(dim int, indices []int, values []float64) (vec *Sparse, err error) {
+	if len(indices) != len(values) {
+		return nil, errors.ErrUnequalLengthInputs
+	}
+	if dim < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the number of elements cannot be negative"))
+	}
+
+	// Sort the positions of the pairs by index without modifying the arguments
+	order := make([]int, len(indices))
+	for i := range order {
+		if indices[i] < 0 || indices[i] >= dim {
+			return nil, errors.ErrInvalidIndex
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return indices[order[i]] < indices[order[j]]
+	})
+
+	// Sum the values of repeated indices
+	vec = &Sparse{
+		dim:     dim,
+		indices: make([]int, 0, len(indices)),
+		values:  make([]float64, 0, len(values)),
+	}
+	for _, i := range order {
+		if n := len(vec.indices); n > 0 && vec.indices[n-1] == indices[i] {
+			vec.values[n-1] += values[i]
+			continue
+		}
+		vec.indices = append(vec.indices, indices[i])
+		vec.values = append(vec.values, values[i])
+	}
+
+	vec.dropZeros()
+	return vec, nil
+}
+
+// Returns a new [Sparse] vector with the non-zero elements of the dense
+// [Vector].
+func NewSparseFromDense(dense Vector) *Sparse {
+	vec := &Sparse{dim: len(dense)}
+	for i, e := range dense {
+		if e != 0.0 {
+			vec.indices = append(vec.indices, i)
+			vec.values = append(vec.values, e)
+		}
+	}
+	return vec
+}
+
+// Returns the number of elements in the [Sparse] vector, including zeros,
+// which is the length of its dense [Vector].
+func (v *Sparse) Len() (elements int) {
+	return v.dim
+}
+
+// Returns the number of non-zero elements stored in the [Sparse] vector.
+func (v *Sparse) NonZero() int {
+	return len(v.indices)
+}
+
+// Returns the indices of the non-zero elements in ascending order. The slice
+// must not be modified.
+func (v *Sparse) Indices() []int {
+	return v.indices
+}
+
+// Returns the values of the non-zero elements in the order of [Sparse.Indices].
+// The slice must not be modified.
+func (v *Sparse) Values() []float64 {
+	return v.values
+}
+
+// Returns the value of the element at index i, which is zero if it is not
+// stored. Returns [errors.ErrInvalidIndex] if i is not in the range [0, Len).
+func (v *Sparse) At(i int) (value float64, err error) {
+	if i < 0 || i >= v.dim {
+		return 0.0, errors.ErrInvalidIndex
+	}
+	if pos, ok := slices.BinarySearch(v.indices, i); ok {
+		return v.values[pos], nil
+	}
+	return 0.0, nil
+}
+
+// Returns the dense [Vector] with the same elements as the [Sparse] vector.
+func (v *Sparse) Dense() Vector {
+	dense := make(Vector, v.dim)
+	for i, index := range v.indices {
+		dense[index] = v.values[i]
+	}
+	return dense
+}
+
+// Cosine returns the cosine of the angle between two sparse vectors as a value
+// between [-1.0, 1.0] (see [Cosine]). Returns [errors.ErrUnequalLengthVectors]
+// if the vectors do not have the same number of elements or
+// [errors.ErrUndefinedValue] if either of the vectors has a length of zero.
+func (v *Sparse) Cosine(other *Sparse) (cosine float64, err error) {
+	var dotprod float64
+	if dotprod, err = v.DotProduct(other); err != nil {
+		return 0.0, err
+	}
+
+	vlenprod := v.Magnitude() * other.Magnitude()
+	if vlenprod == 0.0 {
+		// Cosine is undefined for zero length vectors
+		return 0.0, errors.ErrUndefinedValue
+	}
+
+	return mathematics.BoundToRange(dotprod/vlenprod, -1.0, 1.0), nil
+}
+
+// DotProduct returns the dot product of the two sparse vectors (see
+// [DotProduct]), which only visits their non-zero elements. Returns
+// [errors.ErrUnequalLengthVectors] if the vectors do not have the same number of
+// elements.
+func (v *Sparse) DotProduct(other *Sparse) (product float64, err error) {
+	if v.dim != other.dim {
+		return 0.0, errors.ErrUnequalLengthVectors
+	}
+
+	// Merge the sorted indices
+	for i, j := 0, 0; i < len(v.indices) && j < len(other.indices); {
+		switch {
+		case v.indices[i] < other.indices[j]:
+			i++
+		case v.indices[i] > other.indices[j]:
+			j++
+		default:
+			product += v.values[i] * other.values[j]
+			i++
+			j++
+		}
+	}
+	return product, nil
+}
+
+// Magnitude returns the length (aka magnitude) of the [Sparse] vector (see
+// [Magnitude]).
+func (v *Sparse) Magnitude() (length float64) {
+	for _, e := range v.values {
+		length += e * e
+	}
+	return math.Sqrt(length)
+}
+
+// Add returns a new [Sparse] vector which is the element-wise sum of the two
+// vectors. Returns [errors.ErrUnequalLengthVectors] if the vectors do not have
+// the same number of elements.
+func (v *Sparse) Add(other *Sparse) (sum *Sparse, err error) {
+	if v.dim != other.dim {
+		return nil, errors.ErrUnequalLengthVectors
+	}
+
+	sum = &Sparse{
+		dim:     v.dim,
+		indices: make([]int, 0, len(v.indices)+len(other.indices)),
+		values:  make([]float64, 0, len(v.values)+len(other.values)),
+	}
+
+	// Merge the sorted indices
+	i, j := 0, 0
+	for i < len(v.indices) || j < len(other.indices) {
+		switch {
+		case j == len(other.indices) || (i < len(v.indices) && v.indices[i] < other.indices[j]):
+			sum.indices = append(sum.indices, v.indices[i])
+			sum.values = append(sum.values, v.values[i])
+			i++
+		case i == len(v.indices) || v.indices[i] > other.indices[j]:
+			sum.indices = append(sum.indices, other.indices[j])
+			sum.values = append(sum.values, other.values[j])
+			j++
+		default:
+			sum.indices = append(sum.indices, v.indices[i])
+			sum.values = append(sum.values, v.values[i]+other.values[j])
+			i++
+			j++
+		}
+	}
+
+	sum.dropZeros()
+	return sum, nil
+}
+
+// Scale returns a new [Sparse] vector with each element multiplied by factor.
+func (v *Sparse) Scale(factor float64) *Sparse {
+	scaled := &Sparse{
+		dim:     v.dim,
+		indices: slices.Clone(v.indices),
+		values:  make([]float64, len(v.values)),
+	}
+	for i, e := range v.values {
+		scaled.values[i] = e * factor
+	}
+	scaled.dropZeros()
+	return scaled
+}
+
+// Normalize returns a new [Sparse] vector with the same direction and a length
+// of one (L2 normalization). A vector with a length of zero is returned
+// unchanged.
+func (v *Sparse) Normalize() *Sparse {
+	normalized := v.Scale(1.0)
+	if length := v.Magnitude(); length != 0.0 {
+		for i := range normalized.values {
+			normalized.values[i] /= length
+		}
+	}
+	return normalized
+}
+
+// Removes the stored elements which are zero.
+func (v *Sparse) dropZeros() {
+	n := 0
+	for i, e := range v.values {
+		if e != 0.0 {
+			v.indices[n] = v.indices[i]
+			v.values[n] = e
+			n++
+		}
+	}
+	v.indices = v.indices[:n]
+	v.values = v.values[:n]
+}
+
+// ############################################################################
+// Vector conversion
+// ############################################################################
+
+// A [Vector] wrapper for the [NewSparseFromDense] function.
+func (v Vector) Sparse() *Sparse {
+	return NewSparseFromDense(v)
+}
@@ -0,0 +1,138 @@
+package vector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+)
+
+func TestNewSparse(t *testing.T) {
+	t.Run("SortsAndSums", func(t *testing.T) {
+		indices := []int{9, 2, 5, 2, 7}
+		values := []float64{1, 2, 3, 4, -0}
+		vec, err := vector.NewSparse(10, indices, values)
+		require.NoError(t, err)
+		require.Equal(t, 10, vec.Len())
+		require.Equal(t, 3, vec.NonZero())
+		require.Equal(t, []int{2, 5, 9}, vec.Indices())
+		require.Equal(t, []float64{6, 3, 1}, vec.Values())
+
+		// The arguments are not modified
+		require.Equal(t, []int{9, 2, 5, 2, 7}, indices)
+	})
+
+	t.Run("DropsCancelledValues", func(t *testing.T) {
+		vec, err := vector.NewSparse(4, []int{1, 1}, []float64{1, -1})
+		require.NoError(t, err)
+		require.Equal(t, 0, vec.NonZero())
+		require.Equal(t, vector.Vector{0, 0, 0, 0}, vec.Dense())
+	})
+
+	t.Run("ErrorUnequalLengths", func(t *testing.T) {
+		_, err := vector.NewSparse(4, []int{1, 2}, []float64{1})
+		require.ErrorIs(t, err, errors.ErrUnequalLengthInputs)
+	})
+
+	t.Run("ErrorIndexOutOfRange", func(t *testing.T) {
+		_, err := vector.NewSparse(4, []int{4}, []float64{1})
+		require.ErrorIs(t, err, errors.ErrInvalidIndex)
+
+		_, err = vector.NewSparse(4, []int{-1}, []float64{1})
+		require.ErrorIs(t, err, errors.ErrInvalidIndex)
+	})
+}
+
+func TestSparseDenseConversion(t *testing.T) {
+	dense := vector.Vector{0, 1.5, 0, 0, -2, 0}
+	sparse := dense.Sparse()
+	require.Equal(t, 6, sparse.Len())
+	require.Equal(t, []int{1, 4}, sparse.Indices())
+	require.Equal(t, []float64{1.5, -2}, sparse.Values())
+	require.Equal(t, dense, sparse.Dense())
+
+	value, err := sparse.At(4)
+	require.NoError(t, err)
+	require.Equal(t, -2.0, value)
+
+	value, err = sparse.At(3)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, value)
+
+	_, err = sparse.At(6)
+	require.ErrorIs(t, err, errors.ErrInvalidIndex)
+}
+
+func TestSparseMath(t *testing.T) {
+	denseA := vector.Vector{1, 0, 2, 0, 3, 0}
+	denseB := vector.Vector{0, 4, 5, 0, -1, 2}
+	a, b := denseA.Sparse(), denseB.Sparse()
+
+	t.Run("DotProduct", func(t *testing.T) {
+		expected, err := denseA.DotProduct(denseB)
+		require.NoError(t, err)
+
+		actual, err := a.DotProduct(b)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("Cosine", func(t *testing.T) {
+		expected, err := denseA.Cosine(denseB)
+		require.NoError(t, err)
+
+		actual, err := a.Cosine(b)
+		require.NoError(t, err)
+		require.InDelta(t, expected, actual, 1e-12)
+
+		same, err := a.Cosine(a)
+		require.NoError(t, err)
+		require.Equal(t, 1.0, same)
+
+		_, err = a.Cosine(vector.Vector{0, 0, 0, 0, 0, 0}.Sparse())
+		require.ErrorIs(t, err, errors.ErrUndefinedValue)
+	})
+
+	t.Run("Magnitude", func(t *testing.T) {
+		require.Equal(t, denseA.Magnitude(), a.Magnitude())
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{1, 4, 7, 0, 2, 2}, sum.Dense())
+
+		// Elements which cancel out are not stored
+		zero, err := a.Add(a.Scale(-1))
+		require.NoError(t, err)
+		require.Equal(t, 0, zero.NonZero())
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		require.Equal(t, vector.Vector{2, 0, 4, 0, 6, 0}, a.Scale(2).Dense())
+		require.Equal(t, 0, a.Scale(0).NonZero())
+
+		// The original vector is not modified
+		require.Equal(t, denseA, a.Dense())
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		unit := b.Normalize()
+		require.InDelta(t, 1.0, unit.Magnitude(), 1e-12)
+		require.Equal(t, b.Indices(), unit.Indices())
+
+		zero := vector.Vector{0, 0}.Sparse().Normalize()
+		require.Equal(t, 0, zero.NonZero())
+	})
+
+	t.Run("ErrorUnequalLengths", func(t *testing.T) {
+		short := vector.Vector{1, 2}.Sparse()
+		_, err := a.DotProduct(short)
+		require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+		_, err = a.Cosine(short)
+		require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+		_, err = a.Add(short)
+		require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+	})
+}
@@ -1,8 +1,49 @@
 package vector
 
+import (
+	"go.rtnl.ai/nlp/vector/generic"
+)
+
+// Float is the constraint for the element types of vectors, so the generic
+// vector functions (e.g. [QuantizeInt8] and the [generic] package) can be used
+// with both `[]float64` and the half-size `[]float32` vectors (e.g. for storing
+// large numbers of embeddings).
+type Float = generic.Float
+
 // Vector is currently implemented by a `[]float64`.
 type Vector []float64
 
+// Vector32 is a `[]float32` vector, which uses half the memory of a [Vector]
+// with the same number of elements at the cost of precision.
+type Vector32 []float32
+
+// Returns a copy of the [Vector] with float32 elements.
+func (v Vector) Float32() Vector32 {
+	return Convert[Vector32](v)
+}
+
+// Returns a copy of the [Vector32] with float64 elements.
+func (v Vector32) Float64() Vector {
+	return Convert[Vector](v)
+}
+
+// Convert returns a copy of the vector with the element type of the vector type
+// T, e.g. `Convert[Vector32](v)` for a [Vector] v.
+func Convert[T ~[]G, V ~[]F, F Float, G Float](v V) (converted T) {
+	if v == nil {
+		return nil
+	}
+	converted = make(T, len(v))
+	for i, e := range v {
+		converted[i] = G(e)
+	}
+	return converted
+}
+
+// ############################################################################
+// Vector methods
+// ############################################################################
+
 // A [Vector] wrapper for the [Cosine] function.
 func (v Vector) Cosine(other Vector) (cosine float64, err error) {
 	return Cosine(v, other)
@@ -22,3 +63,217 @@ func (v Vector) Magnitude() (length float64) {
 func (v Vector) Len() (elements int) {
 	return Len(v)
 }
+
+// A [Vector] wrapper for the [Add] function.
+func (v Vector) Add(other Vector) (sum Vector, err error) {
+	return Add(v, other)
+}
+
+// A [Vector] wrapper for the [AddInPlace] function.
+func (v Vector) AddInPlace(other Vector) (err error) {
+	return AddInPlace(v, other)
+}
+
+// A [Vector] wrapper for the [Subtract] function.
+func (v Vector) Subtract(other Vector) (difference Vector, err error) {
+	return Subtract(v, other)
+}
+
+// A [Vector] wrapper for the [SubtractInPlace] function.
+func (v Vector) SubtractInPlace(other Vector) (err error) {
+	return SubtractInPlace(v, other)
+}
+
+// A [Vector] wrapper for the [Multiply] function.
+func (v Vector) Multiply(other Vector) (product Vector, err error) {
+	return Multiply(v, other)
+}
+
+// A [Vector] wrapper for the [MultiplyInPlace] function.
+func (v Vector) MultiplyInPlace(other Vector) (err error) {
+	return MultiplyInPlace(v, other)
+}
+
+// A [Vector] wrapper for the [Scale] function.
+func (v Vector) Scale(factor float64) (scaled Vector) {
+	return Scale(v, factor)
+}
+
+// A [Vector] wrapper for the [ScaleInPlace] function.
+func (v Vector) ScaleInPlace(factor float64) {
+	ScaleInPlace(v, factor)
+}
+
+// A [Vector] wrapper for the [Clone] function.
+func (v Vector) Clone() (clone Vector) {
+	return Clone(v)
+}
+
+// A [Vector] wrapper for the [Length] function.
+func (v Vector) Length(norm Norm) (length float64, err error) {
+	return Length(v, norm)
+}
+
+// A [Vector] wrapper for the [Normalize] function.
+func (v Vector) Normalize(norm Norm) (normalized Vector, err error) {
+	return Normalize(v, norm)
+}
+
+// A [Vector] wrapper for the [NormalizeInPlace] function.
+func (v Vector) NormalizeInPlace(norm Norm) (err error) {
+	return NormalizeInPlace(v, norm)
+}
+
+// A [Vector] wrapper for the [Euclidean] function.
+func (v Vector) Euclidean(other Vector) (distance float64, err error) {
+	return Euclidean(v, other)
+}
+
+// A [Vector] wrapper for the [Manhattan] function.
+func (v Vector) Manhattan(other Vector) (distance float64, err error) {
+	return Manhattan(v, other)
+}
+
+// A [Vector] wrapper for the [Chebyshev] function.
+func (v Vector) Chebyshev(other Vector) (distance float64, err error) {
+	return Chebyshev(v, other)
+}
+
+// A [Vector] wrapper for the [Minkowski] function.
+func (v Vector) Minkowski(other Vector, p float64) (distance float64, err error) {
+	return Minkowski(v, other, p)
+}
+
+// A [Vector] wrapper for the [Angular] function.
+func (v Vector) Angular(other Vector) (distance float64, err error) {
+	return Angular(v, other)
+}
+
+// A [Vector] wrapper for the [QuantizeInt8] function.
+func (v Vector) QuantizeInt8() *Int8 {
+	return QuantizeInt8(v)
+}
+
+// A [Vector] wrapper for the [QuantizeBinary] function.
+func (v Vector) QuantizeBinary() *Binary {
+	return QuantizeBinary(v)
+}
+
+// ############################################################################
+// Vector32 methods
+// ############################################################################
+
+// A [Vector32] wrapper for the [generic.Cosine] function.
+func (v Vector32) Cosine(other Vector32) (cosine float64, err error) {
+	return generic.Cosine(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.DotProduct] function.
+func (v Vector32) DotProduct(other Vector32) (product float64, err error) {
+	return generic.DotProduct(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Magnitude] function.
+func (v Vector32) Magnitude() (length float64) {
+	return generic.Magnitude(v)
+}
+
+// A [Vector32] wrapper for the [generic.Len] function.
+func (v Vector32) Len() (elements int) {
+	return generic.Len(v)
+}
+
+// A [Vector32] wrapper for the [generic.Add] function.
+func (v Vector32) Add(other Vector32) (sum Vector32, err error) {
+	return generic.Add(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.AddInPlace] function.
+func (v Vector32) AddInPlace(other Vector32) (err error) {
+	return generic.AddInPlace(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Subtract] function.
+func (v Vector32) Subtract(other Vector32) (difference Vector32, err error) {
+	return generic.Subtract(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.SubtractInPlace] function.
+func (v Vector32) SubtractInPlace(other Vector32) (err error) {
+	return generic.SubtractInPlace(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Multiply] function.
+func (v Vector32) Multiply(other Vector32) (product Vector32, err error) {
+	return generic.Multiply(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.MultiplyInPlace] function.
+func (v Vector32) MultiplyInPlace(other Vector32) (err error) {
+	return generic.MultiplyInPlace(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Scale] function.
+func (v Vector32) Scale(factor float64) (scaled Vector32) {
+	return generic.Scale(v, factor)
+}
+
+// A [Vector32] wrapper for the [generic.ScaleInPlace] function.
+func (v Vector32) ScaleInPlace(factor float64) {
+	generic.ScaleInPlace(v, factor)
+}
+
+// A [Vector32] wrapper for the [generic.Clone] function.
+func (v Vector32) Clone() (clone Vector32) {
+	return generic.Clone(v)
+}
+
+// A [Vector32] wrapper for the [generic.Length] function.
+func (v Vector32) Length(norm Norm) (length float64, err error) {
+	return generic.Length(v, norm)
+}
+
+// A [Vector32] wrapper for the [generic.Normalize] function.
+func (v Vector32) Normalize(norm Norm) (normalized Vector32, err error) {
+	return generic.Normalize(v, norm)
+}
+
+// A [Vector32] wrapper for the [generic.NormalizeInPlace] function.
+func (v Vector32) NormalizeInPlace(norm Norm) (err error) {
+	return generic.NormalizeInPlace(v, norm)
+}
+
+// A [Vector32] wrapper for the [generic.Euclidean] function.
+func (v Vector32) Euclidean(other Vector32) (distance float64, err error) {
+	return generic.Euclidean(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Manhattan] function.
+func (v Vector32) Manhattan(other Vector32) (distance float64, err error) {
+	return generic.Manhattan(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Chebyshev] function.
+func (v Vector32) Chebyshev(other Vector32) (distance float64, err error) {
+	return generic.Chebyshev(v, other)
+}
+
+// A [Vector32] wrapper for the [generic.Minkowski] function.
+func (v Vector32) Minkowski(other Vector32, p float64) (distance float64, err error) {
+	return generic.Minkowski(v, other, p)
+}
+
+// A [Vector32] wrapper for the [generic.Angular] function.
+func (v Vector32) Angular(other Vector32) (distance float64, err error) {
+	return generic.Angular(v, other)
+}
+
+// A [Vector32] wrapper for the [QuantizeInt8] function.
+func (v Vector32) QuantizeInt8() *Int8 {
+	return QuantizeInt8(v)
+}
+
+// A [Vector32] wrapper for the [QuantizeBinary] function.
+func (v Vector32) QuantizeBinary() *Binary {
+	return QuantizeBinary(v)
+}
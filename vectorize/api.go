@@ -0,0 +1,376 @@
+package vectorize
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.rtnl.ai/nlp/errors"
+)
+
+// ############################################################################
+// APIError
+// ############################################################################
+
+// APIError is returned by the embedding API clients when the API responds with
+// an error status. It wraps the matching error from the errors package, so
+// [errors.Is] can be used to check for [errors.ErrRateLimited],
+// [errors.ErrServerError], [errors.ErrUnauthorized] or [errors.ErrBadRequest],
+// and [errors.As] to get the status code and message.
+type APIError struct {
+	StatusCode int           // the HTTP status code of the response
+	Message    string        // the error message from the response body
+	RetryAfter time.Duration // from the "Retry-After" header, if present
+}
+
+// Returns the status code and message of the [APIError].
+func (e *APIError) Error() string {
+	return fmt.Sprintf("embeddings API error: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Returns the error from the errors package which matches the status code.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return errors.ErrRateLimited
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return errors.ErrUnauthorized
+	case e.StatusCode >= 500:
+		return errors.ErrServerError
+	}
+	return errors.ErrBadRequest
+}
+
+// Returns true if the request may succeed when it is retried, which is the case
+// for rate limits, timeouts and server errors.
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusRequestTimeout || e.StatusCode >= 500
+}
+
+// Returns an [APIError] for the response, reading the message from its body.
+func newAPIError(response *http.Response) *APIError {
+	err := &APIError{
+		StatusCode: response.StatusCode,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After")),
+	}
+
+	// Read the message from the JSON error formats used by the APIs, falling back
+	// to the raw body
+	data, _ := io.ReadAll(io.LimitReader(response.Body, 1<<16))
+	var body struct {
+		Detail  string `json:"detail"`
+		Message string `json:"message"`
+		Error   struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &body) == nil {
+		err.Message = cmp.Or(body.Detail, body.Error.Message, body.Message)
+	}
+	if err.Message == "" {
+		err.Message = strings.TrimSpace(string(data))
+	}
+	return err
+}
+
+// Returns the duration from a "Retry-After" header, which is either a number of
+// seconds or an HTTP date, or zero if the header is missing or invalid.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(header, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
+
+// ############################################################################
+// API client
+// ############################################################################
+
+// Defaults for the embedding API clients.
+const (
+	DefaultAPITimeout     = 60 * time.Second
+	DefaultAPIMaxRetries  = 3
+	DefaultAPIMinBackoff  = 500 * time.Millisecond
+	DefaultAPIMaxBackoff  = 30 * time.Second
+	DefaultAPIConcurrency = 4
+)
+
+// The HTTP configuration shared by the embedding API clients, which makes JSON
+// requests with a timeout, retries with exponential backoff, and client-side
+// rate limits, and splits large inputs into concurrent batches.
+type apiClient struct {
+	client      *http.Client
+	timeout     time.Duration
+	maxRetries  int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	batchItems  int          // the maximum chunks per request; 0 is unlimited
+	batchTokens int          // the maximum estimated tokens per request; 0 is unlimited
+	concurrency int          // the maximum requests at once
+	rpm         int          // the requests per minute limit; 0 is unlimited
+	tpm         int          // the tokens per minute limit; 0 is unlimited
+	requests    *rateLimiter // created from rpm by setup
+	tokens      *rateLimiter // created from tpm by setup
+}
+
+// Returns an [apiClient] with the default options and the API's per-request
+// batch limits.
+func newAPIClient(batchItems, batchTokens int) apiClient {
+	return apiClient{
+		batchItems:  batchItems,
+		batchTokens: batchTokens,
+		concurrency: DefaultAPIConcurrency,
+		client:      &http.Client{},
+		timeout:     DefaultAPITimeout,
+		maxRetries:  DefaultAPIMaxRetries,
+		minBackoff:  DefaultAPIMinBackoff,
+		maxBackoff:  DefaultAPIMaxBackoff,
+	}
+}
+
+// Validates the options and creates the rate limiters, returning an error if
+// any of the options are invalid.
+func (c *apiClient) setup() error {
+	if c.client == nil {
+		return errors.Join(errors.ErrMissingConfig, errors.New("the http client cannot be nil"))
+	}
+	if c.timeout < 0 || c.minBackoff < 0 || c.maxBackoff < 0 {
+		return errors.Join(errors.ErrMissingConfig, errors.New("the timeout and backoff durations cannot be negative"))
+	}
+	if c.maxRetries < 0 {
+		return errors.Join(errors.ErrMissingConfig, errors.New("the maximum number of retries cannot be negative"))
+	}
+	if c.batchItems < 0 || c.batchTokens < 0 {
+		return errors.Join(errors.ErrMissingConfig, errors.New("the batch limits cannot be negative"))
+	}
+	if c.concurrency < 1 {
+		return errors.Join(errors.ErrMissingConfig, errors.New("the concurrency must be at least one"))
+	}
+	if c.rpm < 0 || c.tpm < 0 {
+		return errors.Join(errors.ErrMissingConfig, errors.New("the rate limits cannot be negative"))
+	}
+
+	c.requests = newRateLimiter(c.rpm)
+	c.tokens = newRateLimiter(c.tpm)
+	return nil
+}
+
+// Posts the request as JSON to the endpoint with the headers and decodes the
+// JSON response into response. The estimated tokens of the request are reserved
+// from the tokens per minute limit before each attempt and returned if it fails;
+// the caller settles them with the usage of the response (see
+// [apiClient.settle]). Rate limits, timeouts, server errors and network
+// errors are retried up to the maximum number of retries, waiting for the
+// "Retry-After" duration if the API sent one, or otherwise an exponential
+// backoff. Returns an [APIError] if the API responds with an error status, or
+// the context's error if it is done before the request succeeds.
+func (c *apiClient) post(ctx context.Context, endpoint string, headers map[string]string, tokens int, request, response any) (err error) {
+	var data []byte
+	if data, err = json.Marshal(request); err != nil {
+		return errors.Join(err, errors.New("error encoding embeddings request"))
+	}
+
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		if retry, err = c.attempt(ctx, endpoint, headers, tokens, data, response); err == nil {
+			return nil
+		}
+
+		// Only retry errors which may succeed next time while the context allows
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retry || attempt >= c.maxRetries {
+			return err
+		}
+
+		var retryAfter time.Duration
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			retryAfter = apiErr.RetryAfter
+		}
+		if err = sleep(ctx, c.backoff(attempt, retryAfter)); err != nil {
+			return err
+		}
+	}
+}
+
+// Makes a single request with the configured timeout, returning whether the
+// request may succeed if it is retried when it fails.
+func (c *apiClient) attempt(ctx context.Context, endpoint string, headers map[string]string, tokens int, data []byte, response any) (retry bool, err error) {
+	// Wait for the rate limits, reserving the estimated tokens so concurrent
+	// requests cannot exceed the tokens per minute before their usage is known
+	if err = c.requests.wait(ctx, 1); err != nil {
+		return false, err
+	}
+	if err = c.tokens.wait(ctx, float64(tokens)); err != nil {
+		return false, err
+	}
+	defer func() {
+		// Return the reservation of a failed attempt
+		if err != nil {
+			c.tokens.charge(-float64(tokens))
+		}
+	}()
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var request *http.Request
+	if request, err = http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data)); err != nil {
+		return false, errors.Join(err, errors.New("error creating embeddings http request"))
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	// Network errors and timeouts can be retried
+	var resp *http.Response
+	if resp, err = c.client.Do(request); err != nil {
+		return true, errors.Join(err, errors.New("embeddings request failed"))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := newAPIError(resp)
+		return apiErr.Retryable(), apiErr
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return false, errors.Join(err, errors.New("error decoding embeddings response"))
+	}
+	return false, nil
+}
+
+// Settles the tokens reserved for a successful request (see [apiClient.post])
+// with the tokens the API reports it used. If the API does not report its
+// usage the reservation is kept.
+func (c *apiClient) settle(reserved, used int) {
+	if used > 0 {
+		c.tokens.charge(float64(used - reserved))
+	}
+}
+
+// Returns the time to wait before retrying after the attempt (starting at 0):
+// the "Retry-After" duration if the API sent one, otherwise the minimum
+// backoff doubled for each attempt, up to the maximum backoff.
+func (c *apiClient) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	backoff := c.minBackoff
+	for range attempt {
+		if backoff *= 2; backoff >= c.maxBackoff {
+			return c.maxBackoff
+		}
+	}
+	return min(backoff, c.maxBackoff)
+}
+
+// Waits for the duration, returning early with the context's error if it is
+// done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ############################################################################
+// Rate limiter
+// ############################################################################
+
+// A token bucket which refills at a rate per minute, up to one minute's worth,
+// and which can go into debt when more is used than was waited for (e.g. API
+// tokens, which are estimated before a request and only known after it). A nil
+// limiter is unlimited.
+type rateLimiter struct {
+	sync.Mutex
+	perMinute float64
+	available float64
+	updated   time.Time
+}
+
+// The longest a [rateLimiter] waits before checking what is available again.
+const rateLimiterPoll = 50 * time.Millisecond
+
+// Returns a [rateLimiter] for the rate per minute, or nil if it is not positive.
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		perMinute: float64(perMinute),
+		available: float64(perMinute),
+		updated:   time.Now(),
+	}
+}
+
+// Waits until n is available (or any debt is paid when n is 0) and takes it,
+// returning early with the context's error if it is done first. If n is more
+// than the rate per minute it waits for a full minute's worth and goes into
+// debt for the rest.
+func (l *rateLimiter) wait(ctx context.Context, n float64) error {
+	if l == nil {
+		return nil
+	}
+
+	need := min(n, l.perMinute)
+	for {
+		l.Lock()
+		l.refill()
+		if l.available >= need {
+			l.available -= n
+			l.Unlock()
+			return nil
+		}
+		delay := time.Duration((need - l.available) / l.perMinute * float64(time.Minute))
+		l.Unlock()
+
+		// Check again at least every poll interval, since settled reservations
+		// may return tokens before the refill
+		if err := sleep(ctx, min(max(delay, time.Millisecond), rateLimiterPoll)); err != nil {
+			return err
+		}
+	}
+}
+
+// Takes n without waiting, which may put the limiter into debt.
+func (l *rateLimiter) charge(n float64) {
+	if l == nil {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.refill()
+	l.available -= n
+}
+
+// Adds what has been refilled since the last update; must hold the lock.
+func (l *rateLimiter) refill() {
+	now := time.Now()
+	l.available = min(l.perMinute, l.available+now.Sub(l.updated).Minutes()*l.perMinute)
+	l.updated = now
+}
@@ -0,0 +1,165 @@
+package vectorize
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// BatchError
+// ############################################################################
+
+// BatchError is returned by the embedding API clients when some of the batches
+// of a request fail, alongside the embeddings of the batches which succeeded
+// (with nil embeddings for the chunks which failed). [errors.Is] and
+// [errors.As] match the errors of every failed batch, e.g. an [APIError].
+type BatchError struct {
+	Failures []*BatchFailure // in the order of the chunks
+}
+
+// BatchFailure is a batch of chunks which failed to be embedded.
+type BatchFailure struct {
+	Start int   // the index of the first chunk in the batch
+	End   int   // the index after the last chunk in the batch
+	Err   error // the reason the batch failed
+}
+
+// Returns the number of failed batches and the first error.
+func (e *BatchError) Error() string {
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("embedding chunks [%d, %d) failed: %s", e.Failures[0].Start, e.Failures[0].End, e.Failures[0].Err)
+	}
+	return fmt.Sprintf("%d embedding batches failed, including chunks [%d, %d): %s", len(e.Failures), e.Failures[0].Start, e.Failures[0].End, e.Failures[0].Err)
+}
+
+// Returns the errors of the failed batches.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// Returns the indexes of the chunks which failed in ascending order.
+func (e *BatchError) Indexes() (indexes []int) {
+	for _, failure := range e.Failures {
+		for i := failure.Start; i < failure.End; i++ {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// ############################################################################
+// Batching
+// ############################################################################
+
+// EstimateTokens returns a conservative estimate of the number of tokens the
+// embedding APIs will count for the text, which is used to split requests into
+// batches under the APIs' per-request token limits without a tokenizer for each
+// model: one token for every three ASCII bytes (English averages about four)
+// plus one for every other character, and at least one token.
+func EstimateTokens(text string) (tokens int) {
+	var ascii int
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			tokens++
+		}
+	}
+	return max(tokens+(ascii+2)/3, 1)
+}
+
+// Returns the total estimated tokens of the chunks (see [EstimateTokens]).
+func estimateBatchTokens(chunks []string) (tokens int) {
+	for _, chunk := range chunks {
+		tokens += EstimateTokens(chunk)
+	}
+	return tokens
+}
+
+// Returns the [start, end) ranges of the chunks for batches of at most maxItems
+// chunks and maxTokens estimated tokens (see [EstimateTokens]); 0 is unlimited.
+// A chunk with more than maxTokens is put in a batch by itself.
+func splitBatches(chunks []string, maxItems, maxTokens int) (batches [][2]int) {
+	start, tokens := 0, 0
+	for i, chunk := range chunks {
+		estimate := EstimateTokens(chunk)
+		full := maxItems > 0 && i-start >= maxItems
+		overflow := maxTokens > 0 && tokens+estimate > maxTokens
+		if i > start && (full || overflow) {
+			batches = append(batches, [2]int{start, i})
+			start, tokens = i, 0
+		}
+		tokens += estimate
+	}
+	if start < len(chunks) {
+		batches = append(batches, [2]int{start, len(chunks)})
+	}
+	return batches
+}
+
+// Splits the chunks into batches (see [splitBatches]) and embeds each batch with
+// the embed function, running at most concurrency batches at once, then returns
+// the embeddings in the order of the chunks. If any batch fails, the other
+// batches are still embedded and a [BatchError] is returned with the
+// embeddings that succeeded. Batches which have not started when the context is
+// done fail with the context's error.
+func embedBatches(ctx context.Context, chunks []string, maxItems, maxTokens, concurrency int, embed func(ctx context.Context, batch []string) ([]vector.Vector, error)) (embeddings []vector.Vector, err error) {
+	batches := splitBatches(chunks, maxItems, maxTokens)
+	embeddings = make([]vector.Vector, len(chunks))
+	failures := make([]*BatchFailure, len(batches))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, max(concurrency, 1))
+	for b, batch := range batches {
+		// Wait for a free slot unless the context is done
+		if ctx.Err() != nil {
+			failures[b] = &BatchFailure{Start: batch[0], End: batch[1], Err: ctx.Err()}
+			continue
+		}
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			failures[b] = &BatchFailure{Start: batch[0], End: batch[1], Err: ctx.Err()}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			start, end := batch[0], batch[1]
+			result, err := embed(ctx, chunks[start:end])
+			if err == nil && len(result) != end-start {
+				err = errors.Join(errors.ErrUnequalLengthInputs, fmt.Errorf("expected %d embeddings but got %d", end-start, len(result)))
+			}
+			if err != nil {
+				failures[b] = &BatchFailure{Start: start, End: end, Err: err}
+				return
+			}
+			copy(embeddings[start:end], result)
+		}()
+	}
+	wg.Wait()
+
+	// Report the failed batches in order
+	var batchErr BatchError
+	for _, failure := range failures {
+		if failure != nil {
+			batchErr.Failures = append(batchErr.Failures, failure)
+		}
+	}
+	if len(batchErr.Failures) > 0 {
+		return embeddings, &batchErr
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,531 @@
+package vectorize
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// CachedVectorizer
+// ############################################################################
+
+/*
+CachedVectorizer wraps another [Vectorizer], such as an embedding API client, and
+stores the vectors it creates in a [CacheStore] so the same chunks are not
+vectorized again, e.g. across runs when using a [FileCacheStore]. Vectors are
+stored by a hash of the model and the chunk (see [CacheKey]).
+
+Usage Example:
+
+	voyage, err := vectorize.NewVoyageAIEmbedder()
+	checkErr(err)
+
+	// Cache the embeddings on disk; the model is taken from the embedder
+	store, err := vectorize.NewFileCacheStore("embeddings-cache")
+	checkErr(err)
+	cached, err := vectorize.NewCachedVectorizer(voyage, vectorize.CachedVectorizerWithStore(store))
+	checkErr(err)
+
+	// Only the chunks which are not in the cache are sent to the API
+	embeddings, err := cached.VectorizeAll([]string{"A simple test.", "Number two!"})
+	checkErr(err)
+
+	stats := cached.Stats()
+	fmt.Printf("%d hits, %d misses\n", stats.Hits, stats.Misses)
+*/
+type CachedVectorizer struct {
+	vectorizer Vectorizer
+	store      CacheStore
+	model      string
+	hits       atomic.Int64
+	misses     atomic.Int64
+}
+
+// The number of vectors kept by the default [LRUCacheStore].
+const DefaultCacheCapacity = 10_000
+
+// Ensure [CachedVectorizer] meets the [BatchVectorizer] interface requirements.
+var _ BatchVectorizer = &CachedVectorizer{}
+
+// Creates a new [CachedVectorizer] around the vectorizer. The model is used to
+// keep the vectors of different models apart in the store; it defaults to the
+// vectorizer's Model() (e.g. [VoyageAIEmbedder.Model]), otherwise it must be set
+// with [CachedVectorizerWithModel] or [errors.ErrMissingConfig] is returned. If
+// other options change the vectors, such as [OpenAIEmbedderWithDimensions],
+// include them in the model so they are not mixed up.
+//
+// Defaults:
+//   - Store: [LRUCacheStore] with [DefaultCacheCapacity]
+//   - Model: the vectorizer's Model(), if it has one
+func NewCachedVectorizer(vectorizer Vectorizer, opts ...CachedVectorizerOption) (cached *CachedVectorizer, err error) {
+	cached = &CachedVectorizer{vectorizer: vectorizer}
+	if named, ok := vectorizer.(interface{ Model() string }); ok {
+		cached.model = named.Model()
+	}
+
+	for _, fn := range opts {
+		fn(cached)
+	}
+
+	if cached.store == nil {
+		cached.store = NewLRUCacheStore(DefaultCacheCapacity)
+	}
+
+	if cached.vectorizer == nil {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the vectorizer cannot be nil"))
+	}
+	if cached.model == "" {
+		return nil, errors.Join(
+			errors.ErrMissingConfig,
+			errors.New("field 'model' is required for a vectorizer without a Model() method; use option 'CachedVectorizerWithModel()'."),
+		)
+	}
+
+	return cached, nil
+}
+
+// Returns the [CachedVectorizer]s wrapped vectorizer.
+func (c *CachedVectorizer) Vectorizer() Vectorizer {
+	return c.vectorizer
+}
+
+// Returns the [CachedVectorizer]s configured store.
+func (c *CachedVectorizer) Store() CacheStore {
+	return c.store
+}
+
+// Returns the [CachedVectorizer]s configured model.
+func (c *CachedVectorizer) Model() string {
+	return c.model
+}
+
+// CacheStats are the number of chunks the [CachedVectorizer] found in its store
+// (hits) and had to vectorize (misses).
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
+// Returns the fraction of the chunks which were found in the store, or 0 if
+// there have been none.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0.0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Returns the [CachedVectorizer]s hit and miss counts.
+func (c *CachedVectorizer) Stats() CacheStats {
+	return CacheStats{Hits: int(c.hits.Load()), Misses: int(c.misses.Load())}
+}
+
+// ############################################################################
+// CachedVectorizer Options
+// ############################################################################
+
+// CachedVectorizerOption functions modify a [CachedVectorizer].
+type CachedVectorizerOption func(c *CachedVectorizer)
+
+// CachedVectorizerWithStore sets the [CacheStore] the [CachedVectorizer] keeps
+// the vectors in.
+func CachedVectorizerWithStore(store CacheStore) CachedVectorizerOption {
+	return func(c *CachedVectorizer) {
+		c.store = store
+	}
+}
+
+// CachedVectorizerWithModel sets the model the [CachedVectorizer] uses in the
+// keys of the vectors (see [CacheKey]).
+func CachedVectorizerWithModel(model string) CachedVectorizerOption {
+	return func(c *CachedVectorizer) {
+		c.model = model
+	}
+}
+
+// ############################################################################
+// CachedVectorizer Functionality
+// ############################################################################
+
+// Returns the key a vector is stored under for the model and chunk, which is the
+// hex encoded SHA-256 hash of both.
+func CacheKey(model, chunk string) string {
+	hash := sha256.New()
+	hash.Write([]byte(model))
+	hash.Write([]byte{0})
+	hash.Write([]byte(chunk))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Returns the vector for the chunk from the store, or vectorizes it with the
+// wrapped vectorizer and stores it.
+func (c *CachedVectorizer) Vectorize(chunk string) (vector vector.Vector, err error) {
+	return c.VectorizeContext(context.Background(), chunk)
+}
+
+// Returns the vector for the chunk from the store, or vectorizes it with the
+// wrapped vectorizer and stores it, passing on the context if the vectorizer
+// supports it (e.g. [VoyageAIEmbedder.VectorizeContext]).
+func (c *CachedVectorizer) VectorizeContext(ctx context.Context, chunk string) (embedding vector.Vector, err error) {
+	key := CacheKey(c.model, chunk)
+
+	var ok bool
+	if embedding, ok, err = c.store.Get(key); err != nil {
+		return nil, err
+	}
+	if ok {
+		c.hits.Add(1)
+		return embedding, nil
+	}
+	c.misses.Add(1)
+
+	if vectorizer, ok := c.vectorizer.(interface {
+		VectorizeContext(context.Context, string) (vector.Vector, error)
+	}); ok {
+		embedding, err = vectorizer.VectorizeContext(ctx, chunk)
+	} else {
+		embedding, err = c.vectorizer.Vectorize(chunk)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.store.Put(key, embedding); err != nil {
+		return nil, err
+	}
+	return embedding, nil
+}
+
+// Returns the vectors for the chunks (see [CachedVectorizer.VectorizeAllContext]).
+func (c *CachedVectorizer) VectorizeAll(chunks []string) (embeddings []vector.Vector, err error) {
+	return c.VectorizeAllContext(context.Background(), chunks)
+}
+
+// Returns the vectors for the chunks in order, getting those in the store and
+// vectorizing the others, each distinct chunk only once. The misses are sent to
+// the wrapped vectorizer at once if it is a [BatchVectorizer] (passing on the
+// context if it has a VectorizeAllContext method), otherwise one at a time. If
+// the vectorizer returns a [BatchError], the vectors which succeeded are stored
+// and returned along with a [BatchError] for the indexes of the chunks.
+func (c *CachedVectorizer) VectorizeAllContext(ctx context.Context, chunks []string) (embeddings []vector.Vector, err error) {
+	embeddings = make([]vector.Vector, len(chunks))
+
+	// Get the chunks in the store, grouping the indexes of the missing chunks
+	var misses []string
+	var keys []string
+	indexes := make(map[string][]int)
+	for i, chunk := range chunks {
+		key := CacheKey(c.model, chunk)
+		if _, ok := indexes[key]; ok {
+			// Already missed, so only vectorize the chunk once
+			indexes[key] = append(indexes[key], i)
+			c.misses.Add(1)
+			continue
+		}
+
+		var ok bool
+		if embeddings[i], ok, err = c.store.Get(key); err != nil {
+			return nil, err
+		}
+		if ok {
+			c.hits.Add(1)
+			continue
+		}
+
+		c.misses.Add(1)
+		indexes[key] = []int{i}
+		misses = append(misses, chunk)
+		keys = append(keys, key)
+	}
+	if len(misses) == 0 {
+		return embeddings, nil
+	}
+
+	// Vectorize and store the missing chunks
+	var vectors []vector.Vector
+	var batchErr *BatchError
+	if vectors, err = c.vectorizeAll(ctx, misses); err != nil && !errors.As(err, &batchErr) {
+		return nil, err
+	}
+	if len(vectors) != len(misses) {
+		return nil, errors.Join(errors.ErrUnequalLengthInputs, fmt.Errorf("expected %d vectors but got %d", len(misses), len(vectors)))
+	}
+
+	var failed []int
+	for m, embedding := range vectors {
+		if embedding == nil {
+			failed = append(failed, indexes[keys[m]]...)
+			continue
+		}
+		if err := c.store.Put(keys[m], embedding); err != nil {
+			return nil, err
+		}
+		for _, i := range indexes[keys[m]] {
+			embeddings[i] = embedding
+		}
+	}
+
+	if batchErr != nil {
+		return embeddings, remapBatchError(batchErr, failed)
+	}
+	return embeddings, nil
+}
+
+// Vectorizes the chunks with the wrapped vectorizer, in a single call if it is
+// a [BatchVectorizer].
+func (c *CachedVectorizer) vectorizeAll(ctx context.Context, chunks []string) (vectors []vector.Vector, err error) {
+	switch vectorizer := c.vectorizer.(type) {
+	case interface {
+		VectorizeAllContext(context.Context, []string) ([]vector.Vector, error)
+	}:
+		return vectorizer.VectorizeAllContext(ctx, chunks)
+	case BatchVectorizer:
+		return vectorizer.VectorizeAll(chunks)
+	}
+
+	vectors = make([]vector.Vector, len(chunks))
+	for i, chunk := range chunks {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		if vectors[i], err = c.vectorizer.Vectorize(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return vectors, nil
+}
+
+// Returns a [BatchError] for the failed indexes of the original chunks, which
+// are grouped into contiguous ranges with the first error of the batch error.
+func remapBatchError(batchErr *BatchError, failed []int) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	slices.Sort(failed)
+
+	remapped := &BatchError{}
+	cause := batchErr.Failures[0].Err
+	for _, i := range failed {
+		if n := len(remapped.Failures); n > 0 && remapped.Failures[n-1].End == i {
+			remapped.Failures[n-1].End++
+			continue
+		}
+		remapped.Failures = append(remapped.Failures, &BatchFailure{Start: i, End: i + 1, Err: cause})
+	}
+	return remapped
+}
+
+// ############################################################################
+// CacheStore interface
+// ############################################################################
+
+// CacheStore is the storage of a [CachedVectorizer], which must be safe to use
+// from several goroutines at once.
+type CacheStore interface {
+	// Returns the vector stored under the key, or false if there is none.
+	Get(key string) (vector vector.Vector, ok bool, err error)
+	// Stores the vector under the key, replacing any vector already there.
+	Put(key string, vector vector.Vector) (err error)
+}
+
+// ############################################################################
+// LRUCacheStore
+// ############################################################################
+
+// LRUCacheStore is an in-memory [CacheStore] which keeps up to a number of
+// vectors, evicting the least recently used vector when it is full.
+type LRUCacheStore struct {
+	sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // most recently used at the front
+}
+
+// Ensure [LRUCacheStore] meets the [CacheStore] interface requirements.
+var _ CacheStore = &LRUCacheStore{}
+
+// An entry in the [LRUCacheStore.order] list.
+type lruEntry struct {
+	key    string
+	vector vector.Vector
+}
+
+// Returns an [LRUCacheStore] which keeps up to capacity vectors; a capacity of
+// 0 or less is unlimited.
+func NewLRUCacheStore(capacity int) *LRUCacheStore {
+	return &LRUCacheStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Returns a copy of the vector stored under the key and marks it as the most
+// recently used, or false if there is none.
+func (s *LRUCacheStore) Get(key string) (embedding vector.Vector, ok bool, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var element *list.Element
+	if element, ok = s.entries[key]; !ok {
+		return nil, false, nil
+	}
+	s.order.MoveToFront(element)
+	return vector.Clone(element.Value.(*lruEntry).vector), true, nil
+}
+
+// Stores a copy of the vector under the key as the most recently used, evicting
+// the least recently used vector if the store is full.
+func (s *LRUCacheStore) Put(key string, embedding vector.Vector) (err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		element.Value.(*lruEntry).vector = vector.Clone(embedding)
+		s.order.MoveToFront(element)
+		return nil
+	}
+
+	s.entries[key] = s.order.PushFront(&lruEntry{key: key, vector: vector.Clone(embedding)})
+	if s.capacity > 0 && s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// Returns the number of vectors in the [LRUCacheStore].
+func (s *LRUCacheStore) Len() int {
+	s.Lock()
+	defer s.Unlock()
+	return s.order.Len()
+}
+
+// Returns the [LRUCacheStore]s configured capacity.
+func (s *LRUCacheStore) Capacity() int {
+	return s.capacity
+}
+
+// ############################################################################
+// FileCacheStore
+// ############################################################################
+
+// FileCacheStore is an on-disk [CacheStore] which keeps each vector in its own
+// file in a directory, so it persists across runs and can be shared by several
+// processes. The files are named by the key under a subdirectory of the key's
+// first two characters, and contain the elements as little-endian float64s.
+// Keys must be hex strings, such as those from [CacheKey].
+type FileCacheStore struct {
+	dir string
+}
+
+// Ensure [FileCacheStore] meets the [CacheStore] interface requirements.
+var _ CacheStore = &FileCacheStore{}
+
+// Returns a [FileCacheStore] in the directory, which is created if it does not
+// exist.
+func NewFileCacheStore(dir string) (store *FileCacheStore, err error) {
+	if dir == "" {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the cache directory is required"))
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Join(err, errors.New("error creating cache directory"))
+	}
+	return &FileCacheStore{dir: dir}, nil
+}
+
+// Returns the [FileCacheStore]s configured directory.
+func (s *FileCacheStore) Dir() string {
+	return s.dir
+}
+
+// Returns the vector stored in the key's file, or false if there is none.
+func (s *FileCacheStore) Get(key string) (embedding vector.Vector, ok bool, err error) {
+	var path string
+	if path, err = s.path(key); err != nil {
+		return nil, false, err
+	}
+
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Join(err, errors.New("error reading cached vector"))
+	}
+	if len(data)%8 != 0 {
+		return nil, false, fmt.Errorf("cached vector %q is not a whole number of float64 values", key)
+	}
+
+	embedding = make(vector.Vector, len(data)/8)
+	for i := range embedding {
+		embedding[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return embedding, true, nil
+}
+
+// Writes the vector to the key's file, replacing it atomically so readers never
+// see a partially written vector.
+func (s *FileCacheStore) Put(key string, embedding vector.Vector) (err error) {
+	var path string
+	if path, err = s.path(key); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Join(err, errors.New("error creating cache directory"))
+	}
+
+	data := make([]byte, 0, len(embedding)*8)
+	for _, e := range embedding {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(e))
+	}
+
+	// Write to a temporary file in the same directory then rename it over the
+	// vector's file
+	var tmp *os.File
+	if tmp, err = os.CreateTemp(filepath.Dir(path), key+".*.tmp"); err != nil {
+		return errors.Join(err, errors.New("error writing cached vector"))
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Join(err, errors.New("error writing cached vector"))
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Join(err, errors.New("error writing cached vector"))
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errors.Join(err, errors.New("error writing cached vector"))
+	}
+	return nil
+}
+
+// Returns the path of the key's file, or an error if the key is not a hex
+// string of at least two characters.
+func (s *FileCacheStore) path(key string) (string, error) {
+	if len(key) < 2 {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	for _, r := range key {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
+			return "", fmt.Errorf("invalid cache key %q", key)
+		}
+	}
+	return filepath.Join(s.dir, key[:2], key), nil
+}
@@ -0,0 +1,193 @@
+package vectorize_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+// A [vectorize.Vectorizer] which returns [len(chunk)] and counts its calls.
+type countingVectorizer struct {
+	calls atomic.Int32
+}
+
+func (v *countingVectorizer) Vectorize(chunk string) (vector.Vector, error) {
+	v.calls.Add(1)
+	return vector.Vector{float64(len(chunk))}, nil
+}
+
+// A [vectorize.BatchVectorizer] which fails every chunk containing "fail" with a
+// [vectorize.BatchError].
+type failingBatchVectorizer struct {
+	countingVectorizer
+	batches [][]string
+}
+
+func (v *failingBatchVectorizer) VectorizeAll(chunks []string) ([]vector.Vector, error) {
+	v.batches = append(v.batches, chunks)
+	vectors := make([]vector.Vector, len(chunks))
+	batchErr := &vectorize.BatchError{}
+	for i, chunk := range chunks {
+		if chunk == "fail" {
+			batchErr.Failures = append(batchErr.Failures, &vectorize.BatchFailure{Start: i, End: i + 1, Err: errors.ErrServerError})
+			continue
+		}
+		vectors[i], _ = v.Vectorize(chunk)
+	}
+	if len(batchErr.Failures) > 0 {
+		return vectors, batchErr
+	}
+	return vectors, nil
+}
+
+func TestNewCachedVectorizer(t *testing.T) {
+	t.Run("ModelFromVectorizer", func(t *testing.T) {
+		voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, new(atomic.Int32), 1, http.StatusOK))
+		cached, err := vectorize.NewCachedVectorizer(voyage)
+		require.NoError(t, err)
+		require.Equal(t, "test-model", cached.Model())
+		require.IsType(t, &vectorize.LRUCacheStore{}, cached.Store())
+		require.Equal(t, vectorize.DefaultCacheCapacity, cached.Store().(*vectorize.LRUCacheStore).Capacity())
+	})
+
+	t.Run("ErrorMissingModel", func(t *testing.T) {
+		_, err := vectorize.NewCachedVectorizer(&countingVectorizer{})
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+}
+
+func TestCachedVectorizer(t *testing.T) {
+	t.Run("Vectorize", func(t *testing.T) {
+		inner := &countingVectorizer{}
+		cached, err := vectorize.NewCachedVectorizer(inner, vectorize.CachedVectorizerWithModel("counting"))
+		require.NoError(t, err)
+
+		for range 3 {
+			embedding, err := cached.Vectorize("abc")
+			require.NoError(t, err)
+			require.Equal(t, vector.Vector{3}, embedding)
+		}
+		require.Equal(t, int32(1), inner.calls.Load())
+		require.Equal(t, vectorize.CacheStats{Hits: 2, Misses: 1}, cached.Stats())
+		require.InDelta(t, 2.0/3.0, cached.Stats().HitRate(), 1e-9)
+
+		// Vectorizers without a batch method are called for each miss
+		embeddings, err := cached.VectorizeAll([]string{"abc", "de", "f", "de"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{3}, {2}, {1}, {2}}, embeddings)
+		require.Equal(t, int32(3), inner.calls.Load())
+	})
+
+	t.Run("VoyageAIBatchOnlyMisses", func(t *testing.T) {
+		var requests atomic.Int32
+		voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, &requests, 4, http.StatusOK))
+		cached, err := vectorize.NewCachedVectorizer(voyage)
+		require.NoError(t, err)
+
+		embeddings, err := cached.VectorizeAll([]string{"a", "bb"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{1, 0}, {2, 1}}, embeddings)
+
+		// Only "ccc" is sent, once, so it is the first input of the request
+		embeddings, err = cached.VectorizeAll([]string{"bb", "ccc", "a", "ccc"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{2, 1}, {3, 0}, {1, 0}, {3, 0}}, embeddings)
+
+		// Everything is cached now, so no request is made
+		embeddings, err = cached.VectorizeAll([]string{"ccc", "bb"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{3, 0}, {2, 1}}, embeddings)
+
+		require.Equal(t, int32(2), requests.Load())
+		require.Equal(t, 8, voyage.TotalTokensUsed())
+		require.Equal(t, vectorize.CacheStats{Hits: 4, Misses: 4}, cached.Stats())
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		inner := &failingBatchVectorizer{}
+		cached, err := vectorize.NewCachedVectorizer(inner, vectorize.CachedVectorizerWithModel("failing"))
+		require.NoError(t, err)
+
+		_, err = cached.Vectorize("hit")
+		require.NoError(t, err)
+
+		embeddings, err := cached.VectorizeAll([]string{"hit", "fail", "ok", "fail", "fail"})
+		require.ErrorIs(t, err, errors.ErrServerError)
+		require.Equal(t, []vector.Vector{{3}, nil, {2}, nil, nil}, embeddings)
+		require.Equal(t, [][]string{{"fail", "ok"}}, inner.batches)
+
+		var batchErr *vectorize.BatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, []int{1, 3, 4}, batchErr.Indexes())
+		require.Len(t, batchErr.Failures, 2)
+
+		// The successful vector was cached but the failed one was not
+		_, err = cached.VectorizeAll([]string{"ok", "fail"})
+		require.Error(t, err)
+		require.Equal(t, [][]string{{"fail", "ok"}, {"fail"}}, inner.batches)
+	})
+}
+
+func TestLRUCacheStore(t *testing.T) {
+	store := vectorize.NewLRUCacheStore(2)
+	require.NoError(t, store.Put("a", vector.Vector{1}))
+	require.NoError(t, store.Put("b", vector.Vector{2}))
+
+	// Using "a" makes "b" the least recently used, so it is evicted
+	embedding, ok, err := store.Get("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, vector.Vector{1}, embedding)
+
+	require.NoError(t, store.Put("c", vector.Vector{3}))
+	require.Equal(t, 2, store.Len())
+
+	_, ok, err = store.Get("b")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The store keeps copies of the vectors
+	embedding[0] = 100
+	embedding, _, _ = store.Get("a")
+	require.Equal(t, vector.Vector{1}, embedding)
+}
+
+func TestFileCacheStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	store, err := vectorize.NewFileCacheStore(dir)
+	require.NoError(t, err)
+	require.Equal(t, dir, store.Dir())
+
+	key := vectorize.CacheKey("model", "chunk")
+	_, ok, err := store.Get(key)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	expected := vector.Vector{0.5, -1.25, 3e-300}
+	require.NoError(t, store.Put(key, expected))
+
+	// The vector persists for another store in the same directory
+	store, err = vectorize.NewFileCacheStore(dir)
+	require.NoError(t, err)
+	embedding, ok, err := store.Get(key)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, expected, embedding)
+
+	// The file holds only the little-endian float64s
+	info, err := os.Stat(filepath.Join(dir, key[:2], key))
+	require.NoError(t, err)
+	require.Equal(t, int64(24), info.Size())
+
+	// Keys which are not hex strings are rejected
+	require.Error(t, store.Put("../escape", vector.Vector{1}))
+	_, _, err = store.Get("")
+	require.Error(t, err)
+}
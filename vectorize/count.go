@@ -21,10 +21,11 @@ type CountVectorizer struct {
 	stemmer     stem.Stemmer
 	typeCounter *tokenize.TypeCounter
 	method      VectorizationMethod
+	index       map[string][]int // the vocabulary indices of each stem
 }
 
-// Ensure [CountVectorizer] meets the [Vectorizer] interface requirements.
-var _ Vectorizer = &CountVectorizer{}
+// Ensure [CountVectorizer] meets the [SparseVectorizer] interface requirements.
+var _ SparseVectorizer = &CountVectorizer{}
 
 // Returns a new [CountVectorizer] instance.
 //
@@ -72,6 +73,13 @@ func NewCountVectorizer(opts ...CountVectorizerOption) (vectorizer *CountVectori
 		vectorizer.method = VectorizeOneHot
 	}
 
+	// Stem the vocab words once with the same stemmer as the type counter uses
+	vectorizer.index = make(map[string][]int, len(vectorizer.vocab))
+	for i, word := range vectorizer.vocab {
+		stem := vectorizer.typeCounter.Stemmer().Stem(word)
+		vectorizer.index[stem] = append(vectorizer.index[stem], i)
+	}
+
 	return vectorizer, nil
 }
 
@@ -123,6 +131,44 @@ func (v *CountVectorizer) Vectorize(chunk string) (vector vector.Vector, err err
 	return nil, errors.ErrMethodNotSupported
 }
 
+// Vectorizes the chunk of text as a [vector.Sparse] using the pre-configured
+// vocabulary and [VectorizationMethod], which has the same elements as the
+// vector from [CountVectorizer.Vectorize] without storing the zeros.
+func (v *CountVectorizer) VectorizeSparse(chunk string) (vec *vector.Sparse, err error) {
+	// We need to have set a vocabulary if we wish to use this function
+	if v.vocab == nil {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("a vocab is required to be set"))
+	}
+
+	if v.method != VectorizeOneHot && v.method != VectorizeFrequency {
+		return nil, errors.ErrMethodNotSupported
+	}
+
+	// Type count the text
+	var types map[string]int
+	if types, err = v.typeCounter.TypeCount(chunk); err != nil {
+		return nil, err
+	}
+
+	// Only store the vocabulary indices of the stems in the text
+	var (
+		indices []int
+		values  []float64
+	)
+	for stem, count := range types {
+		for _, i := range v.index[stem] {
+			indices = append(indices, i)
+			if v.method == VectorizeOneHot {
+				values = append(values, 1)
+			} else {
+				values = append(values, float64(count))
+			}
+		}
+	}
+
+	return vector.NewSparse(len(v.vocab), indices, values)
+}
+
 // VectorizeFrequency returns a frequency (count) encoding vector for the given
 // chunk of text and given vocabulary. The vector returned has a value of
 // the count of word instances within the chunk for each vocabulary word index.
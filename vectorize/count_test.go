@@ -167,6 +167,14 @@ func TestCountVectorizerVectorize(t *testing.T) {
 			Expected: vector.Vector{2, 2, 2, 2, 2, 2},
 			Error:    nil,
 		},
+		{
+			Name:     "SharedStemVocab_Frequency",
+			Method:   vectorize.VectorizeFrequency,
+			Vocab:    []string{"cat", "jump", "cats", "jumping"},
+			Text:     "the cats jumped over the cat",
+			Expected: vector.Vector{2, 1, 2, 1},
+			Error:    nil,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -187,5 +195,10 @@ func TestCountVectorizerVectorize(t *testing.T) {
 			require.NoError(t, err)
 		}
 		require.Equal(t, tc.Expected, actual)
+
+		// The sparse vector has the same elements
+		sparse, err := vectorizer.VectorizeSparse(tc.Text)
+		require.NoError(t, err)
+		require.Equal(t, tc.Expected, sparse.Dense())
 	}
 }
@@ -0,0 +1,390 @@
+package vectorize
+
+import (
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/ngrams"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// HashingFeature "enum"
+// ############################################################################
+
+// HashingFeature selects what the features hashed by a [HashingVectorizer] are
+// made of.
+type HashingFeature uint8
+
+const (
+	FeatureUnknown HashingFeature = iota
+	// Features are n-grams of word stems, joined by single spaces; with n = 1
+	// these are the types from [tokenize.TypeCounter.TypeCount].
+	FeatureStems
+	// Features are n-grams of the characters in the lowercase tokens joined by
+	// single spaces.
+	FeatureCharacters
+)
+
+// ############################################################################
+// HashingVectorizer
+// ############################################################################
+
+// The default number of buckets (the vector length) of a [HashingVectorizer].
+const DefaultHashingBuckets = 1 << 12
+
+/*
+HashingVectorizer can be used to vectorize text without a vocabulary (the
+"hashing trick"): each feature (a stem or an n-gram) is hashed with the stable
+64-bit FNV-1a hash into one of a fixed number of buckets, so vectors can be
+created from a stream of text without fitting on a corpus first; create with
+[NewHashingVectorizer].
+
+By default the hash also chooses a sign for each feature, which is added to or
+subtracted from its bucket, so the features which collide in a bucket tend to
+cancel out rather than accumulate, making inner products between the vectors
+unbiased estimates of those between the unhashed vectors (Weinberger et al.,
+2009).
+
+Usage example:
+
+	// Create a new vectorizer which counts stem bigrams in 1024 buckets
+	hashing, err := vectorize.NewHashingVectorizer(
+		vectorize.HashingVectorizerWithBuckets(1024),
+		vectorize.HashingVectorizerWithFeatures(vectorize.FeatureStems, 2),
+		vectorize.HashingVectorizerWithMethod(vectorize.VectorizeFrequency),
+	)
+
+	// Vectorize a chunk of text; no vocabulary or fitting is required
+	myVector, err := hashing.Vectorize("the cat sat on the mat")
+*/
+type HashingVectorizer struct {
+	lang        language.Language
+	tokenizer   tokenize.Tokenizer
+	stemmer     stem.Stemmer
+	typeCounter *tokenize.TypeCounter
+	method      VectorizationMethod
+	feature     HashingFeature
+	n           int
+	buckets     int
+	unsigned    bool
+}
+
+// Ensure [HashingVectorizer] meets the [SparseVectorizer] interface
+// requirements.
+var _ SparseVectorizer = &HashingVectorizer{}
+
+// Returns a new [HashingVectorizer] instance.
+//
+// Defaults:
+//   - Lang: [language.English]
+//   - Tokenizer: [tokenize.RegexTokenizer]
+//   - Stemmer: [stem.Porter2Stemmer]
+//   - TypeCounter: [tokenize.TypeCounter]
+//   - Method: [VectorizeFrequency]
+//   - Features: [FeatureStems] with n = 1
+//   - Buckets: [DefaultHashingBuckets]
+//   - Signed: true
+func NewHashingVectorizer(opts ...HashingVectorizerOption) (vectorizer *HashingVectorizer, err error) {
+	// Set options
+	vectorizer = &HashingVectorizer{}
+	for _, fn := range opts {
+		fn(vectorizer)
+	}
+
+	// Set defaults
+
+	if vectorizer.lang == language.Unknown {
+		vectorizer.lang = language.English
+	}
+
+	if vectorizer.tokenizer == nil {
+		vectorizer.tokenizer = tokenize.NewRegexTokenizer(tokenize.RegexTokenizerWithLanguage(vectorizer.lang))
+	}
+
+	if vectorizer.stemmer == nil {
+		if vectorizer.stemmer, err = stem.NewPorter2Stemmer(vectorizer.lang); err != nil {
+			return nil, err
+		}
+	}
+
+	if vectorizer.typeCounter == nil {
+		if vectorizer.typeCounter, err = tokenize.NewTypeCounter(
+			tokenize.TypeCounterWithLanguage(vectorizer.lang),
+			tokenize.TypeCounterWithTokenizer(vectorizer.tokenizer),
+			tokenize.TypeCounterWithStemmer(vectorizer.stemmer),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if vectorizer.method == VectorizeUnknown {
+		vectorizer.method = VectorizeFrequency
+	}
+
+	if vectorizer.feature == FeatureUnknown {
+		vectorizer.feature = FeatureStems
+	}
+
+	if vectorizer.n == 0 {
+		vectorizer.n = 1
+	}
+
+	if vectorizer.buckets == 0 {
+		vectorizer.buckets = DefaultHashingBuckets
+	}
+
+	// Validate options
+	switch vectorizer.method {
+	case VectorizeOneHot, VectorizeFrequency, VectorizeTermFrequency:
+	default:
+		return nil, errors.ErrMethodNotSupported
+	}
+	if vectorizer.feature > FeatureCharacters {
+		return nil, errors.ErrMethodNotSupported
+	}
+	if vectorizer.n < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the n-gram size must be positive"))
+	}
+	if vectorizer.buckets < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the number of buckets must be positive"))
+	}
+
+	return vectorizer, nil
+}
+
+// Returns the [HashingVectorizer]s configured [language.Language].
+func (v *HashingVectorizer) Language() language.Language {
+	return v.lang
+}
+
+// Returns the [HashingVectorizer]s configured [tokenize.Tokenizer].
+func (v *HashingVectorizer) Tokenizer() tokenize.Tokenizer {
+	return v.tokenizer
+}
+
+// Returns the [HashingVectorizer]s configured [stem.Stemmer].
+func (v *HashingVectorizer) Stemmer() stem.Stemmer {
+	return v.stemmer
+}
+
+// Returns the [HashingVectorizer]s configured [tokenize.TypeCounter].
+func (v *HashingVectorizer) TypeCounter() *tokenize.TypeCounter {
+	return v.typeCounter
+}
+
+// Returns the [HashingVectorizer]s configured [VectorizationMethod].
+func (v *HashingVectorizer) Method() VectorizationMethod {
+	return v.method
+}
+
+// Returns the [HashingVectorizer]s configured [HashingFeature] and the number
+// of units (n) in each feature.
+func (v *HashingVectorizer) Features() (feature HashingFeature, n int) {
+	return v.feature, v.n
+}
+
+// Returns the [HashingVectorizer]s configured number of buckets, which is the
+// length of the vectors it creates.
+func (v *HashingVectorizer) Buckets() int {
+	return v.buckets
+}
+
+// Returns true if the [HashingVectorizer] is configured to use the hash to
+// choose the sign of each feature.
+func (v *HashingVectorizer) Signed() bool {
+	return !v.unsigned
+}
+
+// Vectorizes the chunk of text by hashing each of its features into a bucket
+// and weighting it with the configured [VectorizationMethod]:
+//   - [VectorizeOneHot]: 1 for each distinct feature (binary)
+//   - [VectorizeFrequency]: the number of instances of the feature (count)
+//   - [VectorizeTermFrequency]: the number of instances of the feature divided
+//     by the number of feature instances in the chunk
+//
+// When signed, the weight of each feature is added to or subtracted from its
+// bucket by the sign chosen by its hash.
+func (v *HashingVectorizer) Vectorize(chunk string) (vec vector.Vector, err error) {
+	var sparse *vector.Sparse
+	if sparse, err = v.VectorizeSparse(chunk); err != nil {
+		return nil, err
+	}
+	return sparse.Dense(), nil
+}
+
+// Vectorizes the chunk of text as a [vector.Sparse] (see
+// [HashingVectorizer.Vectorize]), which only stores the buckets of the features
+// in the chunk.
+func (v *HashingVectorizer) VectorizeSparse(chunk string) (vec *vector.Sparse, err error) {
+	var features map[string]int
+	if features, err = v.CountFeatures(chunk); err != nil {
+		return nil, err
+	}
+
+	var total int
+	for _, count := range features {
+		total += count
+	}
+
+	indices := make([]int, 0, len(features))
+	values := make([]float64, 0, len(features))
+	// Iterate in a fixed order so the sums of colliding features are identical
+	for _, feature := range slices.Sorted(maps.Keys(features)) {
+		count := features[feature]
+		var weight float64
+		switch v.method {
+		case VectorizeOneHot:
+			weight = 1.0
+		case VectorizeFrequency:
+			weight = float64(count)
+		case VectorizeTermFrequency:
+			weight = float64(count) / float64(total)
+		default:
+			return nil, errors.ErrMethodNotSupported
+		}
+
+		index, sign := HashFeature(feature, v.buckets)
+		if v.unsigned {
+			sign = 1.0
+		}
+		indices = append(indices, index)
+		values = append(values, sign*weight)
+	}
+
+	// The values of features which collide in a bucket are summed
+	return vector.NewSparse(v.buckets, indices, values)
+}
+
+// CountFeatures returns the count of each feature in the chunk of text using
+// the configured [HashingFeature] and n-gram size.
+func (v *HashingVectorizer) CountFeatures(chunk string) (features map[string]int, err error) {
+	// Tokenize using the type counter's tokenizer
+	var tokens []string
+	if tokens, err = v.typeCounter.Tokenizer().Tokenize(chunk); err != nil {
+		return nil, err
+	}
+
+	features = make(map[string]int)
+	switch v.feature {
+	case FeatureStems:
+		for i, tok := range tokens {
+			tokens[i] = v.typeCounter.Stemmer().Stem(tok)
+		}
+		for _, gram := range ngrams.Ngrams(tokens, v.n) {
+			features[strings.Join(gram, " ")] += 1
+		}
+	case FeatureCharacters:
+		for i, tok := range tokens {
+			tokens[i] = strings.ToLower(tok)
+		}
+		for _, gram := range ngrams.Ngrams([]rune(strings.Join(tokens, " ")), v.n) {
+			features[string(gram)] += 1
+		}
+	default:
+		return nil, errors.ErrMethodNotSupported
+	}
+
+	return features, nil
+}
+
+// ############################################################################
+// Hashing Functions
+// ############################################################################
+
+// HashFeature returns the bucket index in the range [0, buckets) and the sign
+// (1 or -1) for the feature, using the 64-bit FNV-1a hash of the feature. The
+// index is the hash modulo the number of buckets and the sign is chosen by the
+// highest bit of the hash, so the two are independent for any number of
+// buckets below 2^63. The hash is stable across processes and platforms.
+func HashFeature(feature string, buckets int) (index int, sign float64) {
+	hash := fnv.New64a()
+	hash.Write([]byte(feature))
+	sum := hash.Sum64()
+
+	index = int(sum % uint64(buckets))
+	if sum>>63 == 1 {
+		return index, -1.0
+	}
+	return index, 1.0
+}
+
+// ############################################################################
+// HashingVectorizerOption
+// ############################################################################
+
+// HashingVectorizerOption functions modify a [HashingVectorizer].
+type HashingVectorizerOption func(v *HashingVectorizer)
+
+// HashingVectorizerWithLang sets the [language.Language] to use with the
+// [HashingVectorizer].
+func HashingVectorizerWithLang(lang language.Language) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.lang = lang
+	}
+}
+
+// HashingVectorizerWithTokenizer sets the [tokenize.Tokenizer] to use with the
+// [HashingVectorizer].
+func HashingVectorizerWithTokenizer(tokenizer tokenize.Tokenizer) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.tokenizer = tokenizer
+	}
+}
+
+// HashingVectorizerWithStemmer sets the [stem.Stemmer] to use with the
+// [HashingVectorizer].
+func HashingVectorizerWithStemmer(stemmer stem.Stemmer) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.stemmer = stemmer
+	}
+}
+
+// HashingVectorizerWithTypeCounter sets the [tokenize.TypeCounter] to use with
+// the [HashingVectorizer], which provides the tokenizer and stemmer used to
+// extract the features.
+func HashingVectorizerWithTypeCounter(typecounter *tokenize.TypeCounter) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.typeCounter = typecounter
+	}
+}
+
+// HashingVectorizerWithMethod sets the [VectorizationMethod] to use with the
+// [HashingVectorizer]; one of [VectorizeOneHot], [VectorizeFrequency] or
+// [VectorizeTermFrequency].
+func HashingVectorizerWithMethod(method VectorizationMethod) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.method = method
+	}
+}
+
+// HashingVectorizerWithFeatures sets the [HashingFeature] and the number of
+// units (n) in each feature hashed by the [HashingVectorizer].
+func HashingVectorizerWithFeatures(feature HashingFeature, n int) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.feature = feature
+		v.n = n
+	}
+}
+
+// HashingVectorizerWithBuckets sets the number of buckets (the vector length)
+// of the [HashingVectorizer].
+func HashingVectorizerWithBuckets(buckets int) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.buckets = buckets
+	}
+}
+
+// HashingVectorizerWithSigned sets whether the [HashingVectorizer] uses the hash
+// to choose the sign of each feature (true by default).
+func HashingVectorizerWithSigned(signed bool) HashingVectorizerOption {
+	return func(v *HashingVectorizer) {
+		v.unsigned = !signed
+	}
+}
@@ -0,0 +1,158 @@
+package vectorize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+func TestNewHashingVectorizer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer()
+		require.NoError(t, err)
+		require.Equal(t, vectorize.VectorizeFrequency, vectorizer.Method())
+		require.Equal(t, vectorize.DefaultHashingBuckets, vectorizer.Buckets())
+		require.True(t, vectorizer.Signed())
+
+		feature, n := vectorizer.Features()
+		require.Equal(t, vectorize.FeatureStems, feature)
+		require.Equal(t, 1, n)
+	})
+
+	t.Run("SuccessOptions", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer(
+			vectorize.HashingVectorizerWithMethod(vectorize.VectorizeTermFrequency),
+			vectorize.HashingVectorizerWithFeatures(vectorize.FeatureCharacters, 3),
+			vectorize.HashingVectorizerWithBuckets(64),
+			vectorize.HashingVectorizerWithSigned(false),
+		)
+		require.NoError(t, err)
+		require.Equal(t, vectorize.VectorizeTermFrequency, vectorizer.Method())
+		require.Equal(t, 64, vectorizer.Buckets())
+		require.False(t, vectorizer.Signed())
+
+		feature, n := vectorizer.Features()
+		require.Equal(t, vectorize.FeatureCharacters, feature)
+		require.Equal(t, 3, n)
+	})
+
+	t.Run("ErrorMethod", func(t *testing.T) {
+		_, err := vectorize.NewHashingVectorizer(vectorize.HashingVectorizerWithMethod(vectorize.VectorizeTFIDF))
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+	})
+
+	t.Run("ErrorBuckets", func(t *testing.T) {
+		_, err := vectorize.NewHashingVectorizer(vectorize.HashingVectorizerWithBuckets(-1))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+}
+
+func TestHashingVectorizerVectorize(t *testing.T) {
+	// Looks up the weight of a feature in a vector
+	weight := func(vec vector.Vector, feature string, buckets int) float64 {
+		index, sign := vectorize.HashFeature(feature, buckets)
+		return sign * vec[index]
+	}
+
+	t.Run("Frequency", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer()
+		require.NoError(t, err)
+
+		vec, err := vectorizer.Vectorize("The cats chased the cat")
+		require.NoError(t, err)
+		require.Len(t, vec, vectorize.DefaultHashingBuckets)
+		require.Equal(t, 2.0, weight(vec, "the", vectorize.DefaultHashingBuckets))
+		require.Equal(t, 2.0, weight(vec, "cat", vectorize.DefaultHashingBuckets))
+		require.Equal(t, 1.0, weight(vec, "chase", vectorize.DefaultHashingBuckets))
+	})
+
+	t.Run("OneHot", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer(
+			vectorize.HashingVectorizerWithMethod(vectorize.VectorizeOneHot),
+			vectorize.HashingVectorizerWithSigned(false),
+		)
+		require.NoError(t, err)
+
+		vec, err := vectorizer.Vectorize("The cats chased the cat")
+		require.NoError(t, err)
+
+		var sum float64
+		for _, e := range vec {
+			require.GreaterOrEqual(t, e, 0.0)
+			sum += e
+		}
+		require.Equal(t, 3.0, sum)
+	})
+
+	t.Run("TermFrequency", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer(
+			vectorize.HashingVectorizerWithMethod(vectorize.VectorizeTermFrequency),
+			vectorize.HashingVectorizerWithFeatures(vectorize.FeatureStems, 2),
+		)
+		require.NoError(t, err)
+
+		vec, err := vectorizer.Vectorize("the cat sat on the cat sat")
+		require.NoError(t, err)
+		require.InDelta(t, 2.0/6.0, weight(vec, "cat sat", vectorize.DefaultHashingBuckets), 1e-12)
+		require.InDelta(t, 1.0/6.0, weight(vec, "sat on", vectorize.DefaultHashingBuckets), 1e-12)
+	})
+
+	t.Run("Characters", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer(
+			vectorize.HashingVectorizerWithFeatures(vectorize.FeatureCharacters, 3),
+		)
+		require.NoError(t, err)
+
+		features, err := vectorizer.CountFeatures("Banana band")
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"ban": 2, "ana": 2, "nan": 1, "na ": 1, "a b": 1, " ba": 1, "and": 1}, features)
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		a, err := vectorize.NewHashingVectorizer(vectorize.HashingVectorizerWithBuckets(16))
+		require.NoError(t, err)
+		b, err := vectorize.NewHashingVectorizer(vectorize.HashingVectorizerWithBuckets(16))
+		require.NoError(t, err)
+
+		vecA, err := a.Vectorize("a stream of text with no vocabulary")
+		require.NoError(t, err)
+		vecB, err := b.Vectorize("a stream of text with no vocabulary")
+		require.NoError(t, err)
+		require.Equal(t, vecA, vecB)
+	})
+
+	t.Run("Sparse", func(t *testing.T) {
+		vectorizer, err := vectorize.NewHashingVectorizer(vectorize.HashingVectorizerWithBuckets(1 << 20))
+		require.NoError(t, err)
+
+		sparse, err := vectorizer.VectorizeSparse("The cats chased the cat")
+		require.NoError(t, err)
+		require.Equal(t, 1<<20, sparse.Len())
+		require.Equal(t, 3, sparse.NonZero())
+
+		dense, err := vectorizer.Vectorize("The cats chased the cat")
+		require.NoError(t, err)
+		require.Equal(t, dense, sparse.Dense())
+	})
+}
+
+func TestHashFeature(t *testing.T) {
+	signs := make(map[float64]int)
+	for _, feature := range []string{"apple", "banana", "cat", "dog", "egg", "fig", "grape", "ham"} {
+		index, sign := vectorize.HashFeature(feature, 10)
+		require.GreaterOrEqual(t, index, 0)
+		require.Less(t, index, 10)
+		signs[sign]++
+	}
+
+	// Both signs are used
+	require.Len(t, signs, 2)
+
+	// The hash is stable: FNV-1a 64 of "" is 0xcbf29ce484222325
+	index, sign := vectorize.HashFeature("", 1000)
+	require.Equal(t, int(uint64(0xcbf29ce484222325)%1000), index)
+	require.Equal(t, -1.0, sign)
+}
@@ -0,0 +1,496 @@
+package vectorize
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// OpenAIEmbedder
+// ############################################################################
+
+/*
+OpenAIEmbedder can be used to vectorize text using the OpenAI embeddings API
+(https://platform.openai.com/docs/api-reference/embeddings) or any server which
+implements the same "/v1/embeddings" protocol, such as vLLM, llama.cpp or Ollama
+running locally.
+
+Usage Example:
+
+	// Panics when the error is not nil
+	func checkErr(err error) {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Use a local embedding server; see the docs for [NewOpenAIEmbedder] for
+	// information on how to load it's configs via environment variables. An API
+	// key is only required for the OpenAI API itself.
+	embedder, err := vectorize.NewOpenAIEmbedder(
+		vectorize.OpenAIEmbedderWithEndpoint("http://localhost:8000/v1/embeddings"),
+		vectorize.OpenAIEmbedderWithModel("BAAI/bge-small-en-v1.5"),
+	)
+	checkErr(err)
+
+	// Or use the OpenAI API with shortened embeddings sent as base64, which is
+	// smaller than a list of numbers
+	embedder, err = vectorize.NewOpenAIEmbedder(
+		vectorize.OpenAIEmbedderWithAPIKey("your_openai_api_key_here"),
+		vectorize.OpenAIEmbedderWithModel("text-embedding-3-small"),
+		vectorize.OpenAIEmbedderWithDimensions(512),
+		vectorize.OpenAIEmbedderWithEncodingFormat(vectorize.OpenAIEncodingBase64),
+	)
+	checkErr(err)
+
+	// Get a single embedding vector using the [Vectorizer.Vectorize] interface
+	embedding, err := embedder.Vectorize("A simple test.")
+	checkErr(err)
+
+	// Get several embeddings; large inputs are split into batches within the
+	// OpenAI limits which are requested concurrently (see [BatchError] for
+	// handling partial failures)
+	embeddings, err := embedder.VectorizeAll([]string{"A simple test.", "Number two!"})
+	checkErr(err)
+
+	// Print the number of usage tokens that were used
+	fmt.Printf("used %d tokens\n", embedder.TotalTokensUsed())
+*/
+type OpenAIEmbedder struct {
+	apiKey          string
+	endpoint        string
+	model           string
+	dimensions      int
+	encodingFormat  string
+	api             apiClient
+	totalTokensUsed atomic.Int64
+}
+
+// The OpenAI embeddings endpoint, which is used if no endpoint is configured.
+const DefaultOpenAIEndpoint = "https://api.openai.com/v1/embeddings"
+
+// The OpenAI per-request limits.
+// See: https://platform.openai.com/docs/api-reference/embeddings/create
+const (
+	OpenAIMaxBatchItems  = 2048
+	OpenAIMaxBatchTokens = 300_000
+)
+
+// The encoding formats for the embeddings in OpenAI responses.
+const (
+	// Embeddings are sent as lists of numbers
+	OpenAIEncodingFloat = "float"
+	// Embeddings are sent as base64 encoded little-endian float32 values, which
+	// is about a quarter of the size
+	OpenAIEncodingBase64 = "base64"
+)
+
+// Ensure [OpenAIEmbedder] meets the [BatchVectorizer] interface requirements.
+var _ BatchVectorizer = &OpenAIEmbedder{}
+
+// ############################################################################
+// OpenAI Constructor and Options
+// ############################################################################
+
+// Create a new OpenAI-compatible embedding vectorizer. Options will be loaded
+// from "OPENAI_*" environment variables if available (see `.env.template`). The
+// environment values will be overridden by [OpenAIEmbedderOption]s. If a
+// necessary option is not provided or found in the environment, then
+// [errors.ErrMissingConfig] will be returned alongside another more descriptive
+// error, so ensure you use [errors.Is] to disambiguate the errors. An API key is
+// only required when using the [DefaultOpenAIEndpoint].
+//
+// Defaults:
+//   - Endpoint: [DefaultOpenAIEndpoint]
+//   - Dimensions: 0 (the model's default)
+//   - Encoding format: [OpenAIEncodingFloat]
+//   - HTTP Client: [http.Client]
+//   - Timeout: [DefaultAPITimeout] for each attempt
+//   - Retries: [DefaultAPIMaxRetries]
+//   - Backoff: [DefaultAPIMinBackoff] doubling up to [DefaultAPIMaxBackoff]
+//   - Rate limit: none
+//   - Batch limits: [OpenAIMaxBatchItems] and [OpenAIMaxBatchTokens]
+//   - Concurrency: [DefaultAPIConcurrency]
+func NewOpenAIEmbedder(opts ...OpenAIEmbedderOption) (vectorizer *OpenAIEmbedder, err error) {
+	// Initialize with options from the environment; the user must load the env
+	// vars somewhere else themselves.
+	vectorizer = &OpenAIEmbedder{
+		apiKey:   os.Getenv("OPENAI_API_KEY"),
+		endpoint: os.Getenv("OPENAI_EMBEDDING_ENDPOINT"),
+		model:    os.Getenv("OPENAI_EMBEDDING_MODEL"),
+		api:      newAPIClient(OpenAIMaxBatchItems, OpenAIMaxBatchTokens),
+	}
+
+	// Set user-provided options, overridding any environment variables
+	for _, fn := range opts {
+		fn(vectorizer)
+	}
+
+	// Set defaults
+	if vectorizer.endpoint == "" {
+		vectorizer.endpoint = DefaultOpenAIEndpoint
+	}
+	if vectorizer.encodingFormat == "" {
+		vectorizer.encodingFormat = OpenAIEncodingFloat
+	}
+
+	// Ensure all required configs are set before returning the vectorizer
+	if vectorizer.apiKey == "" && vectorizer.endpoint == DefaultOpenAIEndpoint {
+		return nil, errors.Join(
+			errors.ErrMissingConfig,
+			errors.New("field 'apiKey' is required for the OpenAI API; use option 'OpenAIEmbedderWithAPIKey()' or set the environment variable 'OPENAI_API_KEY'."),
+		)
+	}
+	if vectorizer.model == "" {
+		return nil, errors.Join(
+			errors.ErrMissingConfig,
+			errors.New("field 'model' is required; use option 'OpenAIEmbedderWithModel()' or set the environment variable 'OPENAI_EMBEDDING_MODEL'."),
+		)
+	}
+	if vectorizer.dimensions < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the number of dimensions cannot be negative"))
+	}
+	if vectorizer.encodingFormat != OpenAIEncodingFloat && vectorizer.encodingFormat != OpenAIEncodingBase64 {
+		return nil, errors.Join(errors.ErrMethodNotSupported, fmt.Errorf("unknown encoding format %q", vectorizer.encodingFormat))
+	}
+	if err = vectorizer.api.setup(); err != nil {
+		return nil, err
+	}
+
+	return vectorizer, nil
+}
+
+// Returns the total number of tokens used by this client.
+func (o *OpenAIEmbedder) TotalTokensUsed() int {
+	return int(o.totalTokensUsed.Load())
+}
+
+// Returns the [OpenAIEmbedder]s configured endpoint URL.
+func (o *OpenAIEmbedder) Endpoint() string {
+	return o.endpoint
+}
+
+// Returns the [OpenAIEmbedder]s configured model.
+func (o *OpenAIEmbedder) Model() string {
+	return o.model
+}
+
+// Returns the [OpenAIEmbedder]s configured number of dimensions, where 0 is the
+// model's default.
+func (o *OpenAIEmbedder) Dimensions() int {
+	return o.dimensions
+}
+
+// Returns the [OpenAIEmbedder]s configured encoding format.
+func (o *OpenAIEmbedder) EncodingFormat() string {
+	return o.encodingFormat
+}
+
+// Returns the [OpenAIEmbedder]s configured timeout for each request attempt.
+func (o *OpenAIEmbedder) Timeout() time.Duration {
+	return o.api.timeout
+}
+
+// Returns the [OpenAIEmbedder]s configured maximum number of retries.
+func (o *OpenAIEmbedder) MaxRetries() int {
+	return o.api.maxRetries
+}
+
+// Returns the [OpenAIEmbedder]s configured minimum and maximum backoff between
+// retries.
+func (o *OpenAIEmbedder) Backoff() (minBackoff, maxBackoff time.Duration) {
+	return o.api.minBackoff, o.api.maxBackoff
+}
+
+// Returns the [OpenAIEmbedder]s configured maximum number of chunks and
+// estimated tokens in each request, where 0 is unlimited.
+func (o *OpenAIEmbedder) BatchLimits() (maxItems, maxTokens int) {
+	return o.api.batchItems, o.api.batchTokens
+}
+
+// Returns the [OpenAIEmbedder]s configured maximum number of requests made at
+// once.
+func (o *OpenAIEmbedder) Concurrency() int {
+	return o.api.concurrency
+}
+
+// Returns the [OpenAIEmbedder]s configured client-side rate limits, where 0 is
+// unlimited.
+func (o *OpenAIEmbedder) RateLimit() (requestsPerMinute, tokensPerMinute int) {
+	return o.api.rpm, o.api.tpm
+}
+
+// ############################################################################
+// OpenAI Embedder Options
+// ############################################################################
+
+// OpenAIEmbedderOption functions modify an [OpenAIEmbedder].
+type OpenAIEmbedderOption func(c *OpenAIEmbedder)
+
+// OpenAIEmbedderWithAPIKey sets the API key string to use with the
+// [OpenAIEmbedder].
+func OpenAIEmbedderWithAPIKey(apiKey string) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.apiKey = apiKey
+	}
+}
+
+// OpenAIEmbedderWithModel sets the model to use with the [OpenAIEmbedder].
+func OpenAIEmbedderWithModel(model string) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.model = model
+	}
+}
+
+// OpenAIEmbedderWithEndpoint sets the endpoint URL to use with the
+// [OpenAIEmbedder], e.g. "http://localhost:8000/v1/embeddings" for a local
+// server.
+func OpenAIEmbedderWithEndpoint(endpoint string) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.endpoint = endpoint
+	}
+}
+
+// OpenAIEmbedderWithDimensions sets the number of dimensions of the embeddings
+// returned by the [OpenAIEmbedder], for models which support shortened
+// embeddings; 0 is the model's default.
+func OpenAIEmbedderWithDimensions(dimensions int) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.dimensions = dimensions
+	}
+}
+
+// OpenAIEmbedderWithEncodingFormat sets the format the embeddings are sent to
+// the [OpenAIEmbedder] in; either [OpenAIEncodingFloat] or
+// [OpenAIEncodingBase64].
+func OpenAIEmbedderWithEncodingFormat(format string) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.encodingFormat = format
+	}
+}
+
+// OpenAIEmbedderWithHTTPClient sets the [http.Client] to use with the
+// [OpenAIEmbedder].
+func OpenAIEmbedderWithHTTPClient(client *http.Client) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.client = client
+	}
+}
+
+// OpenAIEmbedderWithTimeout sets the timeout for each request attempt made by
+// the [OpenAIEmbedder]; 0 is no timeout other than the context's.
+func OpenAIEmbedderWithTimeout(timeout time.Duration) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.timeout = timeout
+	}
+}
+
+// OpenAIEmbedderWithRetries sets the maximum number of times the
+// [OpenAIEmbedder] retries a request which was rate limited, timed out, or
+// failed with a server or network error; 0 disables retries.
+func OpenAIEmbedderWithRetries(maxRetries int) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.maxRetries = maxRetries
+	}
+}
+
+// OpenAIEmbedderWithBackoff sets the exponential backoff between the
+// [OpenAIEmbedder]s retries, which starts at minBackoff and doubles after each
+// retry up to maxBackoff. A "Retry-After" header from the API is used instead
+// when it is present.
+func OpenAIEmbedderWithBackoff(minBackoff, maxBackoff time.Duration) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.minBackoff = minBackoff
+		c.api.maxBackoff = maxBackoff
+	}
+}
+
+// OpenAIEmbedderWithBatchLimits sets the maximum number of chunks and estimated
+// tokens (see [EstimateTokens]) in each request made by the [OpenAIEmbedder];
+// larger inputs are split into several requests. 0 is unlimited. Local servers
+// often have lower limits than the OpenAI API.
+func OpenAIEmbedderWithBatchLimits(maxItems, maxTokens int) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.batchItems = maxItems
+		c.api.batchTokens = maxTokens
+	}
+}
+
+// OpenAIEmbedderWithConcurrency sets the maximum number of requests the
+// [OpenAIEmbedder] makes at once when an input is split into several batches.
+func OpenAIEmbedderWithConcurrency(concurrency int) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.concurrency = concurrency
+	}
+}
+
+// OpenAIEmbedderWithRateLimit sets client-side rate limits for the
+// [OpenAIEmbedder], which waits before each request so no more than the number
+// of requests and tokens per minute are used; 0 is unlimited. The estimated
+// tokens of each request (see [EstimateTokens]) are reserved before it is sent,
+// so concurrent batches stay under the limit, and are corrected to the usage
+// reported by the API.
+func OpenAIEmbedderWithRateLimit(requestsPerMinute, tokensPerMinute int) OpenAIEmbedderOption {
+	return func(c *OpenAIEmbedder) {
+		c.api.rpm = requestsPerMinute
+		c.api.tpm = tokensPerMinute
+	}
+}
+
+// ############################################################################
+// OpenAI Text Embedding Functionality
+// ############################################################################
+
+// Performs embedding vectorization on a chunk of text using the embeddings API.
+func (o *OpenAIEmbedder) Vectorize(chunk string) (embedding vector.Vector, err error) {
+	return o.VectorizeContext(context.Background(), chunk)
+}
+
+// Performs embedding vectorization on a chunk of text using the embeddings API,
+// stopping early if the context is done.
+func (o *OpenAIEmbedder) VectorizeContext(ctx context.Context, chunk string) (embedding vector.Vector, err error) {
+	var embeddings []vector.Vector
+	if embeddings, err = o.makeTextEmbeddingsRequest(ctx, []string{chunk}); err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// Performs embedding vectorization on several chunks of text using the
+// embeddings API (see [OpenAIEmbedder.VectorizeAllContext]).
+func (o *OpenAIEmbedder) VectorizeAll(chunks []string) (embeddings []vector.Vector, err error) {
+	return o.VectorizeAllContext(context.Background(), chunks)
+}
+
+// Performs embedding vectorization on several chunks of text using the
+// embeddings API, stopping early if the context is done. The chunks are split
+// into batches within the configured batch limits, which are requested
+// concurrently, and the embeddings are returned in the same order as the
+// chunks. If any of the batches fail, a [BatchError] is returned with the
+// embeddings of the batches which succeeded, which reports the failed chunks.
+func (o *OpenAIEmbedder) VectorizeAllContext(ctx context.Context, chunks []string) (embeddings []vector.Vector, err error) {
+	return embedBatches(ctx, chunks, o.api.batchItems, o.api.batchTokens, o.api.concurrency, o.makeTextEmbeddingsRequest)
+}
+
+// Makes a request to the embeddings API, retrying and rate limiting it as
+// configured.
+func (o *OpenAIEmbedder) makeTextEmbeddingsRequest(ctx context.Context, chunks []string) (embeddings []vector.Vector, err error) {
+	data := &OpenAIEmbeddingsRequest{
+		Model:          o.model,
+		Input:          chunks,
+		EncodingFormat: o.encodingFormat,
+	}
+	if o.dimensions > 0 {
+		data.Dimensions = &o.dimensions
+	}
+
+	headers := make(map[string]string)
+	if o.apiKey != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", o.apiKey)
+	}
+
+	var body *OpenAIEmbeddingsResponse
+	tokens := estimateBatchTokens(chunks)
+	if err = o.api.post(ctx, o.endpoint, headers, tokens, data, &body); err != nil {
+		return nil, err
+	}
+
+	// Count the tokens used so we have a running total for this client
+	o.totalTokensUsed.Add(int64(body.Usage.TotalTokens))
+	o.api.settle(tokens, body.Usage.TotalTokens)
+
+	// Gather and return the embeddings in the order of the input
+	if len(body.Data) != len(chunks) {
+		return nil, errors.Join(errors.ErrUnequalLengthInputs, fmt.Errorf("expected %d embeddings but the response has %d", len(chunks), len(body.Data)))
+	}
+	slices.SortFunc(body.Data, func(a, b *OpenAIEmbedding) int {
+		return a.Index - b.Index
+	})
+	for _, embedding := range body.Data {
+		embeddings = append(embeddings, vector.Vector(embedding.Embedding))
+	}
+
+	return embeddings, nil
+}
+
+// ############################################################################
+// OpenAI Request Struct
+// ############################################################################
+
+// Model for OpenAI embeddings request (non-exhaustive).
+// See: https://platform.openai.com/docs/api-reference/embeddings/create
+type OpenAIEmbeddingsRequest struct {
+	// Required
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+
+	// Optional
+	Dimensions     *int   `json:"dimensions,omitempty"`
+	EncodingFormat string `json:"encoding_format,omitempty"`
+}
+
+// ############################################################################
+// OpenAI Response Structs
+// ############################################################################
+
+// Model for OpenAI embeddings response.
+// See: https://platform.openai.com/docs/api-reference/embeddings/object
+type OpenAIEmbeddingsResponse struct {
+	Object string             `json:"object"` // always "list"
+	Data   []*OpenAIEmbedding `json:"data"`
+	Model  string             `json:"model"`
+	Usage  OpenAIUsage        `json:"usage"`
+}
+
+// Model for OpenAI embeddings inside the [OpenAIEmbeddingsResponse].
+type OpenAIEmbedding struct {
+	Object    string              `json:"object"` // always "embedding"
+	Embedding OpenAIEmbeddingData `json:"embedding"`
+	Index     int                 `json:"index"`
+}
+
+// OpenAIEmbeddingData is an embedding inside an [OpenAIEmbedding], which is
+// decoded from either a list of numbers ([OpenAIEncodingFloat]) or a base64
+// string of little-endian float32 values ([OpenAIEncodingBase64]).
+type OpenAIEmbeddingData []float64
+
+// Decodes the embedding from a list of numbers or a base64 string.
+func (d *OpenAIEmbeddingData) UnmarshalJSON(data []byte) (err error) {
+	var encoded string
+	if err = json.Unmarshal(data, &encoded); err != nil {
+		// Not a string, so it must be a list of numbers
+		return json.Unmarshal(data, (*[]float64)(d))
+	}
+
+	var raw []byte
+	if raw, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+		return errors.Join(err, errors.New("error decoding base64 embedding"))
+	}
+	if len(raw)%4 != 0 {
+		return errors.New("base64 embedding is not a whole number of float32 values")
+	}
+
+	*d = make(OpenAIEmbeddingData, len(raw)/4)
+	for i := range *d {
+		(*d)[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:])))
+	}
+	return nil
+}
+
+// Model for OpenAI usage data inside the [OpenAIEmbeddingsResponse].
+type OpenAIUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
@@ -0,0 +1,226 @@
+package vectorize_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+// Returns a stand-in for an OpenAI-compatible embeddings API which responds with
+// an embedding of [len(input), i] for each input i, encoded as requested, and
+// stores the last request it received.
+func openAIServer(t *testing.T, requests *atomic.Int32, last *vectorize.OpenAIEmbeddingsRequest, auth string, tokens int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		// Use assert since require cannot stop the test from the handler goroutine
+		assert.Equal(t, auth, r.Header.Get("Authorization"))
+
+		var request vectorize.OpenAIEmbeddingsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*last = request
+
+		// Respond with the embeddings in reverse order
+		data := make([]map[string]any, 0, len(request.Input))
+		for i := len(request.Input) - 1; i >= 0; i-- {
+			var embedding any = []float64{float64(len(request.Input[i])), float64(i)}
+			if request.EncodingFormat == vectorize.OpenAIEncodingBase64 {
+				raw := make([]byte, 8)
+				binary.LittleEndian.PutUint32(raw, math.Float32bits(float32(len(request.Input[i]))))
+				binary.LittleEndian.PutUint32(raw[4:], math.Float32bits(float32(i)))
+				embedding = base64.StdEncoding.EncodeToString(raw)
+			}
+			data = append(data, map[string]any{"object": "embedding", "embedding": embedding, "index": i})
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"object": "list",
+			"data":   data,
+			"model":  request.Model,
+			"usage":  map[string]int{"prompt_tokens": tokens, "total_tokens": tokens},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewOpenAIEmbedderOptions(t *testing.T) {
+	// Ensure the environment does not provide any configs
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_EMBEDDING_ENDPOINT", "")
+	t.Setenv("OPENAI_EMBEDDING_MODEL", "")
+
+	t.Run("Defaults", func(t *testing.T) {
+		openai, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey("test-key"),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, vectorize.DefaultOpenAIEndpoint, openai.Endpoint())
+		require.Equal(t, "test-model", openai.Model())
+		require.Zero(t, openai.Dimensions())
+		require.Equal(t, vectorize.OpenAIEncodingFloat, openai.EncodingFormat())
+		require.Equal(t, vectorize.DefaultAPITimeout, openai.Timeout())
+		require.Equal(t, vectorize.DefaultAPIMaxRetries, openai.MaxRetries())
+		require.Equal(t, vectorize.DefaultAPIConcurrency, openai.Concurrency())
+
+		maxItems, maxTokens := openai.BatchLimits()
+		require.Equal(t, vectorize.OpenAIMaxBatchItems, maxItems)
+		require.Equal(t, vectorize.OpenAIMaxBatchTokens, maxTokens)
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("OPENAI_EMBEDDING_ENDPOINT", "http://localhost:8000/v1/embeddings")
+		t.Setenv("OPENAI_EMBEDDING_MODEL", "env-model")
+
+		// No API key is required for other endpoints
+		openai, err := vectorize.NewOpenAIEmbedder()
+		require.NoError(t, err)
+		require.Equal(t, "http://localhost:8000/v1/embeddings", openai.Endpoint())
+		require.Equal(t, "env-model", openai.Model())
+	})
+
+	t.Run("ErrorMissingAPIKey", func(t *testing.T) {
+		_, err := vectorize.NewOpenAIEmbedder(vectorize.OpenAIEmbedderWithModel("test-model"))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+
+	t.Run("ErrorMissingModel", func(t *testing.T) {
+		_, err := vectorize.NewOpenAIEmbedder(vectorize.OpenAIEmbedderWithAPIKey("test-key"))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+
+	t.Run("ErrorNegativeDimensions", func(t *testing.T) {
+		_, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey("test-key"),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+			vectorize.OpenAIEmbedderWithDimensions(-1),
+		)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+
+	t.Run("ErrorUnknownEncodingFormat", func(t *testing.T) {
+		_, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey("test-key"),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+			vectorize.OpenAIEmbedderWithEncodingFormat("int8"),
+		)
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+	})
+}
+
+func TestOpenAIEmbedderHTTP(t *testing.T) {
+	t.Run("Float", func(t *testing.T) {
+		var requests atomic.Int32
+		var last vectorize.OpenAIEmbeddingsRequest
+		server := openAIServer(t, &requests, &last, "Bearer test-key", 5)
+
+		openai, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey("test-key"),
+			vectorize.OpenAIEmbedderWithEndpoint(server.URL),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+		)
+		require.NoError(t, err)
+
+		embeddings, err := openai.VectorizeAll([]string{"a", "bb", "ccc"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{1, 0}, {2, 1}, {3, 2}}, embeddings)
+		require.Equal(t, "test-model", last.Model)
+		require.Nil(t, last.Dimensions, "dimensions should be omitted by default")
+		require.Equal(t, vectorize.OpenAIEncodingFloat, last.EncodingFormat)
+
+		embedding, err := openai.Vectorize("dddd")
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{4, 0}, embedding)
+
+		require.Equal(t, int32(2), requests.Load())
+		require.Equal(t, 10, openai.TotalTokensUsed())
+	})
+
+	t.Run("Base64Dimensions", func(t *testing.T) {
+		var requests atomic.Int32
+		var last vectorize.OpenAIEmbeddingsRequest
+		server := openAIServer(t, &requests, &last, "Bearer test-key", 3)
+
+		openai, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey("test-key"),
+			vectorize.OpenAIEmbedderWithEndpoint(server.URL),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+			vectorize.OpenAIEmbedderWithDimensions(2),
+			vectorize.OpenAIEmbedderWithEncodingFormat(vectorize.OpenAIEncodingBase64),
+		)
+		require.NoError(t, err)
+
+		embeddings, err := openai.VectorizeAll([]string{"a", "bb"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{1, 0}, {2, 1}}, embeddings)
+		require.NotNil(t, last.Dimensions)
+		require.Equal(t, 2, *last.Dimensions)
+		require.Equal(t, vectorize.OpenAIEncodingBase64, last.EncodingFormat)
+		require.Equal(t, 3, openai.TotalTokensUsed())
+	})
+
+	t.Run("NoAPIKey", func(t *testing.T) {
+		var requests atomic.Int32
+		var last vectorize.OpenAIEmbeddingsRequest
+		server := openAIServer(t, &requests, &last, "", 1)
+
+		openai, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey(""),
+			vectorize.OpenAIEmbedderWithEndpoint(server.URL),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+		)
+		require.NoError(t, err)
+
+		embedding, err := openai.Vectorize("a")
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{1, 0}, embedding)
+	})
+
+	t.Run("Batching", func(t *testing.T) {
+		var requests atomic.Int32
+		var last vectorize.OpenAIEmbeddingsRequest
+		server := openAIServer(t, &requests, &last, "Bearer test-key", 2)
+
+		openai, err := vectorize.NewOpenAIEmbedder(
+			vectorize.OpenAIEmbedderWithAPIKey("test-key"),
+			vectorize.OpenAIEmbedderWithEndpoint(server.URL),
+			vectorize.OpenAIEmbedderWithModel("test-model"),
+			vectorize.OpenAIEmbedderWithBatchLimits(2, 0),
+			vectorize.OpenAIEmbedderWithConcurrency(1),
+			vectorize.OpenAIEmbedderWithBackoff(time.Millisecond, 10*time.Millisecond),
+		)
+		require.NoError(t, err)
+
+		embeddings, err := openai.VectorizeAll([]string{"a", "bb", "ccc", "dddd", "eeeee"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{1, 0}, {2, 1}, {3, 0}, {4, 1}, {5, 0}}, embeddings)
+		require.Equal(t, int32(3), requests.Load())
+		require.Equal(t, 6, openai.TotalTokensUsed())
+	})
+}
+
+func TestOpenAIEmbeddingData(t *testing.T) {
+	var data vectorize.OpenAIEmbeddingData
+	require.NoError(t, json.Unmarshal([]byte(`[0.5, -1.25]`), &data))
+	require.Equal(t, vectorize.OpenAIEmbeddingData{0.5, -1.25}, data)
+
+	// 0.5 and -1.25 as little-endian float32 values
+	require.NoError(t, json.Unmarshal([]byte(`"AAAAPwAAoL8="`), &data))
+	require.Equal(t, vectorize.OpenAIEmbeddingData{0.5, -1.25}, data)
+
+	require.Error(t, json.Unmarshal([]byte(`"AAAA"`), &data), "a partial float32 should be an error")
+	require.Error(t, json.Unmarshal([]byte(`"not base64!"`), &data))
+}
@@ -0,0 +1,293 @@
+package vectorize
+
+import (
+	"math"
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// PoolingMethod "enum"
+// ############################################################################
+
+// PoolingMethod selects how a [PoolingVectorizer] combines the vectors of the
+// words in a chunk into one vector.
+type PoolingMethod uint8
+
+const (
+	PoolingUnknown PoolingMethod = iota
+	// The mean of the word vectors.
+	PoolingMean
+	// The maximum of each element of the word vectors.
+	PoolingMax
+	// The smooth inverse frequency (SIF) weighted average of the word vectors
+	// (Arora et al., 2017), where each word is weighted by `a / (a + p(w))` for
+	// its probability p(w), so frequent words count for less. The common
+	// component removal step is not done, since it requires a corpus.
+	PoolingSIF
+)
+
+// The default SIF weight parameter a of a [PoolingVectorizer].
+const DefaultSIFWeight = 1e-3
+
+// ############################################################################
+// PoolingVectorizer
+// ############################################################################
+
+/*
+PoolingVectorizer can be used to create document embeddings without an API by
+pooling pre-trained [WordVectors]: the chunk is tokenized and the vectors of the
+tokens are combined with the [PoolingMethod]. Tokens without a vector are looked
+up again in lowercase, then skipped; a chunk without any known tokens is a zero
+vector. Create with [NewPoolingVectorizer].
+
+For [PoolingSIF], the probability of each word is taken from the word frequencies
+if they are provided with [PoolingVectorizerWithWordFrequencies], otherwise it is
+estimated by Zipf's law from the rank of the word in the [WordVectors], since
+pre-trained vectors are usually sorted by descending frequency.
+
+Usage example:
+
+	glove, err := vectorize.LoadWordVectors("glove.6B.100d.txt", vectorize.FormatGloVe, 0)
+
+	pooling, err := vectorize.NewPoolingVectorizer(
+		vectorize.PoolingVectorizerWithWordVectors(glove),
+		vectorize.PoolingVectorizerWithMethod(vectorize.PoolingSIF),
+	)
+
+	// Vectorize a chunk of text into a 100 element vector
+	myVector, err := pooling.Vectorize("the cat sat on the mat")
+*/
+type PoolingVectorizer struct {
+	lang        language.Language
+	tokenizer   tokenize.Tokenizer
+	vectors     *WordVectors
+	method      PoolingMethod
+	sifWeight   float64
+	frequencies map[string]float64 // the probability of each word; nil to use Zipf's law
+	harmonic    float64            // the harmonic number of the number of words for Zipf's law
+}
+
+// Ensure [PoolingVectorizer] meets the [Vectorizer] interface requirements.
+var _ Vectorizer = &PoolingVectorizer{}
+
+// Returns a new [PoolingVectorizer] instance. The [WordVectors] are required,
+// otherwise [errors.ErrMissingConfig] is returned.
+//
+// Defaults:
+//   - Lang: [language.English]
+//   - Tokenizer: [tokenize.RegexTokenizer]
+//   - Method: [PoolingMean]
+//   - SIF weight: [DefaultSIFWeight]
+//   - Word frequencies: estimated from the rank of the words
+func NewPoolingVectorizer(opts ...PoolingVectorizerOption) (vectorizer *PoolingVectorizer, err error) {
+	// Set options
+	vectorizer = &PoolingVectorizer{}
+	for _, fn := range opts {
+		fn(vectorizer)
+	}
+
+	// Set defaults
+
+	if vectorizer.lang == language.Unknown {
+		vectorizer.lang = language.English
+	}
+
+	if vectorizer.tokenizer == nil {
+		vectorizer.tokenizer = tokenize.NewRegexTokenizer(tokenize.RegexTokenizerWithLanguage(vectorizer.lang))
+	}
+
+	if vectorizer.method == PoolingUnknown {
+		vectorizer.method = PoolingMean
+	}
+
+	if vectorizer.sifWeight == 0.0 {
+		vectorizer.sifWeight = DefaultSIFWeight
+	}
+
+	// Validate options
+	if vectorizer.vectors == nil {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("word vectors are required; use option 'PoolingVectorizerWithWordVectors()'"))
+	}
+	if vectorizer.method > PoolingSIF {
+		return nil, errors.ErrMethodNotSupported
+	}
+	if vectorizer.sifWeight < 0.0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the SIF weight must be positive"))
+	}
+
+	// The n-th harmonic number, for the Zipf's law probability of a rank
+	if n := float64(vectorizer.vectors.Len()); n > 0 {
+		vectorizer.harmonic = math.Log(n) + 0.5772156649015329 + 1.0/(2.0*n)
+	}
+
+	return vectorizer, nil
+}
+
+// Returns the [PoolingVectorizer]s configured [language.Language].
+func (v *PoolingVectorizer) Language() language.Language {
+	return v.lang
+}
+
+// Returns the [PoolingVectorizer]s configured [tokenize.Tokenizer].
+func (v *PoolingVectorizer) Tokenizer() tokenize.Tokenizer {
+	return v.tokenizer
+}
+
+// Returns the [PoolingVectorizer]s configured [WordVectors].
+func (v *PoolingVectorizer) WordVectors() *WordVectors {
+	return v.vectors
+}
+
+// Returns the [PoolingVectorizer]s configured [PoolingMethod].
+func (v *PoolingVectorizer) Method() PoolingMethod {
+	return v.method
+}
+
+// Returns the [PoolingVectorizer]s configured SIF weight parameter a.
+func (v *PoolingVectorizer) SIFWeight() float64 {
+	return v.sifWeight
+}
+
+// ############################################################################
+// PoolingVectorizer Options
+// ############################################################################
+
+// PoolingVectorizerOption functions modify a [PoolingVectorizer].
+type PoolingVectorizerOption func(v *PoolingVectorizer)
+
+// PoolingVectorizerWithLang sets the language of the [PoolingVectorizer]s
+// default tokenizer.
+func PoolingVectorizerWithLang(lang language.Language) PoolingVectorizerOption {
+	return func(v *PoolingVectorizer) {
+		v.lang = lang
+	}
+}
+
+// PoolingVectorizerWithTokenizer sets the [tokenize.Tokenizer] the
+// [PoolingVectorizer] splits chunks into words with.
+func PoolingVectorizerWithTokenizer(tokenizer tokenize.Tokenizer) PoolingVectorizerOption {
+	return func(v *PoolingVectorizer) {
+		v.tokenizer = tokenizer
+	}
+}
+
+// PoolingVectorizerWithWordVectors sets the [WordVectors] the
+// [PoolingVectorizer] pools.
+func PoolingVectorizerWithWordVectors(vectors *WordVectors) PoolingVectorizerOption {
+	return func(v *PoolingVectorizer) {
+		v.vectors = vectors
+	}
+}
+
+// PoolingVectorizerWithMethod sets the [PoolingMethod] of the
+// [PoolingVectorizer].
+func PoolingVectorizerWithMethod(method PoolingMethod) PoolingVectorizerOption {
+	return func(v *PoolingVectorizer) {
+		v.method = method
+	}
+}
+
+// PoolingVectorizerWithSIFWeight sets the SIF weight parameter a of the
+// [PoolingVectorizer] (see [PoolingSIF]); smaller values weight rare words more
+// heavily.
+func PoolingVectorizerWithSIFWeight(a float64) PoolingVectorizerOption {
+	return func(v *PoolingVectorizer) {
+		v.sifWeight = a
+	}
+}
+
+// PoolingVectorizerWithWordFrequencies sets the counts of the words (e.g. in a
+// corpus) the [PoolingVectorizer] uses for [PoolingSIF] instead of estimating
+// them from the rank of the words; words which are not counted have a
+// probability of 0.
+func PoolingVectorizerWithWordFrequencies(counts map[string]int) PoolingVectorizerOption {
+	return func(v *PoolingVectorizer) {
+		var total int
+		for _, count := range counts {
+			total += count
+		}
+
+		v.frequencies = make(map[string]float64, len(counts))
+		for word, count := range counts {
+			v.frequencies[word] = float64(count) / float64(max(total, 1))
+		}
+	}
+}
+
+// ############################################################################
+// PoolingVectorizer Functionality
+// ############################################################################
+
+// Vectorizes the chunk by pooling the vectors of its words with the configured
+// [PoolingMethod].
+func (v *PoolingVectorizer) Vectorize(chunk string) (pooled vector.Vector, err error) {
+	var tokens []string
+	if tokens, err = v.tokenizer.Tokenize(chunk); err != nil {
+		return nil, err
+	}
+
+	pooled = make(vector.Vector, v.vectors.Dim())
+	var total float64
+	for _, token := range tokens {
+		word, embedding, ok := v.lookup(token)
+		if !ok {
+			continue
+		}
+
+		switch v.method {
+		case PoolingMax:
+			for i, e := range embedding {
+				if total == 0.0 || float64(e) > pooled[i] {
+					pooled[i] = float64(e)
+				}
+			}
+			total = 1.0
+		case PoolingSIF:
+			weight := v.sifWeight / (v.sifWeight + v.probability(word))
+			for i, e := range embedding {
+				pooled[i] += weight * float64(e)
+			}
+			total += 1.0
+		default:
+			for i, e := range embedding {
+				pooled[i] += float64(e)
+			}
+			total += 1.0
+		}
+	}
+
+	// Average the sums; the max is already pooled
+	if v.method != PoolingMax && total > 0.0 {
+		vector.ScaleInPlace(pooled, 1.0/total)
+	}
+	return pooled, nil
+}
+
+// Returns the word with a vector for the token and its vector, trying the
+// token as is and then in lowercase.
+func (v *PoolingVectorizer) lookup(token string) (word string, embedding vector.Vector32, ok bool) {
+	if embedding, ok = v.vectors.Vector(token); ok {
+		return token, embedding, true
+	}
+	word = strings.ToLower(token)
+	if embedding, ok = v.vectors.Vector(word); ok {
+		return word, embedding, true
+	}
+	return "", nil, false
+}
+
+// Returns the probability of the word, from the word frequencies if they were
+// provided, or otherwise from Zipf's law for the rank of the word, where the
+// word at rank r (from 1) of n words has a probability of `1 / (r * H(n))`.
+func (v *PoolingVectorizer) probability(word string) float64 {
+	if v.frequencies != nil {
+		return v.frequencies[word]
+	}
+	rank, _ := v.vectors.Rank(word)
+	return 1.0 / (float64(rank+1) * v.harmonic)
+}
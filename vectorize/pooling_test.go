@@ -0,0 +1,125 @@
+package vectorize_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+// Returns small word vectors sorted by descending frequency for the tests.
+func testWordVectors(t *testing.T) *vectorize.WordVectors {
+	wv, err := vectorize.NewWordVectors(
+		[]string{"the", "cat", "sat", "mat"},
+		[]vector.Vector32{{1, 1}, {4, -2}, {-2, 6}, {0, 0.5}},
+	)
+	require.NoError(t, err)
+	return wv
+}
+
+func TestNewPoolingVectorizer(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		pooling, err := vectorize.NewPoolingVectorizer(vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)))
+		require.NoError(t, err)
+		require.Equal(t, vectorize.PoolingMean, pooling.Method())
+		require.Equal(t, vectorize.DefaultSIFWeight, pooling.SIFWeight())
+		require.IsType(t, &tokenize.RegexTokenizer{}, pooling.Tokenizer())
+	})
+
+	t.Run("ErrorMissingWordVectors", func(t *testing.T) {
+		_, err := vectorize.NewPoolingVectorizer()
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+
+	t.Run("ErrorUnknownMethod", func(t *testing.T) {
+		_, err := vectorize.NewPoolingVectorizer(
+			vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)),
+			vectorize.PoolingVectorizerWithMethod(vectorize.PoolingSIF+1),
+		)
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+	})
+}
+
+func TestPoolingVectorizer(t *testing.T) {
+	// "dog" has no vector and "The" is found in lowercase
+	chunk := "The cat sat, dog!"
+
+	t.Run("Mean", func(t *testing.T) {
+		pooling, err := vectorize.NewPoolingVectorizer(vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)))
+		require.NoError(t, err)
+
+		embedding, err := pooling.Vectorize(chunk)
+		require.NoError(t, err)
+		require.InDeltaSlice(t, vector.Vector{1, 5.0 / 3.0}, embedding, 1e-9)
+	})
+
+	t.Run("Max", func(t *testing.T) {
+		pooling, err := vectorize.NewPoolingVectorizer(
+			vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)),
+			vectorize.PoolingVectorizerWithMethod(vectorize.PoolingMax),
+		)
+		require.NoError(t, err)
+
+		embedding, err := pooling.Vectorize(chunk)
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{4, 6}, embedding)
+
+		// Negative elements are kept when all the vectors are negative
+		embedding, err = pooling.Vectorize("cat")
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{4, -2}, embedding)
+	})
+
+	t.Run("SIFFrequencies", func(t *testing.T) {
+		pooling, err := vectorize.NewPoolingVectorizer(
+			vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)),
+			vectorize.PoolingVectorizerWithMethod(vectorize.PoolingSIF),
+			vectorize.PoolingVectorizerWithSIFWeight(0.1),
+			vectorize.PoolingVectorizerWithWordFrequencies(map[string]int{"the": 6, "cat": 3, "sat": 1}),
+		)
+		require.NoError(t, err)
+
+		// Weights: the 0.1/0.7, cat 0.1/0.4, sat 0.1/0.2
+		weights := []float64{1.0 / 7.0, 0.25, 0.5}
+		expected := vector.Vector{
+			(weights[0]*1 + weights[1]*4 + weights[2]*-2) / 3,
+			(weights[0]*1 + weights[1]*-2 + weights[2]*6) / 3,
+		}
+
+		embedding, err := pooling.Vectorize(chunk)
+		require.NoError(t, err)
+		require.InDeltaSlice(t, expected, embedding, 1e-9)
+	})
+
+	t.Run("SIFZipf", func(t *testing.T) {
+		pooling, err := vectorize.NewPoolingVectorizer(
+			vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)),
+			vectorize.PoolingVectorizerWithMethod(vectorize.PoolingSIF),
+		)
+		require.NoError(t, err)
+
+		// The more frequent "the" is weighted less than the rarer "mat"
+		harmonic := math.Log(4) + 0.5772156649015329 + 1.0/8.0
+		weightThe := 1e-3 / (1e-3 + 1/(1*harmonic))
+		weightMat := 1e-3 / (1e-3 + 1/(4*harmonic))
+		expected := vector.Vector{weightThe / 2, (weightThe + 0.5*weightMat) / 2}
+
+		embedding, err := pooling.Vectorize("the mat")
+		require.NoError(t, err)
+		require.InDeltaSlice(t, expected, embedding, 1e-9)
+		require.Less(t, weightThe, weightMat)
+	})
+
+	t.Run("NoKnownWords", func(t *testing.T) {
+		pooling, err := vectorize.NewPoolingVectorizer(vectorize.PoolingVectorizerWithWordVectors(testWordVectors(t)))
+		require.NoError(t, err)
+
+		embedding, err := pooling.Vectorize("dog and bird")
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{0, 0}, embedding)
+	})
+}
@@ -0,0 +1,411 @@
+package vectorize
+
+import (
+	"math"
+	"slices"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// TfidfVectorizer
+// ############################################################################
+
+/*
+TfidfVectorizer can be used to vectorize text using term frequency-inverse
+document frequency (TF-IDF) weighting, as defined by SLP 3rd Edition section
+6.5. The document frequencies are learned from a corpus of chunks using
+[TfidfVectorizer.Fit] before any text can be vectorized.
+
+Usage example:
+
+	// Create a new [TfidfVectorizer]; if no vocabulary is provided then the
+	// vocabulary will be learned from the corpus when it is fit
+	tfidf, err := vectorize.NewTfidfVectorizer()
+
+	// Learn the document frequencies from a corpus of chunks
+	err = tfidf.Fit([]string{
+		"the cat sat on the mat",
+		"the dog sat on the log",
+		"cats and dogs",
+	})
+
+	// Vectorize a chunk of text using the learned weights
+	myVector, err := tfidf.Vectorize("the cat and the dog")
+*/
+type TfidfVectorizer struct {
+	vocab       []string
+	lang        language.Language
+	tokenizer   tokenize.Tokenizer
+	stemmer     stem.Stemmer
+	typeCounter *tokenize.TypeCounter
+	smooth      bool
+	sublinear   bool
+	normalize   bool
+
+	// Learned by [TfidfVectorizer.Fit]
+	learned  bool             // true if the vocabulary was learned rather than configured
+	stems    []string         // the stem for each vocabulary index
+	index    map[string][]int // maps each stem to it's vocabulary indices
+	docFreqs []int            // document frequency for each vocabulary index
+	idf      []float64        // inverse document frequency for each vocabulary index
+	docCount int
+}
+
+// Ensure [TfidfVectorizer] meets the [SparseVectorizer] interface requirements.
+var _ SparseVectorizer = &TfidfVectorizer{}
+
+// Returns a new [TfidfVectorizer] instance. The vectorizer must be fit with
+// [TfidfVectorizer.Fit] before it can be used to vectorize text.
+//
+// Defaults:
+//   - Vocab: nil (learned from the corpus in [TfidfVectorizer.Fit])
+//   - Lang: [language.English]
+//   - Tokenizer: [tokenize.RegexTokenizer]
+//   - Stemmer: [stem.Porter2Stemmer]
+//   - TypeCounter: [tokenize.TypeCounter]
+//   - Smooth IDF: true
+//   - Sublinear TF: false
+//   - Normalize (L2): true
+func NewTfidfVectorizer(opts ...TfidfVectorizerOption) (vectorizer *TfidfVectorizer, err error) {
+	// Set defaults which are true, then set options
+	vectorizer = &TfidfVectorizer{
+		smooth:    true,
+		normalize: true,
+	}
+	for _, fn := range opts {
+		fn(vectorizer)
+	}
+
+	// Set defaults
+
+	if vectorizer.lang == language.Unknown {
+		vectorizer.lang = language.English
+	}
+
+	if vectorizer.tokenizer == nil {
+		vectorizer.tokenizer = tokenize.NewRegexTokenizer(tokenize.RegexTokenizerWithLanguage(vectorizer.lang))
+	}
+
+	if vectorizer.stemmer == nil {
+		if vectorizer.stemmer, err = stem.NewPorter2Stemmer(vectorizer.lang); err != nil {
+			return nil, err
+		}
+	}
+
+	if vectorizer.typeCounter == nil {
+		if vectorizer.typeCounter, err = tokenize.NewTypeCounter(
+			tokenize.TypeCounterWithLanguage(vectorizer.lang),
+			tokenize.TypeCounterWithTokenizer(vectorizer.tokenizer),
+			tokenize.TypeCounterWithStemmer(vectorizer.stemmer),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return vectorizer, nil
+}
+
+// Returns the [TfidfVectorizer]s vocabulary. If no vocabulary was configured
+// then this will be the vocabulary learned by [TfidfVectorizer.Fit], which is
+// made of the stems found in the corpus rather than the words.
+func (v *TfidfVectorizer) Vocab() []string {
+	return v.vocab
+}
+
+// Returns the [TfidfVectorizer]s configured [language.Language].
+func (v *TfidfVectorizer) Language() language.Language {
+	return v.lang
+}
+
+// Returns the [TfidfVectorizer]s configured [tokenize.Tokenizer].
+func (v *TfidfVectorizer) Tokenizer() tokenize.Tokenizer {
+	return v.tokenizer
+}
+
+// Returns the [TfidfVectorizer]s configured [stem.Stemmer].
+func (v *TfidfVectorizer) Stemmer() stem.Stemmer {
+	return v.stemmer
+}
+
+// Returns the [TfidfVectorizer]s configured [tokenize.TypeCounter].
+func (v *TfidfVectorizer) TypeCounter() *tokenize.TypeCounter {
+	return v.typeCounter
+}
+
+// Returns the [VectorizationMethod] for the [TfidfVectorizer], which is always
+// [VectorizeTFIDF].
+func (v *TfidfVectorizer) Method() VectorizationMethod {
+	return VectorizeTFIDF
+}
+
+// Returns true if the [TfidfVectorizer] uses smoothed inverse document
+// frequencies.
+func (v *TfidfVectorizer) Smooth() bool {
+	return v.smooth
+}
+
+// Returns true if the [TfidfVectorizer] uses sublinear term frequencies.
+func (v *TfidfVectorizer) Sublinear() bool {
+	return v.sublinear
+}
+
+// Returns true if the [TfidfVectorizer] L2-normalizes the vectors it returns.
+func (v *TfidfVectorizer) Normalize() bool {
+	return v.normalize
+}
+
+// Returns true if [TfidfVectorizer.Fit] has been called successfully.
+func (v *TfidfVectorizer) Fitted() bool {
+	return v.idf != nil
+}
+
+// Returns the number of documents the [TfidfVectorizer] was fit on.
+func (v *TfidfVectorizer) DocumentCount() int {
+	return v.docCount
+}
+
+// Returns the document frequency for each vocabulary index learned by
+// [TfidfVectorizer.Fit].
+func (v *TfidfVectorizer) DocumentFrequencies() []int {
+	return v.docFreqs
+}
+
+// Returns the inverse document frequency for each vocabulary index learned by
+// [TfidfVectorizer.Fit].
+func (v *TfidfVectorizer) IDF() []float64 {
+	return v.idf
+}
+
+// Fit learns the document frequency of each vocabulary stem from the corpus of
+// chunks. If no vocabulary was configured, the vocabulary will be set to the
+// sorted list of every stem found in the corpus. Calling Fit again replaces
+// everything learned by the previous call.
+func (v *TfidfVectorizer) Fit(chunks []string) (err error) {
+	// Count the number of documents each stem appears in
+	docFreqs := make(map[string]int)
+	for _, chunk := range chunks {
+		var types map[string]int
+		if types, err = v.typeCounter.TypeCount(chunk); err != nil {
+			return err
+		}
+		for stem := range types {
+			docFreqs[stem] += 1
+		}
+	}
+
+	// Get the stems for the vocabulary, learning the vocabulary if needed; a
+	// vocabulary learned by a previous call is learned again from this corpus
+	if v.vocab == nil || v.learned {
+		v.stems = make([]string, 0, len(docFreqs))
+		for stem := range docFreqs {
+			v.stems = append(v.stems, stem)
+		}
+		slices.Sort(v.stems)
+		v.vocab = v.stems
+		v.learned = true
+	} else {
+		// Stem the vocab words with the same stemmer as the type counter uses
+		v.stems = make([]string, 0, len(v.vocab))
+		for _, word := range v.vocab {
+			v.stems = append(v.stems, v.typeCounter.Stemmer().Stem(word))
+		}
+	}
+
+	// Calculate the inverse document frequency for each vocabulary index
+	v.docCount = len(chunks)
+	v.index = make(map[string][]int, len(v.stems))
+	v.docFreqs = make([]int, len(v.stems))
+	v.idf = make([]float64, len(v.stems))
+	for i, stem := range v.stems {
+		v.index[stem] = append(v.index[stem], i)
+		v.docFreqs[i] = docFreqs[stem]
+		v.idf[i] = InverseDocumentFrequency(v.docCount, v.docFreqs[i], v.smooth)
+	}
+
+	return nil
+}
+
+// Vectorizes the chunk of text using the TF-IDF weights learned by
+// [TfidfVectorizer.Fit]. Returns [errors.ErrMissingConfig] if the vectorizer
+// has not been fit.
+func (v *TfidfVectorizer) Vectorize(chunk string) (vec vector.Vector, err error) {
+	// We need to have been fit on a corpus to use this function
+	if !v.Fitted() {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the vectorizer must be fit on a corpus before use"))
+	}
+
+	// Type count the text
+	var types map[string]int
+	if types, err = v.typeCounter.TypeCount(chunk); err != nil {
+		return nil, err
+	}
+
+	// Weight each vocabulary index by it's term frequency and IDF
+	vec = make(vector.Vector, len(v.stems))
+	for i, stem := range v.stems {
+		if count, ok := types[stem]; ok {
+			vec[i] = TermFrequency(count, v.sublinear) * v.idf[i]
+		}
+	}
+
+	// Normalize the vector to unit length
+	if v.normalize {
+		if length := vec.Magnitude(); length != 0.0 {
+			for i := range vec {
+				vec[i] /= length
+			}
+		}
+	}
+
+	return vec, nil
+}
+
+// Vectorizes the chunk of text as a [vector.Sparse] using the TF-IDF weights
+// learned by [TfidfVectorizer.Fit], which has the same elements as the vector
+// from [TfidfVectorizer.Vectorize] without storing the zeros. Returns
+// [errors.ErrMissingConfig] if the vectorizer has not been fit.
+func (v *TfidfVectorizer) VectorizeSparse(chunk string) (vec *vector.Sparse, err error) {
+	// We need to have been fit on a corpus to use this function
+	if !v.Fitted() {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("the vectorizer must be fit on a corpus before use"))
+	}
+
+	// Type count the text
+	var types map[string]int
+	if types, err = v.typeCounter.TypeCount(chunk); err != nil {
+		return nil, err
+	}
+
+	// Weight the vocabulary indices of the stems in the text
+	var (
+		indices []int
+		values  []float64
+	)
+	for stem, count := range types {
+		for _, i := range v.index[stem] {
+			indices = append(indices, i)
+			values = append(values, TermFrequency(count, v.sublinear)*v.idf[i])
+		}
+	}
+
+	if vec, err = vector.NewSparse(len(v.stems), indices, values); err != nil {
+		return nil, err
+	}
+
+	// Normalize the vector to unit length
+	if v.normalize {
+		vec = vec.Normalize()
+	}
+
+	return vec, nil
+}
+
+// ############################################################################
+// TF-IDF Weighting Functions
+// ############################################################################
+
+// TermFrequency returns the weight for a term that occurs count times in a
+// document. If sublinear is true, the weight is `1 + ln(count)`, otherwise it
+// is the raw count. A count of zero or less always has a weight of zero.
+func TermFrequency(count int, sublinear bool) float64 {
+	if count <= 0 {
+		return 0.0
+	}
+	if sublinear {
+		return 1.0 + math.Log(float64(count))
+	}
+	return float64(count)
+}
+
+// InverseDocumentFrequency returns the IDF weight for a term which appears in
+// docFreq of the docCount documents in a corpus. If smooth is true, the weight
+// is `ln((1 + docCount) / (1 + docFreq)) + 1`, which acts as if an extra
+// document containing every term was seen, otherwise it is
+// `ln(docCount / docFreq) + 1`. When not smoothing, a term which appears in no
+// documents has a weight of zero.
+func InverseDocumentFrequency(docCount, docFreq int, smooth bool) float64 {
+	if smooth {
+		return math.Log(float64(1+docCount)/float64(1+docFreq)) + 1.0
+	}
+	if docFreq <= 0 || docCount <= 0 {
+		return 0.0
+	}
+	return math.Log(float64(docCount)/float64(docFreq)) + 1.0
+}
+
+// ############################################################################
+// TfidfVectorizerOption
+// ############################################################################
+
+// TfidfVectorizerOption functions modify a [TfidfVectorizer].
+type TfidfVectorizerOption func(c *TfidfVectorizer)
+
+// TfidfVectorizerWithVocab sets the vocabulary to use with the
+// [TfidfVectorizer]. If not set, the vocabulary is learned by
+// [TfidfVectorizer.Fit].
+func TfidfVectorizerWithVocab(vocab []string) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.vocab = vocab
+	}
+}
+
+// TfidfVectorizerWithLang sets the [language.Language] to use with the
+// [TfidfVectorizer].
+func TfidfVectorizerWithLang(lang language.Language) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.lang = lang
+	}
+}
+
+// TfidfVectorizerWithTokenizer sets the [tokenize.Tokenizer] to use with the
+// [TfidfVectorizer].
+func TfidfVectorizerWithTokenizer(tokenizer tokenize.Tokenizer) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.tokenizer = tokenizer
+	}
+}
+
+// TfidfVectorizerWithStemmer sets the [stem.Stemmer] to use with the
+// [TfidfVectorizer].
+func TfidfVectorizerWithStemmer(stemmer stem.Stemmer) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.stemmer = stemmer
+	}
+}
+
+// TfidfVectorizerWithTypeCounter sets the [tokenize.TypeCounter] to use with
+// the [TfidfVectorizer].
+func TfidfVectorizerWithTypeCounter(typecounter *tokenize.TypeCounter) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.typeCounter = typecounter
+	}
+}
+
+// TfidfVectorizerWithSmoothing sets whether the [TfidfVectorizer] smooths the
+// inverse document frequencies (see [InverseDocumentFrequency]).
+func TfidfVectorizerWithSmoothing(smooth bool) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.smooth = smooth
+	}
+}
+
+// TfidfVectorizerWithSublinearTF sets whether the [TfidfVectorizer] uses
+// sublinear term frequencies (see [TermFrequency]).
+func TfidfVectorizerWithSublinearTF(sublinear bool) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.sublinear = sublinear
+	}
+}
+
+// TfidfVectorizerWithNormalization sets whether the [TfidfVectorizer]
+// L2-normalizes the vectors it returns.
+func TfidfVectorizerWithNormalization(normalize bool) TfidfVectorizerOption {
+	return func(c *TfidfVectorizer) {
+		c.normalize = normalize
+	}
+}
@@ -0,0 +1,210 @@
+package vectorize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/similarity"
+	"go.rtnl.ai/nlp/stem"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+func TestNewTfidfVectorizer(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		vectorizer, err := vectorize.NewTfidfVectorizer()
+		require.NoError(t, err)
+		require.NotNil(t, vectorizer)
+		require.Nil(t, vectorizer.Vocab())
+		require.Equal(t, language.English, vectorizer.Language())
+		require.Equal(t, vectorize.VectorizeTFIDF, vectorizer.Method())
+		require.True(t, vectorizer.Smooth())
+		require.False(t, vectorizer.Sublinear())
+		require.True(t, vectorizer.Normalize())
+		require.False(t, vectorizer.Fitted())
+	})
+
+	t.Run("SuccessVocabOption", func(t *testing.T) {
+		vocab := []string{"one", "two", "three"}
+		vectorizer, err := vectorize.NewTfidfVectorizer(vectorize.TfidfVectorizerWithVocab(vocab))
+		require.NoError(t, err)
+		require.NotNil(t, vectorizer)
+		require.Equal(t, vocab, vectorizer.Vocab())
+	})
+
+	t.Run("SuccessToolOptions", func(t *testing.T) {
+		tokenizer := tokenize.NewRegexTokenizer()
+		stemmer, err := stem.NewPorter2Stemmer(language.English)
+		require.NoError(t, err)
+		typecounter, err := tokenize.NewTypeCounter()
+		require.NoError(t, err)
+
+		vectorizer, err := vectorize.NewTfidfVectorizer(
+			vectorize.TfidfVectorizerWithLang(language.English),
+			vectorize.TfidfVectorizerWithTokenizer(tokenizer),
+			vectorize.TfidfVectorizerWithStemmer(stemmer),
+			vectorize.TfidfVectorizerWithTypeCounter(typecounter),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, vectorizer)
+		require.Equal(t, tokenizer, vectorizer.Tokenizer())
+		require.Equal(t, stemmer, vectorizer.Stemmer())
+		require.Equal(t, typecounter, vectorizer.TypeCounter())
+	})
+
+	t.Run("SuccessWeightingOptions", func(t *testing.T) {
+		vectorizer, err := vectorize.NewTfidfVectorizer(
+			vectorize.TfidfVectorizerWithSmoothing(false),
+			vectorize.TfidfVectorizerWithSublinearTF(true),
+			vectorize.TfidfVectorizerWithNormalization(false),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, vectorizer)
+		require.False(t, vectorizer.Smooth())
+		require.True(t, vectorizer.Sublinear())
+		require.False(t, vectorizer.Normalize())
+	})
+}
+
+func TestTfidfVectorizerFit(t *testing.T) {
+	corpus := []string{"one two", "one three", "one"}
+
+	t.Run("LearnedVocab", func(t *testing.T) {
+		vectorizer, err := vectorize.NewTfidfVectorizer()
+		require.NoError(t, err)
+
+		require.NoError(t, vectorizer.Fit(corpus))
+		require.True(t, vectorizer.Fitted())
+		require.Equal(t, 3, vectorizer.DocumentCount())
+		require.Equal(t, []string{"one", "three", "two"}, vectorizer.Vocab())
+		require.Equal(t, []int{3, 1, 1}, vectorizer.DocumentFrequencies())
+		require.InDeltaSlice(t, []float64{1.0, 1.6931471805599454, 1.6931471805599454}, vectorizer.IDF(), 1e-12)
+	})
+
+	t.Run("ConfiguredVocab", func(t *testing.T) {
+		vectorizer, err := vectorize.NewTfidfVectorizer(vectorize.TfidfVectorizerWithVocab([]string{"ones", "four"}))
+		require.NoError(t, err)
+
+		require.NoError(t, vectorizer.Fit(corpus))
+		require.Equal(t, []string{"ones", "four"}, vectorizer.Vocab())
+		require.Equal(t, []int{3, 0}, vectorizer.DocumentFrequencies())
+		require.InDeltaSlice(t, []float64{1.0, 2.386294361119891}, vectorizer.IDF(), 1e-12)
+
+		// Fitting again keeps the configured vocabulary
+		require.NoError(t, vectorizer.Fit([]string{"four", "one four"}))
+		require.Equal(t, []string{"ones", "four"}, vectorizer.Vocab())
+		require.Equal(t, []int{1, 2}, vectorizer.DocumentFrequencies())
+	})
+
+	t.Run("RefitLearnedVocab", func(t *testing.T) {
+		vectorizer, err := vectorize.NewTfidfVectorizer()
+		require.NoError(t, err)
+
+		// The learned vocabulary is made of stems
+		require.NoError(t, vectorizer.Fit([]string{"generously generous", "generation"}))
+		require.Equal(t, []string{"generat", "generous"}, vectorizer.Vocab())
+
+		// Fitting again learns the vocabulary from the new corpus only
+		require.NoError(t, vectorizer.Fit([]string{"apple banana", "cherry"}))
+		require.Equal(t, 2, vectorizer.DocumentCount())
+		require.Equal(t, []string{"appl", "banana", "cherri"}, vectorizer.Vocab())
+		require.Equal(t, []int{1, 1, 1}, vectorizer.DocumentFrequencies())
+
+		vec, err := vectorizer.Vectorize("generous cherry")
+		require.NoError(t, err)
+		require.Equal(t, []float64{0, 0, 1}, []float64(vec))
+	})
+}
+
+func TestTfidfVectorizerVectorize(t *testing.T) {
+	corpus := []string{"one two", "one three", "one"}
+	testcases := []struct {
+		Name     string
+		Options  []vectorize.TfidfVectorizerOption
+		Text     string
+		Expected vector.Vector
+	}{
+		{
+			Name:     "Defaults",
+			Text:     "one two two",
+			Expected: vector.Vector{0.2832169249871526, 0.0, 0.95905587605771}, // calculated in Python 3.13.4
+		},
+		{
+			Name:     "NotNormalized",
+			Options:  []vectorize.TfidfVectorizerOption{vectorize.TfidfVectorizerWithNormalization(false)},
+			Text:     "one two two",
+			Expected: vector.Vector{1.0, 0.0, 3.386294361119891}, // calculated in Python 3.13.4
+		},
+		{
+			Name: "SublinearNotSmoothNotNormalized",
+			Options: []vectorize.TfidfVectorizerOption{
+				vectorize.TfidfVectorizerWithSmoothing(false),
+				vectorize.TfidfVectorizerWithSublinearTF(true),
+				vectorize.TfidfVectorizerWithNormalization(false),
+			},
+			Text:     "one two two",
+			Expected: vector.Vector{1.0, 0.0, 3.5532594796468646}, // calculated in Python 3.13.4
+		},
+		{
+			Name:     "NoVocabWords",
+			Text:     "zebra",
+			Expected: vector.Vector{0.0, 0.0, 0.0},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			//setup
+			vectorizer, err := vectorize.NewTfidfVectorizer(tc.Options...)
+			require.NoError(t, err)
+			require.NoError(t, vectorizer.Fit(corpus))
+
+			//test
+			actual, err := vectorizer.Vectorize(tc.Text)
+			require.NoError(t, err)
+			require.InDeltaSlice(t, tc.Expected, actual, 1e-12)
+
+			// The sparse vector has the same elements
+			sparse, err := vectorizer.VectorizeSparse(tc.Text)
+			require.NoError(t, err)
+			require.Equal(t, actual, sparse.Dense())
+		})
+	}
+
+	t.Run("ErrorNotFit", func(t *testing.T) {
+		vectorizer, err := vectorize.NewTfidfVectorizer()
+		require.NoError(t, err)
+
+		actual, err := vectorizer.Vectorize("one two")
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, actual)
+
+		sparse, err := vectorizer.VectorizeSparse("one two")
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, sparse)
+	})
+}
+
+func TestTfidfVectorizerCosineSimilarity(t *testing.T) {
+	vectorizer, err := vectorize.NewTfidfVectorizer()
+	require.NoError(t, err)
+	require.NoError(t, vectorizer.Fit([]string{
+		"the cat sat on the mat",
+		"the dog sat on the log",
+		"the cats and the dogs",
+	}))
+
+	sim, err := similarity.NewCosineSimilarizer(similarity.CosineSimilarizerWithVectorizer(vectorizer))
+	require.NoError(t, err)
+
+	// The common word "the" should not make these two chunks similar
+	common, err := sim.Similarity("the cat", "the dog")
+	require.NoError(t, err)
+	same, err := sim.Similarity("the cat", "the cats")
+	require.NoError(t, err)
+	require.Less(t, common, same)
+	require.InDelta(t, 1.0, same, 1e-12)
+}
@@ -12,6 +12,20 @@ type Vectorizer interface {
 	Vectorize(chunk string) (vector vector.Vector, err error)
 }
 
+// SparseVectorizer is a [Vectorizer] which can also create [vector.Sparse]
+// vectors, which only store the non-zero elements.
+type SparseVectorizer interface {
+	Vectorizer
+	VectorizeSparse(chunk string) (vector *vector.Sparse, err error)
+}
+
+// BatchVectorizer is a [Vectorizer] which can vectorize several chunks at once,
+// such as the embedding API clients which send them in a single request.
+type BatchVectorizer interface {
+	Vectorizer
+	VectorizeAll(chunks []string) (vectors []vector.Vector, err error)
+}
+
 // ############################################################################
 // VectorizationMethod "enum"
 // ############################################################################
@@ -22,4 +36,6 @@ const (
 	VectorizeUnknown VectorizationMethod = iota
 	VectorizeOneHot
 	VectorizeFrequency
+	VectorizeTFIDF
+	VectorizeTermFrequency
 )
@@ -0,0 +1,313 @@
+package vectorize
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/stopwords"
+	"go.rtnl.ai/nlp/tokenize"
+)
+
+// ############################################################################
+// VocabularyBuilder
+// ############################################################################
+
+/*
+VocabularyBuilder can be used to build a vocabulary for a [CountVectorizer],
+[TfidfVectorizer], or a `text.Text` (using `text.WithVocabulary`) from a corpus
+of chunks rather than curating one by hand; create with [NewVocabularyBuilder].
+
+Each vocabulary entry represents a single stem. Because stemming a stem does not
+always return the same stem, the vocabulary contains the most common (lowercase)
+word found in the corpus for each stem, so that stemming the vocabulary word
+gives back the stem that was counted.
+
+Usage example:
+
+	// Create a builder which ignores stop words and rare words
+	builder, err := vectorize.NewVocabularyBuilder(
+		vectorize.VocabularyBuilderWithStopWords(true),
+		vectorize.VocabularyBuilderWithMinDocFreq(2),
+	)
+
+	// Add the corpus; this can be called as many times as needed
+	err = builder.Add(
+		"the cat sat on the mat",
+		"the dog sat on the log",
+		"cats and dogs",
+	)
+
+	// Build the vocabulary
+	vocab := builder.Build() // []string{"cat", "dog", "sat"}
+*/
+type VocabularyBuilder struct {
+	lang          language.Language
+	typeCounter   *tokenize.TypeCounter
+	excludeStops  bool
+	stopWords     stopwords.StopwordSet
+	minDocFreq    int
+	maxDocFreq    int
+	minDocRatio   float64
+	maxDocRatio   float64
+	maxFeatures   int
+	docCount      int
+	docFreqs      map[string]int            // stem -> number of documents
+	termFreqs     map[string]int            // stem -> number of instances
+	surfaceCounts map[string]map[string]int // stem -> word -> number of instances
+}
+
+// Returns a new [VocabularyBuilder] instance.
+//
+// Defaults:
+//   - Lang: [language.English]
+//   - TypeCounter: [tokenize.TypeCounter]
+//   - Stop words: included (the stop words of the language when excluded)
+//   - Min document frequency: 1
+//   - Max document frequency: unlimited
+//   - Min document ratio: 0.0
+//   - Max document ratio: 1.0
+//   - Max features: unlimited
+func NewVocabularyBuilder(opts ...VocabularyBuilderOption) (builder *VocabularyBuilder, err error) {
+	// Set defaults which are not zero values, then set options
+	builder = &VocabularyBuilder{
+		maxDocRatio: 1.0,
+	}
+	for _, fn := range opts {
+		fn(builder)
+	}
+
+	// Set defaults
+
+	if builder.lang == language.Unknown {
+		builder.lang = language.English
+	}
+
+	if builder.excludeStops && builder.stopWords == nil {
+		// An unsupported language has no stop words, so nothing is excluded
+		builder.stopWords, _ = stopwords.ForLanguage(builder.lang)
+	}
+
+	if builder.typeCounter == nil {
+		if builder.typeCounter, err = tokenize.NewTypeCounter(
+			tokenize.TypeCounterWithLanguage(builder.lang),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate options
+	if builder.minDocFreq < 0 || builder.maxDocFreq < 0 || builder.maxFeatures < 0 {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("document frequencies and max features cannot be negative"))
+	}
+	if builder.minDocRatio < 0.0 || 1.0 < builder.maxDocRatio || builder.maxDocRatio < builder.minDocRatio {
+		return nil, errors.Join(errors.ErrMissingConfig, errors.New("document ratios must be in the range [0.0, 1.0] with the min less than the max"))
+	}
+
+	builder.Reset()
+
+	return builder, nil
+}
+
+// Returns the [VocabularyBuilder]s configured [language.Language].
+func (b *VocabularyBuilder) Language() language.Language {
+	return b.lang
+}
+
+// Returns the [VocabularyBuilder]s configured [tokenize.TypeCounter].
+func (b *VocabularyBuilder) TypeCounter() *tokenize.TypeCounter {
+	return b.typeCounter
+}
+
+// Returns the number of documents added to the [VocabularyBuilder].
+func (b *VocabularyBuilder) DocumentCount() int {
+	return b.docCount
+}
+
+// Returns the number of documents added to the [VocabularyBuilder] which
+// contain the stem of the given word.
+func (b *VocabularyBuilder) DocumentFrequency(word string) int {
+	return b.docFreqs[b.typeCounter.Stemmer().Stem(word)]
+}
+
+// Removes all documents which were added to the [VocabularyBuilder].
+func (b *VocabularyBuilder) Reset() {
+	b.docCount = 0
+	b.docFreqs = make(map[string]int)
+	b.termFreqs = make(map[string]int)
+	b.surfaceCounts = make(map[string]map[string]int)
+}
+
+// Add tokenizes, stems, and counts each of the chunks as a separate document.
+func (b *VocabularyBuilder) Add(chunks ...string) (err error) {
+	for _, chunk := range chunks {
+		// Tokenize the chunk using the type counter's tokenizer
+		var tokens []string
+		if tokens, err = b.typeCounter.Tokenizer().Tokenize(chunk); err != nil {
+			return err
+		}
+
+		// Remove stop words, lowercase, and record the surface form of each stem
+		stems := make([]string, 0, len(tokens))
+		for _, tok := range tokens {
+			if b.excludeStops && b.stopWords.Contains(tok) {
+				continue
+			}
+			word := strings.ToLower(tok)
+			stem := b.typeCounter.Stemmer().Stem(word)
+			if b.surfaceCounts[stem] == nil {
+				b.surfaceCounts[stem] = make(map[string]int)
+			}
+			b.surfaceCounts[stem][word] += 1
+			stems = append(stems, stem)
+		}
+
+		// Count the types in the document
+		for stem, count := range b.typeCounter.CountTypes(stems) {
+			b.docFreqs[stem] += 1
+			b.termFreqs[stem] += count
+		}
+		b.docCount += 1
+	}
+	return nil
+}
+
+// Build returns the vocabulary for the documents added so far, filtered by the
+// configured document frequency and ratio limits. If a max features limit is
+// set, the most frequent stems across the corpus are kept, with ties broken by
+// the alphabetical order of the stems. The vocabulary is always returned in
+// alphabetical order so it is deterministic for the same corpus.
+func (b *VocabularyBuilder) Build() (vocab []string) {
+	// Filter the stems by document frequency
+	stems := make([]string, 0, len(b.docFreqs))
+	for stem, df := range b.docFreqs {
+		if df < b.minDocFreq || (b.maxDocFreq != 0 && b.maxDocFreq < df) {
+			continue
+		}
+		ratio := float64(df) / float64(b.docCount)
+		if ratio < b.minDocRatio || b.maxDocRatio < ratio {
+			continue
+		}
+		stems = append(stems, stem)
+	}
+
+	// Keep only the most frequent stems
+	if b.maxFeatures != 0 && b.maxFeatures < len(stems) {
+		slices.SortFunc(stems, func(x, y string) int {
+			if c := cmp.Compare(b.termFreqs[y], b.termFreqs[x]); c != 0 {
+				return c
+			}
+			return cmp.Compare(x, y)
+		})
+		stems = stems[:b.maxFeatures]
+	}
+
+	// Get the most common word for each stem
+	vocab = make([]string, 0, len(stems))
+	for _, stem := range stems {
+		vocab = append(vocab, mostCommonWord(b.surfaceCounts[stem]))
+	}
+	slices.Sort(vocab)
+
+	return vocab
+}
+
+// Returns the word with the highest count, with ties broken by alphabetical
+// order.
+func mostCommonWord(counts map[string]int) (word string) {
+	best := math.MinInt
+	for w, count := range counts {
+		if best < count || (count == best && w < word) {
+			word, best = w, count
+		}
+	}
+	return word
+}
+
+// ############################################################################
+// VocabularyBuilderOption
+// ############################################################################
+
+// VocabularyBuilderOption functions modify a [VocabularyBuilder].
+type VocabularyBuilderOption func(b *VocabularyBuilder)
+
+// VocabularyBuilderWithLang sets the [language.Language] to use with the
+// [VocabularyBuilder].
+func VocabularyBuilderWithLang(lang language.Language) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.lang = lang
+	}
+}
+
+// VocabularyBuilderWithTypeCounter sets the [tokenize.TypeCounter] to use with
+// the [VocabularyBuilder]; it's tokenizer and stemmer are used on each
+// document.
+func VocabularyBuilderWithTypeCounter(typecounter *tokenize.TypeCounter) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.typeCounter = typecounter
+	}
+}
+
+// VocabularyBuilderWithStopWords sets whether the [VocabularyBuilder] excludes
+// stop words (see [stopwords.IsStopWord]) from the vocabulary.
+func VocabularyBuilderWithStopWords(exclude bool) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.excludeStops = exclude
+	}
+}
+
+// VocabularyBuilderWithStopWordSet sets the [stopwords.StopwordSet] which the
+// [VocabularyBuilder] excludes from the vocabulary instead of the stop words of
+// the language, e.g. one tailored to a domain with a
+// [stopwords.StopwordBuilder].
+func VocabularyBuilderWithStopWordSet(set stopwords.StopwordSet) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.excludeStops = true
+		b.stopWords = set
+	}
+}
+
+// VocabularyBuilderWithMinDocFreq sets the minimum number of documents a stem
+// must appear in to be included in the vocabulary.
+func VocabularyBuilderWithMinDocFreq(n int) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.minDocFreq = n
+	}
+}
+
+// VocabularyBuilderWithMaxDocFreq sets the maximum number of documents a stem
+// can appear in to be included in the vocabulary. Zero means unlimited.
+func VocabularyBuilderWithMaxDocFreq(n int) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.maxDocFreq = n
+	}
+}
+
+// VocabularyBuilderWithMinDocRatio sets the minimum proportion of documents in
+// the range [0.0, 1.0] a stem must appear in to be included in the vocabulary.
+func VocabularyBuilderWithMinDocRatio(ratio float64) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.minDocRatio = ratio
+	}
+}
+
+// VocabularyBuilderWithMaxDocRatio sets the maximum proportion of documents in
+// the range [0.0, 1.0] a stem can appear in to be included in the vocabulary.
+// This is useful to remove corpus-specific stop words.
+func VocabularyBuilderWithMaxDocRatio(ratio float64) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.maxDocRatio = ratio
+	}
+}
+
+// VocabularyBuilderWithMaxFeatures sets the maximum number of words in the
+// vocabulary, keeping the most frequent stems across the corpus. Zero means
+// unlimited.
+func VocabularyBuilderWithMaxFeatures(n int) VocabularyBuilderOption {
+	return func(b *VocabularyBuilder) {
+		b.maxFeatures = n
+	}
+}
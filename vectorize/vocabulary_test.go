@@ -0,0 +1,157 @@
+package vectorize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/language"
+	"go.rtnl.ai/nlp/stopwords"
+	"go.rtnl.ai/nlp/tokenize"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+func TestNewVocabularyBuilder(t *testing.T) {
+	t.Run("SuccessDefaults", func(t *testing.T) {
+		builder, err := vectorize.NewVocabularyBuilder()
+		require.NoError(t, err)
+		require.NotNil(t, builder)
+		require.Equal(t, language.English, builder.Language())
+		require.NotNil(t, builder.TypeCounter())
+		require.Equal(t, 0, builder.DocumentCount())
+	})
+
+	t.Run("SuccessTypeCounterOption", func(t *testing.T) {
+		typecounter, err := tokenize.NewTypeCounter()
+		require.NoError(t, err)
+
+		builder, err := vectorize.NewVocabularyBuilder(
+			vectorize.VocabularyBuilderWithLang(language.English),
+			vectorize.VocabularyBuilderWithTypeCounter(typecounter),
+		)
+		require.NoError(t, err)
+		require.Equal(t, typecounter, builder.TypeCounter())
+	})
+
+	t.Run("ErrorNegativeLimit", func(t *testing.T) {
+		builder, err := vectorize.NewVocabularyBuilder(vectorize.VocabularyBuilderWithMaxFeatures(-1))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, builder)
+	})
+
+	t.Run("ErrorRatioOutOfRange", func(t *testing.T) {
+		builder, err := vectorize.NewVocabularyBuilder(vectorize.VocabularyBuilderWithMaxDocRatio(1.5))
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, builder)
+	})
+
+	t.Run("ErrorRatiosReversed", func(t *testing.T) {
+		builder, err := vectorize.NewVocabularyBuilder(
+			vectorize.VocabularyBuilderWithMinDocRatio(0.8),
+			vectorize.VocabularyBuilderWithMaxDocRatio(0.2),
+		)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+		require.Nil(t, builder)
+	})
+}
+
+func TestVocabularyBuilderBuild(t *testing.T) {
+	corpus := []string{
+		"The cat sat on the mat.",
+		"The dog sat on the log.",
+		"Cats and dogs and more cats!",
+	}
+
+	testcases := []struct {
+		Name     string
+		Options  []vectorize.VocabularyBuilderOption
+		Expected []string
+	}{
+		{
+			Name:     "Defaults",
+			Expected: []string{"and", "cats", "dog", "log", "mat", "more", "on", "sat", "the"},
+		},
+		{
+			Name:     "StopWords",
+			Options:  []vectorize.VocabularyBuilderOption{vectorize.VocabularyBuilderWithStopWords(true)},
+			Expected: []string{"cats", "dog", "log", "mat", "sat"},
+		},
+		{
+			Name:     "StopWordSet",
+			Options:  []vectorize.VocabularyBuilderOption{vectorize.VocabularyBuilderWithStopWordSet(stopwords.NewStopwordSet("the", "on", "SAT"))},
+			Expected: []string{"and", "cats", "dog", "log", "mat", "more"},
+		},
+		{
+			Name:     "MinDocFreq",
+			Options:  []vectorize.VocabularyBuilderOption{vectorize.VocabularyBuilderWithMinDocFreq(2)},
+			Expected: []string{"cats", "dog", "on", "sat", "the"},
+		},
+		{
+			Name:     "MaxDocFreq",
+			Options:  []vectorize.VocabularyBuilderOption{vectorize.VocabularyBuilderWithMaxDocFreq(1)},
+			Expected: []string{"and", "log", "mat", "more"},
+		},
+		{
+			Name: "DocRatios",
+			Options: []vectorize.VocabularyBuilderOption{
+				vectorize.VocabularyBuilderWithMinDocRatio(0.5),
+				vectorize.VocabularyBuilderWithMaxDocRatio(0.9),
+			},
+			Expected: []string{"cats", "dog", "on", "sat", "the"},
+		},
+		{
+			Name: "MaxFeatures",
+			Options: []vectorize.VocabularyBuilderOption{
+				vectorize.VocabularyBuilderWithStopWords(true),
+				vectorize.VocabularyBuilderWithMaxFeatures(2),
+			},
+			// "cat" has 3 instances, "dog" and "sat" have 2 but "dog" sorts first
+			Expected: []string{"cats", "dog"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			builder, err := vectorize.NewVocabularyBuilder(tc.Options...)
+			require.NoError(t, err)
+			require.NoError(t, builder.Add(corpus...))
+			require.Equal(t, 3, builder.DocumentCount())
+
+			// Build twice to ensure the order is deterministic
+			require.Equal(t, tc.Expected, builder.Build())
+			require.Equal(t, tc.Expected, builder.Build())
+		})
+	}
+}
+
+func TestVocabularyBuilderDocumentFrequency(t *testing.T) {
+	builder, err := vectorize.NewVocabularyBuilder()
+	require.NoError(t, err)
+	require.NoError(t, builder.Add("cats and dogs", "a cat", "a dog"))
+	require.Equal(t, 2, builder.DocumentFrequency("cat"))
+	require.Equal(t, 2, builder.DocumentFrequency("dogs"))
+	require.Equal(t, 0, builder.DocumentFrequency("zebra"))
+
+	builder.Reset()
+	require.Equal(t, 0, builder.DocumentCount())
+	require.Equal(t, 0, builder.DocumentFrequency("cat"))
+}
+
+func TestVocabularyBuilderCountVectorizer(t *testing.T) {
+	builder, err := vectorize.NewVocabularyBuilder(vectorize.VocabularyBuilderWithStopWords(true))
+	require.NoError(t, err)
+	require.NoError(t, builder.Add("generously generous generously", "generosity"))
+	vocab := builder.Build()
+
+	vectorizer, err := vectorize.NewCountVectorizer(
+		vectorize.CountVectorizerWithVocab(vocab),
+		vectorize.CountVectorizerWithMethod(vectorize.VectorizeFrequency),
+	)
+	require.NoError(t, err)
+
+	// Every vocabulary word must stem back to a stem that was counted
+	actual, err := vectorizer.Vectorize("generously generous generosity")
+	require.NoError(t, err)
+	require.Equal(t, vector.Vector{1, 2}, actual)
+}
@@ -1,11 +1,13 @@
 package vectorize
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"go.rtnl.ai/nlp/errors"
 	"go.rtnl.ai/nlp/vector"
@@ -36,6 +38,10 @@ Usage Example:
 		vectorize.VoyageAIEmbedderWithAPIKey("your_voyageai_api_key_here"),
 		vectorize.VoyageAIEmbedderWithEndpoint("https://api.voyageai.com/v1/embeddings"),
 		vectorize.VoyageAIEmbedderWithModel("voyage-3.5-lite"),
+		// Optionally configure the timeout, retries, and client-side rate limits
+		vectorize.VoyageAIEmbedderWithTimeout(30*time.Second),
+		vectorize.VoyageAIEmbedderWithRetries(5),
+		vectorize.VoyageAIEmbedderWithRateLimit(300, 1_000_000),
 		)
 	checkErr(err)
 
@@ -44,10 +50,25 @@ Usage Example:
 	embedding, err := voyage.Vectorize(chunk1)
 	checkErr(err)
 
-	// Get several embeddings in a single VoyageAI API call
+	// Get several embeddings; large inputs are split into batches within the
+	// VoyageAI limits which are requested concurrently, and the requests can be
+	// cancelled with a context
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
 	chunks := []string{chunk1, "A slightly more complex test, but only slightly.", "Number three!"}
-	embeddings, err := voyage.VectorizeAll(chunks)
-	checkErr(err)
+	embeddings, err := voyage.VectorizeAllContext(ctx, chunks)
+
+	// If some batches fail the embeddings of the others are still returned, and
+	// the error reports which chunks failed; API errors can also be checked for
+	// their cause or status code
+	var batchErr *vectorize.BatchError
+	if errors.As(err, &batchErr) {
+		fmt.Printf("chunks %v failed\n", batchErr.Indexes())
+	}
+	var apiErr *vectorize.APIError
+	if errors.Is(err, errors.ErrRateLimited) && errors.As(err, &apiErr) {
+		fmt.Printf("rate limited; retry after %s\n", apiErr.RetryAfter)
+	}
 
 	// Print the number of VoyageAI usage tokens that were used
 	fmt.Printf("used %d tokens\n", voyage.TotalTokensUsed())
@@ -56,12 +77,20 @@ type VoyageAIEmbedder struct {
 	apiKey          string
 	endpoint        string
 	model           string
-	client          *http.Client
-	totalTokensUsed int
+	api             apiClient
+	totalTokensUsed atomic.Int64
 }
 
-// Ensure [VoyageAIEmbedder] meets the [Vectorizer] interface requirements.
-var _ Vectorizer = &VoyageAIEmbedder{}
+// The VoyageAI per-request limits; the token limit is the lowest of the models
+// (e.g. voyage-3-large), since it depends on the model.
+// See: https://docs.voyageai.com/reference/embeddings-api
+const (
+	VoyageAIMaxBatchItems  = 1000
+	VoyageAIMaxBatchTokens = 120_000
+)
+
+// Ensure [VoyageAIEmbedder] meets the [BatchVectorizer] interface requirements.
+var _ BatchVectorizer = &VoyageAIEmbedder{}
 
 // ############################################################################
 // VoyageAI Constructor and Options
@@ -73,6 +102,15 @@ var _ Vectorizer = &VoyageAIEmbedder{}
 // necessary option is not provided or found in the environment, then
 // [errors.ErrMissingConfig] will be returned alongside another more descriptive
 // error, so ensure you use [errors.Is] to disambiguate the errors.
+//
+// Defaults:
+//   - HTTP Client: [http.Client]
+//   - Timeout: [DefaultAPITimeout] for each attempt
+//   - Retries: [DefaultAPIMaxRetries]
+//   - Backoff: [DefaultAPIMinBackoff] doubling up to [DefaultAPIMaxBackoff]
+//   - Rate limit: none
+//   - Batch limits: [VoyageAIMaxBatchItems] and [VoyageAIMaxBatchTokens]
+//   - Concurrency: [DefaultAPIConcurrency]
 func NewVoyageAIEmbedder(opts ...VoyageAIEmbedderOption) (vectorizer *VoyageAIEmbedder, err error) {
 	// Initialize with options from the environment; the user must load the env
 	// vars somewhere else themselves.
@@ -80,7 +118,7 @@ func NewVoyageAIEmbedder(opts ...VoyageAIEmbedderOption) (vectorizer *VoyageAIEm
 		apiKey:   os.Getenv("VOYAGEAI_API_KEY"),
 		endpoint: os.Getenv("VOYAGEAI_EMBEDDING_ENDPOINT"),
 		model:    os.Getenv("VOYAGEAI_EMBEDDING_MODEL"),
-		client:   &http.Client{},
+		api:      newAPIClient(VoyageAIMaxBatchItems, VoyageAIMaxBatchTokens),
 	}
 
 	// Set user-provided options, overridding any environment variables
@@ -98,22 +136,69 @@ func NewVoyageAIEmbedder(opts ...VoyageAIEmbedderOption) (vectorizer *VoyageAIEm
 	if vectorizer.model == "" {
 		return nil, errors.Join(
 			errors.ErrMissingConfig,
-			errors.New("field 'model' is required; use option 'VoyageAIEmbedderWithModel()' or set the environment variable 'VOYAGEAI_EMBEDDING_ENDPOINT'."),
+			errors.New("field 'model' is required; use option 'VoyageAIEmbedderWithModel()' or set the environment variable 'VOYAGEAI_EMBEDDING_MODEL'."),
 		)
 	}
 	if vectorizer.endpoint == "" {
 		return nil, errors.Join(
 			errors.ErrMissingConfig,
-			errors.New("field 'endpoint' is required; use option 'VoyageAIEmbedderWithEndpoint()' or set the environment variable 'VOYAGEAI_EMBEDDING_MODEL'."),
+			errors.New("field 'endpoint' is required; use option 'VoyageAIEmbedderWithEndpoint()' or set the environment variable 'VOYAGEAI_EMBEDDING_ENDPOINT'."),
 		)
 	}
+	if err = vectorizer.api.setup(); err != nil {
+		return nil, err
+	}
 
 	return vectorizer, nil
 }
 
 // Returns the total number of VoyageAI tokens used by this client.
 func (v *VoyageAIEmbedder) TotalTokensUsed() int {
-	return v.totalTokensUsed
+	return int(v.totalTokensUsed.Load())
+}
+
+// Returns the [VoyageAIEmbedder]s configured endpoint URL.
+func (v *VoyageAIEmbedder) Endpoint() string {
+	return v.endpoint
+}
+
+// Returns the [VoyageAIEmbedder]s configured model.
+func (v *VoyageAIEmbedder) Model() string {
+	return v.model
+}
+
+// Returns the [VoyageAIEmbedder]s configured timeout for each request attempt.
+func (v *VoyageAIEmbedder) Timeout() time.Duration {
+	return v.api.timeout
+}
+
+// Returns the [VoyageAIEmbedder]s configured maximum number of retries.
+func (v *VoyageAIEmbedder) MaxRetries() int {
+	return v.api.maxRetries
+}
+
+// Returns the [VoyageAIEmbedder]s configured minimum and maximum backoff between
+// retries.
+func (v *VoyageAIEmbedder) Backoff() (minBackoff, maxBackoff time.Duration) {
+	return v.api.minBackoff, v.api.maxBackoff
+}
+
+// Returns the [VoyageAIEmbedder]s configured maximum number of chunks and
+// estimated tokens in each request, where 0 is unlimited.
+func (v *VoyageAIEmbedder) BatchLimits() (maxItems, maxTokens int) {
+	return v.api.batchItems, v.api.batchTokens
+}
+
+// Returns the [VoyageAIEmbedder]s configured maximum number of requests made at
+// once.
+func (v *VoyageAIEmbedder) Concurrency() int {
+	return v.api.concurrency
+}
+
+// Returns the [VoyageAIEmbedder]s configured client-side rate limits, where 0
+// is unlimited.
+func (v *VoyageAIEmbedder) RateLimit() (requestsPerMinute, tokensPerMinute int) {
+	return v.api.rpm, v.api.tpm
 }
 
 // ############################################################################
@@ -146,67 +231,142 @@ func VoyageAIEmbedderWithEndpoint(endpoint string) VoyageAIEmbedderOption {
 	}
 }
 
+// VoyageAIEmbedderWithHTTPClient sets the [http.Client] to use with the
+// [VoyageAIEmbedder].
+func VoyageAIEmbedderWithHTTPClient(client *http.Client) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.client = client
+	}
+}
+
+// VoyageAIEmbedderWithTimeout sets the timeout for each request attempt made by
+// the [VoyageAIEmbedder]; 0 is no timeout other than the context's.
+func VoyageAIEmbedderWithTimeout(timeout time.Duration) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.timeout = timeout
+	}
+}
+
+// VoyageAIEmbedderWithRetries sets the maximum number of times the
+// [VoyageAIEmbedder] retries a request which was rate limited, timed out, or
+// failed with a server or network error; 0 disables retries.
+func VoyageAIEmbedderWithRetries(maxRetries int) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.maxRetries = maxRetries
+	}
+}
+
+// VoyageAIEmbedderWithBackoff sets the exponential backoff between the
+// [VoyageAIEmbedder]s retries, which starts at minBackoff and doubles after each
+// retry up to maxBackoff. A "Retry-After" header from the API is used instead
+// when it is present.
+func VoyageAIEmbedderWithBackoff(minBackoff, maxBackoff time.Duration) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.minBackoff = minBackoff
+		c.api.maxBackoff = maxBackoff
+	}
+}
+
+// VoyageAIEmbedderWithBatchLimits sets the maximum number of chunks and
+// estimated tokens (see [EstimateTokens]) in each request made by the
+// [VoyageAIEmbedder]; larger inputs are split into several requests. 0 is
+// unlimited. Use this to raise the token limit for models which allow more
+// tokens per request than the default.
+func VoyageAIEmbedderWithBatchLimits(maxItems, maxTokens int) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.batchItems = maxItems
+		c.api.batchTokens = maxTokens
+	}
+}
+
+// VoyageAIEmbedderWithConcurrency sets the maximum number of requests the
+// [VoyageAIEmbedder] makes at once when an input is split into several batches.
+func VoyageAIEmbedderWithConcurrency(concurrency int) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.concurrency = concurrency
+	}
+}
+
+// VoyageAIEmbedderWithRateLimit sets client-side rate limits for the
+// [VoyageAIEmbedder], which waits before each request so no more than the
+// number of requests and tokens per minute are used (e.g. to match the limits
+// of a VoyageAI account); 0 is unlimited. The estimated tokens of each request
+// (see [EstimateTokens]) are reserved before it is sent, so concurrent batches
+// stay under the limit, and are corrected to the usage reported by the API.
+func VoyageAIEmbedderWithRateLimit(requestsPerMinute, tokensPerMinute int) VoyageAIEmbedderOption {
+	return func(c *VoyageAIEmbedder) {
+		c.api.rpm = requestsPerMinute
+		c.api.tpm = tokensPerMinute
+	}
+}
+
 // ############################################################################
 // VoyageAI Text Embedding Functionality
 // ############################################################################
 
 // Performs embedding vectorization on a chunk of text using the VoyageAI API.
 func (v *VoyageAIEmbedder) Vectorize(chunk string) (embedding vector.Vector, err error) {
-	if embeddings, err := v.VectorizeAll([]string{chunk}); err != nil {
+	return v.VectorizeContext(context.Background(), chunk)
+}
+
+// Performs embedding vectorization on a chunk of text using the VoyageAI API,
+// stopping early if the context is done.
+func (v *VoyageAIEmbedder) VectorizeContext(ctx context.Context, chunk string) (embedding vector.Vector, err error) {
+	var embeddings []vector.Vector
+	if embeddings, err = v.makeTextEmbeddingsRequest(ctx, &VoyageAIEmbeddingsRequest{Model: v.model, Input: []string{chunk}}); err != nil {
 		return nil, err
-	} else {
-		return embeddings[0], nil
 	}
+	return embeddings[0], nil
 }
 
-// Performs embedding vectorization on several chunks of text in a single
-// request using the VoyageAI API.
+// Performs embedding vectorization on several chunks of text using the VoyageAI
+// API (see [VoyageAIEmbedder.VectorizeAllContext]).
 func (v *VoyageAIEmbedder) VectorizeAll(chunks []string) (embeddings []vector.Vector, err error) {
-	data := &VoyageAIEmbeddingsRequest{
-		Model: v.model,
-		Input: chunks,
-	}
-	return v.makeTextEmbeddingsRequest(data)
+	return v.VectorizeAllContext(context.Background(), chunks)
 }
 
-// Makes a request to the VoyageAI
-func (v *VoyageAIEmbedder) makeTextEmbeddingsRequest(data *VoyageAIEmbeddingsRequest) (embeddings []vector.Vector, err error) {
-	var (
-		jsonData []byte
-		request  *http.Request
-		response *http.Response
-		body     *VoyageAIEmbeddingsResponse
+// Performs embedding vectorization on several chunks of text using the VoyageAI
+// API, stopping early if the context is done. The chunks are split into
+// batches within the configured batch limits, which are requested
+// concurrently, and the embeddings are returned in the same order as the
+// chunks. If any of the batches fail, a [BatchError] is returned with the
+// embeddings of the batches which succeeded, which reports the failed chunks.
+func (v *VoyageAIEmbedder) VectorizeAllContext(ctx context.Context, chunks []string) (embeddings []vector.Vector, err error) {
+	return embedBatches(ctx, chunks, v.api.batchItems, v.api.batchTokens, v.api.concurrency,
+		func(ctx context.Context, batch []string) ([]vector.Vector, error) {
+			data := &VoyageAIEmbeddingsRequest{
+				Model: v.model,
+				Input: batch,
+			}
+			return v.makeTextEmbeddingsRequest(ctx, data)
+		},
 	)
+}
 
-	// Marshal JSON body
-	if jsonData, err = json.Marshal(data); err != nil {
-		return nil, errors.Join(err, errors.New("error encoding VoyageAIEmbeddingsRequest"))
-	}
-
-	// Create HTTP request
-	if request, err = http.NewRequest("POST", v.endpoint, bytes.NewBuffer(jsonData)); err != nil {
-		return nil, errors.Join(err, errors.New("error creating embeddings http request"))
-	}
-
-	// Set headers
-	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", v.apiKey))
-	request.Header.Set("Content-Type", "application/json")
-
-	// Make request
-	if response, err = v.client.Do(request); err != nil {
-		return nil, errors.Join(err, errors.New("embeddings request failed"))
+// Makes a request to the VoyageAI embeddings API, retrying and rate limiting it
+// as configured.
+func (v *VoyageAIEmbedder) makeTextEmbeddingsRequest(ctx context.Context, data *VoyageAIEmbeddingsRequest) (embeddings []vector.Vector, err error) {
+	headers := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", v.apiKey),
 	}
 
-	// Unmarshal data
-	err = json.NewDecoder(response.Body).Decode(&body)
-	if err != nil {
-		return nil, errors.Join(err, errors.New("error decoding VoyageAIEmbeddingsResponse"))
+	var body *VoyageAIEmbeddingsResponse
+	tokens := estimateBatchTokens(data.Input)
+	if err = v.api.post(ctx, v.endpoint, headers, tokens, data, &body); err != nil {
+		return nil, err
 	}
 
 	// Count the tokens used so we have a running total for this client
-	v.totalTokensUsed += body.Usage.TotalTokens
+	v.totalTokensUsed.Add(int64(body.Usage.TotalTokens))
+	v.api.settle(tokens, body.Usage.TotalTokens)
 
-	// Gather and return the embeddings
+	// Gather and return the embeddings in the order of the input
+	if len(body.Data) != len(data.Input) {
+		return nil, errors.Join(errors.ErrUnequalLengthInputs, fmt.Errorf("expected %d embeddings but the response has %d", len(data.Input), len(body.Data)))
+	}
+	slices.SortFunc(body.Data, func(a, b *VoyageAIEmbedding) int {
+		return a.Index - b.Index
+	})
 	for _, embedding := range body.Data {
 		embeddings = append(embeddings, embedding.Embedding)
 	}
@@ -1,12 +1,22 @@
 package vectorize_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
 	"go.rtnl.ai/nlp/vectorize"
 )
 
@@ -68,3 +78,391 @@ func TestVoyageAIEmbedder(t *testing.T) {
 	// How many tokens were used for the tests?
 	t.Logf("used %d tokens for TestVoyageAIEmbedder", voyage.TotalTokensUsed())
 }
+
+// Returns a stand-in for the VoyageAI embeddings API which responds to each
+// request with the next status code (repeating the last one), with an embedding
+// of [len(input), i] for each input i when the status is 200.
+func voyageAIServer(t *testing.T, requests *atomic.Int32, tokens int, statuses ...int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1)) - 1
+		status := statuses[min(n, len(statuses)-1)]
+
+		// Use assert since require cannot stop the test from the handler goroutine
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var request vectorize.VoyageAIEmbeddingsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "test-model", request.Model)
+
+		switch status {
+		case http.StatusOK:
+			// Respond with the embeddings in reverse order
+			response := vectorize.VoyageAIEmbeddingsResponse{Object: "list", Model: request.Model}
+			for i := len(request.Input) - 1; i >= 0; i-- {
+				response.Data = append(response.Data, &vectorize.VoyageAIEmbedding{
+					Object:    "embedding",
+					Embedding: []float64{float64(len(request.Input[i])), float64(i)},
+					Index:     i,
+				})
+			}
+			response.Usage.TotalTokens = tokens
+			json.NewEncoder(w).Encode(response)
+		case http.StatusTooManyRequests:
+			w.Header().Set("Retry-After", "0.05")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"detail": "You have exceeded your rate limit."}`))
+		default:
+			w.WriteHeader(status)
+			w.Write([]byte(`{"detail": "Something went wrong."}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// Returns a [vectorize.VoyageAIEmbedder] for the test server with fast retries.
+func newTestVoyageAIEmbedder(t *testing.T, server *httptest.Server, opts ...vectorize.VoyageAIEmbedderOption) *vectorize.VoyageAIEmbedder {
+	opts = append([]vectorize.VoyageAIEmbedderOption{
+		vectorize.VoyageAIEmbedderWithAPIKey("test-key"),
+		vectorize.VoyageAIEmbedderWithEndpoint(server.URL),
+		vectorize.VoyageAIEmbedderWithModel("test-model"),
+		vectorize.VoyageAIEmbedderWithBackoff(time.Millisecond, 10*time.Millisecond),
+	}, opts...)
+	voyage, err := vectorize.NewVoyageAIEmbedder(opts...)
+	require.NoError(t, err)
+	return voyage
+}
+
+func TestNewVoyageAIEmbedderOptions(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		voyage, err := vectorize.NewVoyageAIEmbedder(
+			vectorize.VoyageAIEmbedderWithAPIKey("test-key"),
+			vectorize.VoyageAIEmbedderWithEndpoint("http://localhost"),
+			vectorize.VoyageAIEmbedderWithModel("test-model"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, vectorize.DefaultAPITimeout, voyage.Timeout())
+		require.Equal(t, vectorize.DefaultAPIMaxRetries, voyage.MaxRetries())
+
+		minBackoff, maxBackoff := voyage.Backoff()
+		require.Equal(t, vectorize.DefaultAPIMinBackoff, minBackoff)
+		require.Equal(t, vectorize.DefaultAPIMaxBackoff, maxBackoff)
+
+		rpm, tpm := voyage.RateLimit()
+		require.Zero(t, rpm)
+		require.Zero(t, tpm)
+	})
+
+	t.Run("ErrorNegativeRetries", func(t *testing.T) {
+		_, err := vectorize.NewVoyageAIEmbedder(
+			vectorize.VoyageAIEmbedderWithAPIKey("test-key"),
+			vectorize.VoyageAIEmbedderWithEndpoint("http://localhost"),
+			vectorize.VoyageAIEmbedderWithModel("test-model"),
+			vectorize.VoyageAIEmbedderWithRetries(-1),
+		)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+}
+
+func TestVoyageAIEmbedderHTTP(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var requests atomic.Int32
+		voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, &requests, 7, http.StatusOK))
+
+		embeddings, err := voyage.VectorizeAllContext(context.Background(), []string{"a", "bb", "ccc"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{1, 0}, {2, 1}, {3, 2}}, embeddings)
+
+		embedding, err := voyage.Vectorize("dddd")
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{4, 0}, embedding)
+
+		require.Equal(t, int32(2), requests.Load())
+		require.Equal(t, 14, voyage.TotalTokensUsed())
+	})
+
+	t.Run("RetryRateLimitAndServerErrors", func(t *testing.T) {
+		var requests atomic.Int32
+		server := voyageAIServer(t, &requests, 1, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK)
+		voyage := newTestVoyageAIEmbedder(t, server)
+
+		// The first retry waits for the Retry-After header
+		start := time.Now()
+		embedding, err := voyage.Vectorize("a")
+		require.NoError(t, err)
+		require.Equal(t, vector.Vector{1, 0}, embedding)
+		require.Equal(t, int32(3), requests.Load())
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("ErrorRetriesExhausted", func(t *testing.T) {
+		var requests atomic.Int32
+		voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, &requests, 1, http.StatusServiceUnavailable),
+			vectorize.VoyageAIEmbedderWithRetries(2),
+		)
+
+		_, err := voyage.Vectorize("a")
+		require.ErrorIs(t, err, errors.ErrServerError)
+		require.Equal(t, int32(3), requests.Load())
+
+		var apiErr *vectorize.APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		require.Equal(t, "Something went wrong.", apiErr.Message)
+	})
+
+	t.Run("ErrorNotRetried", func(t *testing.T) {
+		for status, target := range map[int]error{
+			http.StatusBadRequest:   errors.ErrBadRequest,
+			http.StatusUnauthorized: errors.ErrUnauthorized,
+		} {
+			var requests atomic.Int32
+			voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, &requests, 1, status))
+
+			_, err := voyage.Vectorize("a")
+			require.ErrorIs(t, err, target)
+			require.Equal(t, int32(1), requests.Load())
+		}
+	})
+
+	t.Run("ErrorRateLimitedNoRetries", func(t *testing.T) {
+		var requests atomic.Int32
+		voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, &requests, 1, http.StatusTooManyRequests),
+			vectorize.VoyageAIEmbedderWithRetries(0),
+		)
+
+		_, err := voyage.Vectorize("a")
+		require.ErrorIs(t, err, errors.ErrRateLimited)
+
+		var apiErr *vectorize.APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, 50*time.Millisecond, apiErr.RetryAfter)
+		require.Equal(t, "You have exceeded your rate limit.", apiErr.Message)
+	})
+
+	t.Run("ErrorTimeout", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		// Each attempt times out and is retried
+		voyage := newTestVoyageAIEmbedder(t, server,
+			vectorize.VoyageAIEmbedderWithTimeout(20*time.Millisecond),
+			vectorize.VoyageAIEmbedderWithRetries(1),
+		)
+		_, err := voyage.Vectorize("a")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, int32(2), requests.Load())
+
+		// The context stops the retries
+		voyage = newTestVoyageAIEmbedder(t, server,
+			vectorize.VoyageAIEmbedderWithTimeout(0),
+			vectorize.VoyageAIEmbedderWithRetries(5),
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		_, err = voyage.VectorizeContext(ctx, "a")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		// The first request uses 100 more tokens than the limit of 200 per second,
+		// so the second waits half a second for the debt to be paid off
+		var requests atomic.Int32
+		voyage := newTestVoyageAIEmbedder(t, voyageAIServer(t, &requests, 12100, http.StatusOK),
+			vectorize.VoyageAIEmbedderWithRateLimit(0, 12000),
+		)
+
+		start := time.Now()
+		_, err := voyage.Vectorize("a")
+		require.NoError(t, err)
+		require.Less(t, time.Since(start), 250*time.Millisecond)
+
+		_, err = voyage.Vectorize("b")
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+}
+
+func TestVoyageAIEmbedderBatching(t *testing.T) {
+	// A stand-in server which embeds each input as [len(input)], fails any
+	// request with an input of "bad", and tracks the requests in flight
+	var requests, inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		var request vectorize.VoyageAIEmbeddingsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.LessOrEqual(t, len(request.Input), 2)
+
+		response := vectorize.VoyageAIEmbeddingsResponse{}
+		for i, input := range request.Input {
+			if input == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"detail": "bad input"}`))
+				return
+			}
+			response.Data = append(response.Data, &vectorize.VoyageAIEmbedding{Embedding: []float64{float64(len(input))}, Index: i})
+		}
+		response.Usage.TotalTokens = len(request.Input)
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("Options", func(t *testing.T) {
+		voyage := newTestVoyageAIEmbedder(t, server)
+		maxItems, maxTokens := voyage.BatchLimits()
+		require.Equal(t, vectorize.VoyageAIMaxBatchItems, maxItems)
+		require.Equal(t, vectorize.VoyageAIMaxBatchTokens, maxTokens)
+		require.Equal(t, vectorize.DefaultAPIConcurrency, voyage.Concurrency())
+
+		_, err := vectorize.NewVoyageAIEmbedder(
+			vectorize.VoyageAIEmbedderWithAPIKey("test-key"),
+			vectorize.VoyageAIEmbedderWithEndpoint(server.URL),
+			vectorize.VoyageAIEmbedderWithModel("test-model"),
+			vectorize.VoyageAIEmbedderWithConcurrency(0),
+		)
+		require.ErrorIs(t, err, errors.ErrMissingConfig)
+	})
+
+	t.Run("SplitsAndReassembles", func(t *testing.T) {
+		requests.Store(0)
+		maxInFlight.Store(0)
+		voyage := newTestVoyageAIEmbedder(t, server,
+			vectorize.VoyageAIEmbedderWithBatchLimits(2, 0),
+			vectorize.VoyageAIEmbedderWithConcurrency(2),
+		)
+
+		embeddings, err := voyage.VectorizeAll([]string{"a", "bb", "ccc", "dddd", "eeeee", "ffffff", "g"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{1}, {2}, {3}, {4}, {5}, {6}, {1}}, embeddings)
+		require.Equal(t, int32(4), requests.Load())
+		require.Equal(t, int32(2), maxInFlight.Load())
+		require.Equal(t, 7, voyage.TotalTokensUsed())
+	})
+
+	t.Run("TokenLimit", func(t *testing.T) {
+		requests.Store(0)
+		voyage := newTestVoyageAIEmbedder(t, server,
+			vectorize.VoyageAIEmbedderWithBatchLimits(0, 4),
+		)
+
+		// Each chunk is estimated at 2 tokens, except the last which is over the
+		// limit by itself
+		embeddings, err := voyage.VectorizeAll([]string{"aaaa", "bbbb", "cccc", "dddddddddddddddd"})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{4}, {4}, {4}, {16}}, embeddings)
+		require.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("TokenRateLimit", func(t *testing.T) {
+		// Each chunk is estimated at 25,000 tokens, so only two of the
+		// concurrent batches fit in the limit of 60,000 tokens per minute; the
+		// server reports 1 token used, so the third is sent once one settles
+		chunk := strings.Repeat("a", 75_000)
+		requests.Store(0)
+		maxInFlight.Store(0)
+		voyage := newTestVoyageAIEmbedder(t, server,
+			vectorize.VoyageAIEmbedderWithBatchLimits(1, 0),
+			vectorize.VoyageAIEmbedderWithConcurrency(3),
+			vectorize.VoyageAIEmbedderWithRateLimit(0, 60_000),
+		)
+
+		embeddings, err := voyage.VectorizeAll([]string{chunk, chunk, chunk})
+		require.NoError(t, err)
+		require.Equal(t, []vector.Vector{{75_000}, {75_000}, {75_000}}, embeddings)
+		require.Equal(t, int32(3), requests.Load())
+		require.Equal(t, int32(2), maxInFlight.Load())
+	})
+
+	t.Run("TokenRateLimitUsage", func(t *testing.T) {
+		// The server reports the estimated tokens as used, so the third batch
+		// waits for the limit to refill and the context ends first
+		var used atomic.Int32
+		usageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request vectorize.VoyageAIEmbeddingsRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+			response := vectorize.VoyageAIEmbeddingsResponse{}
+			for i, input := range request.Input {
+				response.Data = append(response.Data, &vectorize.VoyageAIEmbedding{Embedding: []float64{float64(len(input))}, Index: i})
+				response.Usage.TotalTokens += vectorize.EstimateTokens(input)
+			}
+			used.Add(int32(response.Usage.TotalTokens))
+			json.NewEncoder(w).Encode(response)
+		}))
+		t.Cleanup(usageServer.Close)
+
+		chunk := strings.Repeat("a", 75_000)
+		voyage := newTestVoyageAIEmbedder(t, usageServer,
+			vectorize.VoyageAIEmbedderWithBatchLimits(1, 0),
+			vectorize.VoyageAIEmbedderWithConcurrency(3),
+			vectorize.VoyageAIEmbedderWithRateLimit(0, 60_000),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		embeddings, err := voyage.VectorizeAllContext(ctx, []string{chunk, chunk, chunk})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Len(t, embeddings, 3)
+		require.Equal(t, int32(50_000), used.Load())
+		require.Equal(t, 50_000, voyage.TotalTokensUsed())
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		voyage := newTestVoyageAIEmbedder(t, server,
+			vectorize.VoyageAIEmbedderWithBatchLimits(2, 0),
+		)
+
+		embeddings, err := voyage.VectorizeAll([]string{"a", "bb", "bad", "dddd", "eeeee", "ffffff", "bad"})
+		require.ErrorIs(t, err, errors.ErrBadRequest)
+		require.Equal(t, []vector.Vector{{1}, {2}, nil, nil, {5}, {6}, nil}, embeddings)
+
+		var batchErr *vectorize.BatchError
+		require.True(t, errors.As(err, &batchErr))
+		require.Equal(t, []int{2, 3, 6}, batchErr.Indexes())
+		require.Len(t, batchErr.Failures, 2)
+		require.Equal(t, 2, batchErr.Failures[0].Start)
+		require.Equal(t, 4, batchErr.Failures[0].End)
+
+		var apiErr *vectorize.APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, "bad input", apiErr.Message)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		voyage := newTestVoyageAIEmbedder(t, server)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		embeddings, err := voyage.VectorizeAllContext(ctx, []string{"a", "b"})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, []vector.Vector{nil, nil}, embeddings)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		requests.Store(0)
+		voyage := newTestVoyageAIEmbedder(t, server)
+		embeddings, err := voyage.VectorizeAll(nil)
+		require.NoError(t, err)
+		require.Empty(t, embeddings)
+		require.Zero(t, requests.Load())
+	})
+}
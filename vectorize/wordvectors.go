@@ -0,0 +1,286 @@
+package vectorize
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+)
+
+// ############################################################################
+// WordVectorsFormat "enum"
+// ############################################################################
+
+// WordVectorsFormat is the file format of pre-trained word vectors.
+type WordVectorsFormat uint8
+
+const (
+	FormatUnknown WordVectorsFormat = iota
+	// The word2vec binary format: a "<words> <dimensions>" header line, then each
+	// word followed by a space and its elements as little-endian float32s.
+	FormatWord2VecBinary
+	// The word2vec text format: a "<words> <dimensions>" header line, then a line
+	// for each word with the word and its elements separated by spaces.
+	FormatWord2VecText
+	// The GloVe text format: the word2vec text format without the header line.
+	FormatGloVe
+)
+
+// ############################################################################
+// WordVectors
+// ############################################################################
+
+/*
+WordVectors are pre-trained static word embeddings, such as word2vec, GloVe or
+fastText vectors, which map each word in a vocabulary to a vector; load with
+[LoadWordVectors] or [ReadWordVectors]. The vectors are kept as float32s in a
+single block of memory, which is half the size of [vector.Vector]s.
+
+Pre-trained vectors are usually sorted by descending frequency, so the rank of a
+word (its position in the file) can be used to estimate its frequency (see
+[PoolingSIF]), and the vocabulary can be limited to the most frequent words to
+save memory.
+
+Usage example:
+
+	// Load the 100,000 most frequent words
+	glove, err := vectorize.LoadWordVectors("glove.6B.100d.txt", vectorize.FormatGloVe, 100_000)
+
+	if embedding, ok := glove.Vector("cat"); ok {
+		// ...
+	}
+*/
+type WordVectors struct {
+	dim   int
+	words []string
+	index map[string]int
+	data  []float32 // the elements of the vector of word i are at [i*dim, (i+1)*dim)
+}
+
+// Returns [WordVectors] with the words and their vectors, which must all have
+// the same number of elements. Returns [errors.ErrUnequalLengthInputs] if there
+// is not a vector for every word, or [errors.ErrUnequalLengthVectors] if the
+// vectors have different lengths.
+func NewWordVectors(words []string, vectors []vector.Vector32) (wv *WordVectors, err error) {
+	if len(words) != len(vectors) {
+		return nil, errors.ErrUnequalLengthInputs
+	}
+
+	var dim int
+	if len(vectors) > 0 {
+		dim = len(vectors[0])
+	}
+	wv = newWordVectors(dim, len(words))
+	for i, word := range words {
+		if len(vectors[i]) != dim {
+			return nil, errors.ErrUnequalLengthVectors
+		}
+		wv.add(word, vectors[i])
+	}
+	return wv, nil
+}
+
+// Returns empty [WordVectors] with room for the number of words.
+func newWordVectors(dim, words int) *WordVectors {
+	return &WordVectors{
+		dim:   dim,
+		words: make([]string, 0, words),
+		index: make(map[string]int, words),
+		data:  make([]float32, 0, words*dim),
+	}
+}
+
+// Adds the word and its vector unless the word was already added, since the
+// first vector is for the most frequent form in a pre-trained file.
+func (w *WordVectors) add(word string, values []float32) {
+	if _, ok := w.index[word]; ok {
+		return
+	}
+	w.index[word] = len(w.words)
+	w.words = append(w.words, word)
+	w.data = append(w.data, values...)
+}
+
+// Returns the number of words in the [WordVectors].
+func (w *WordVectors) Len() int {
+	return len(w.words)
+}
+
+// Returns the number of elements in each of the vectors.
+func (w *WordVectors) Dim() int {
+	return w.dim
+}
+
+// Returns the words in the order they were loaded, which is usually descending
+// frequency. The slice must not be modified.
+func (w *WordVectors) Words() []string {
+	return w.words
+}
+
+// Returns the position of the word in [WordVectors.Words] starting at 0, or
+// false if it has no vector.
+func (w *WordVectors) Rank(word string) (rank int, ok bool) {
+	rank, ok = w.index[word]
+	return rank, ok
+}
+
+// Returns the vector of the word, or false if it has no vector. The vector must
+// not be modified.
+func (w *WordVectors) Vector(word string) (embedding vector.Vector32, ok bool) {
+	var i int
+	if i, ok = w.index[word]; !ok {
+		return nil, false
+	}
+	return w.data[i*w.dim : (i+1)*w.dim : (i+1)*w.dim], true
+}
+
+// ############################################################################
+// Loading
+// ############################################################################
+
+// Returns the [WordVectors] loaded from the file at path in the format (see
+// [ReadWordVectors]).
+func LoadWordVectors(path string, format WordVectorsFormat, maxWords int) (wv *WordVectors, err error) {
+	var file *os.File
+	if file, err = os.Open(path); err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadWordVectors(file, format, maxWords)
+}
+
+// Returns the [WordVectors] read from r in the format, stopping after maxWords
+// words if it is positive. Words which appear more than once keep their first
+// vector. Returns [errors.ErrMethodNotSupported] for an unknown format.
+func ReadWordVectors(r io.Reader, format WordVectorsFormat, maxWords int) (wv *WordVectors, err error) {
+	reader := bufio.NewReaderSize(r, 1<<16)
+	switch format {
+	case FormatWord2VecBinary:
+		return readWord2VecBinary(reader, maxWords)
+	case FormatWord2VecText:
+		return readWordVectorsText(reader, true, maxWords)
+	case FormatGloVe:
+		return readWordVectorsText(reader, false, maxWords)
+	default:
+		return nil, errors.ErrMethodNotSupported
+	}
+}
+
+// Reads the word2vec "<words> <dimensions>" header line.
+func readWord2VecHeader(reader *bufio.Reader) (words, dim int, err error) {
+	var line string
+	if line, err = reader.ReadString('\n'); err != nil && (err != io.EOF || line == "") {
+		return 0, 0, errors.Join(err, errors.New("error reading word vectors header"))
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid word vectors header %q", strings.TrimSpace(line))
+	}
+	if words, err = strconv.Atoi(fields[0]); err != nil || words < 0 {
+		return 0, 0, fmt.Errorf("invalid word vectors header %q", strings.TrimSpace(line))
+	}
+	if dim, err = strconv.Atoi(fields[1]); err != nil || dim <= 0 {
+		return 0, 0, fmt.Errorf("invalid word vectors header %q", strings.TrimSpace(line))
+	}
+	return words, dim, nil
+}
+
+// Reads the word2vec binary format.
+func readWord2VecBinary(reader *bufio.Reader, maxWords int) (wv *WordVectors, err error) {
+	var words, dim int
+	if words, dim, err = readWord2VecHeader(reader); err != nil {
+		return nil, err
+	}
+	if maxWords > 0 {
+		words = min(words, maxWords)
+	}
+
+	wv = newWordVectors(dim, words)
+	values := make([]float32, dim)
+	buf := make([]byte, 4*dim)
+	for i := range words {
+		// The word ends at a space; vectors may be followed by a newline, which
+		// is then at the start of the next word
+		var word string
+		if word, err = reader.ReadString(' '); err != nil {
+			return nil, errors.Join(err, fmt.Errorf("error reading word %d of %d", i+1, words))
+		}
+		word = strings.TrimLeft(word[:len(word)-1], "\n")
+
+		if _, err = io.ReadFull(reader, buf); err != nil {
+			return nil, errors.Join(err, fmt.Errorf("error reading the vector of %q", word))
+		}
+		for j := range values {
+			values[j] = math.Float32frombits(binary.LittleEndian.Uint32(buf[j*4:]))
+		}
+		wv.add(word, values)
+	}
+	return wv, nil
+}
+
+// Reads the word2vec text format, or the GloVe format if there is no header, in
+// which case the dimensions are the number of values on the first line.
+func readWordVectorsText(reader *bufio.Reader, header bool, maxWords int) (wv *WordVectors, err error) {
+	var words, dim int
+	if header {
+		if words, dim, err = readWord2VecHeader(reader); err != nil {
+			return nil, err
+		}
+		if maxWords > 0 {
+			words = min(words, maxWords)
+		}
+		wv = newWordVectors(dim, words)
+	}
+
+	var values []float32
+	var read int
+	for n := 1; maxWords <= 0 || read < maxWords; n++ {
+		var line string
+		if line, err = reader.ReadString('\n'); err != nil && err != io.EOF {
+			return nil, errors.Join(err, fmt.Errorf("error reading word vectors line %d", n))
+		}
+		eof := err == io.EOF
+
+		// Words may contain spaces, so the elements are the last fields
+		if fields := strings.Fields(line); len(fields) > 0 {
+			if wv == nil {
+				dim = len(fields) - 1
+				wv = newWordVectors(dim, max(maxWords, 0))
+			}
+			if dim <= 0 || len(fields) < dim+1 {
+				return nil, fmt.Errorf("word vectors line %d does not have a word and %d values", n, dim)
+			}
+
+			values = values[:0]
+			for _, field := range fields[len(fields)-dim:] {
+				var value float64
+				if value, err = strconv.ParseFloat(field, 32); err != nil {
+					return nil, errors.Join(err, fmt.Errorf("invalid value on word vectors line %d", n))
+				}
+				values = append(values, float32(value))
+			}
+			wv.add(strings.Join(fields[:len(fields)-dim], " "), values)
+			read++
+		}
+
+		if eof {
+			break
+		}
+	}
+
+	if wv == nil {
+		wv = newWordVectors(0, 0)
+	}
+	if header && read < words {
+		return nil, fmt.Errorf("expected %d word vectors but read %d", words, read)
+	}
+	return wv, nil
+}
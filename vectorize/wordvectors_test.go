@@ -0,0 +1,133 @@
+package vectorize_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.rtnl.ai/nlp/errors"
+	"go.rtnl.ai/nlp/vector"
+	"go.rtnl.ai/nlp/vectorize"
+)
+
+// Returns the words and vectors in the word2vec binary format, with a newline
+// after each vector like the original word2vec tool.
+func word2VecBinary(header string, words []string, vectors []vector.Vector32) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header + "\n")
+	for i, word := range words {
+		buf.WriteString(word + " ")
+		for _, e := range vectors[i] {
+			binary.Write(&buf, binary.LittleEndian, math.Float32bits(e))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func TestReadWordVectors(t *testing.T) {
+	words := []string{"the", "cat", "New_York"}
+	vectors := []vector.Vector32{{0.5, -1}, {2, 0.25}, {-3, 4}}
+
+	requireWordVectors := func(t *testing.T, wv *vectorize.WordVectors, n int) {
+		require.Equal(t, n, wv.Len())
+		require.Equal(t, 2, wv.Dim())
+		require.Equal(t, words[:n], wv.Words())
+		for i, word := range words[:n] {
+			embedding, ok := wv.Vector(word)
+			require.True(t, ok, "missing vector for %q", word)
+			require.Equal(t, vectors[i], embedding)
+
+			rank, ok := wv.Rank(word)
+			require.True(t, ok)
+			require.Equal(t, i, rank)
+		}
+		_, ok := wv.Vector("dog")
+		require.False(t, ok)
+	}
+
+	t.Run("Word2VecBinary", func(t *testing.T) {
+		data := word2VecBinary("3 2", words, vectors)
+		wv, err := vectorize.ReadWordVectors(bytes.NewReader(data), vectorize.FormatWord2VecBinary, 0)
+		require.NoError(t, err)
+		requireWordVectors(t, wv, 3)
+
+		wv, err = vectorize.ReadWordVectors(bytes.NewReader(data), vectorize.FormatWord2VecBinary, 2)
+		require.NoError(t, err)
+		requireWordVectors(t, wv, 2)
+
+		_, err = vectorize.ReadWordVectors(bytes.NewReader(data[:len(data)-4]), vectorize.FormatWord2VecBinary, 0)
+		require.Error(t, err, "a truncated file should be an error")
+	})
+
+	t.Run("Word2VecText", func(t *testing.T) {
+		data := "3 2\nthe 0.5 -1 \ncat 2 0.25 \r\nNew_York -3 4\n"
+		wv, err := vectorize.ReadWordVectors(strings.NewReader(data), vectorize.FormatWord2VecText, 0)
+		require.NoError(t, err)
+		requireWordVectors(t, wv, 3)
+
+		wv, err = vectorize.ReadWordVectors(strings.NewReader(data), vectorize.FormatWord2VecText, 1)
+		require.NoError(t, err)
+		requireWordVectors(t, wv, 1)
+
+		_, err = vectorize.ReadWordVectors(strings.NewReader("4 2\nthe 0.5 -1\n"), vectorize.FormatWord2VecText, 0)
+		require.Error(t, err, "fewer words than the header should be an error")
+	})
+
+	t.Run("GloVe", func(t *testing.T) {
+		// Repeated words keep their first vector and there is no final newline
+		data := "the 0.5 -1\ncat 2 0.25\n\nthe 9 9\nNew_York -3 4"
+		wv, err := vectorize.ReadWordVectors(strings.NewReader(data), vectorize.FormatGloVe, 0)
+		require.NoError(t, err)
+		requireWordVectors(t, wv, 3)
+
+		// Words in text formats may contain spaces
+		wv, err = vectorize.ReadWordVectors(strings.NewReader("the 0.5 -1\n. . . 2 0.25\n"), vectorize.FormatGloVe, 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"the", ". . ."}, wv.Words())
+
+		_, err = vectorize.ReadWordVectors(strings.NewReader("the 0.5 -1\ncat x 0.25\n"), vectorize.FormatGloVe, 0)
+		require.Error(t, err, "a value which is not a number should be an error")
+
+		wv, err = vectorize.ReadWordVectors(strings.NewReader(""), vectorize.FormatGloVe, 0)
+		require.NoError(t, err)
+		require.Zero(t, wv.Len())
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vectors.bin")
+		require.NoError(t, os.WriteFile(path, word2VecBinary("3 2", words, vectors), 0o644))
+
+		wv, err := vectorize.LoadWordVectors(path, vectorize.FormatWord2VecBinary, 0)
+		require.NoError(t, err)
+		requireWordVectors(t, wv, 3)
+	})
+
+	t.Run("ErrorHeader", func(t *testing.T) {
+		_, err := vectorize.ReadWordVectors(strings.NewReader("the 0.5 -1\n"), vectorize.FormatWord2VecText, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("ErrorUnknownFormat", func(t *testing.T) {
+		_, err := vectorize.ReadWordVectors(strings.NewReader(""), vectorize.FormatUnknown, 0)
+		require.ErrorIs(t, err, errors.ErrMethodNotSupported)
+	})
+}
+
+func TestNewWordVectors(t *testing.T) {
+	wv, err := vectorize.NewWordVectors([]string{"a", "b"}, []vector.Vector32{{1, 2}, {3, 4}})
+	require.NoError(t, err)
+	require.Equal(t, 2, wv.Len())
+	require.Equal(t, 2, wv.Dim())
+
+	_, err = vectorize.NewWordVectors([]string{"a"}, []vector.Vector32{{1, 2}, {3, 4}})
+	require.ErrorIs(t, err, errors.ErrUnequalLengthInputs)
+
+	_, err = vectorize.NewWordVectors([]string{"a", "b"}, []vector.Vector32{{1, 2}, {3}})
+	require.ErrorIs(t, err, errors.ErrUnequalLengthVectors)
+}